@@ -1,10 +1,16 @@
 package main
 
 import (
+	"context"
 	"log"
+	"time"
 
+	"github.com/Code-Linx/wallet-service/internal/auth"
+	"github.com/Code-Linx/wallet-service/internal/clock"
 	"github.com/Code-Linx/wallet-service/internal/config"
+	"github.com/Code-Linx/wallet-service/internal/fx"
 	"github.com/Code-Linx/wallet-service/internal/handlers"
+	"github.com/Code-Linx/wallet-service/internal/jobs"
 	"github.com/Code-Linx/wallet-service/internal/repositories"
 	"github.com/Code-Linx/wallet-service/internal/usecases"
 	"github.com/Code-Linx/wallet-service/pkg/database"
@@ -36,15 +42,129 @@ func main() {
 	// Initialize repositories
 	repos := repositories.NewRepositories(db)
 
+	// Load exchange rates
+	var opts []usecases.Option
+	if cfg.App.FXRatesFile != "" {
+		rates, err := fx.LoadStaticProvider(cfg.App.FXRatesFile)
+		if err != nil {
+			log.Fatalf("Failed to load exchange rates: %v", err)
+		}
+		opts = append(opts, usecases.WithRateProvider(rates))
+	}
+
+	opts = append(opts, usecases.WithRetryPolicy(usecases.RetryPolicy{
+		MaxAttempts: cfg.App.SchedulerMaxAttempts,
+		Backoff:     cfg.App.SchedulerRetryBackoff,
+	}))
+	opts = append(opts, usecases.WithApprovalPolicy(usecases.ApprovalPolicy{
+		DualApprovalThreshold: cfg.App.AdjustmentApprovalThreshold,
+		Thresholds:            cfg.App.AdjustmentApprovalThresholds,
+	}))
+
 	// Initialize use cases
-	useCases := usecases.NewUseCases(repos)
+	useCases := usecases.NewUseCases(repos, opts...)
+
+	// Start background jobs
+	jobs.Start(context.Background(),
+		jobs.Job{
+			Name:     "hold-expiry",
+			Interval: time.Minute,
+			Run: func() error {
+				_, err := useCases.Wallet.ExpireHolds()
+				return err
+			},
+		},
+		jobs.Job{
+			Name:     "escrow-expiry",
+			Interval: time.Minute,
+			Run: func() error {
+				_, err := useCases.Wallet.ExpireEscrows()
+				return err
+			},
+		},
+		jobs.Job{
+			Name:     "payment-request-expiry",
+			Interval: time.Minute,
+			Run: func() error {
+				_, err := useCases.PaymentRequest.ExpirePending()
+				return err
+			},
+		},
+		jobs.Job{
+			Name:     "promo-expiry",
+			Interval: time.Minute,
+			Run: func() error {
+				_, err := useCases.Promo.ExpireBuckets()
+				return err
+			},
+		},
+		jobs.Job{
+			Name:     "overdraft-interest",
+			Interval: time.Hour,
+			Run: func() error {
+				_, err := useCases.Wallet.ChargeOverdraftInterest()
+				return err
+			},
+		},
+		jobs.Job{
+			Name:     "interest",
+			Interval: time.Hour,
+			Run: func() error {
+				if _, err := useCases.Interest.AccrueDaily(); err != nil {
+					return err
+				}
+				_, err := useCases.Interest.PayMonthly()
+				return err
+			},
+		},
+		jobs.Job{
+			Name:     "scheduled-transfers",
+			Interval: time.Minute,
+			Run: func() error {
+				_, err := useCases.Scheduled.RunDue()
+				return err
+			},
+		},
+		jobs.Job{
+			Name:     "payout-batches",
+			Interval: 5 * time.Second,
+			Run: func() error {
+				_, err := useCases.Payout.ProcessPendingBatches()
+				return err
+			},
+		},
+	)
+
+	// Load the keys access tokens are signed with
+	keys, err := auth.NewKeyring(cfg.App.JWTKeyID, cfg.App.JWTSecret, cfg.App.JWTPreviousKeys)
+	if err != nil {
+		log.Fatalf("Failed to load JWT keys: %v", err)
+	}
+	tokens := auth.NewTokens(keys, cfg.App.JWTAccessTTL, cfg.App.JWTRefreshTTL, clock.Real())
+
+	// Money-moving requests must be signed once signing keys are configured
+	var signer *auth.RequestSigner
+	if len(cfg.App.RequestSigningKeys) > 0 {
+		signer, err = auth.NewRequestSigner(cfg.App.RequestSigningKeys, cfg.App.RequestSigningWindow, auth.NewMemoryNonceStore(clock.Real()), clock.Real())
+		if err != nil {
+			log.Fatalf("Failed to load request signing keys: %v", err)
+		}
+	} else {
+		log.Println("REQUEST_SIGNING_KEYS is not set; money-moving requests will not need a signature")
+	}
 
 	// Initialize handlers
-	handlers := handlers.NewHandlers(useCases)
+	handlers := handlers.NewHandlers(useCases, tokens, signer)
 
 	// Setup router
 	router := handlers.SetupRouter(handlers)
 
+	// API key allowlists check the client IP, so only believe forwarded
+	// addresses from our own proxies
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		log.Fatalf("Failed to set trusted proxies: %v", err)
+	}
+
 	// Start server
 	serverAddr := cfg.GetServerAddress()
 	log.Printf("Starting server on %s", serverAddr)
@@ -0,0 +1,49 @@
+// Command token mints an access token for an admin or a backend service,
+// signed with the configured JWT key:
+//
+//	go run ./cmd/token -role service -subject billing -ttl 720h
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/auth"
+	"github.com/Code-Linx/wallet-service/internal/clock"
+	"github.com/Code-Linx/wallet-service/internal/config"
+)
+
+func main() {
+	role := flag.String("role", string(auth.RoleService), "role to grant: support, finance, superadmin or service")
+	subject := flag.String("subject", "", "who the token is for, e.g. a service name or an email")
+	ttl := flag.Duration("ttl", 24*time.Hour, "how long the token is valid")
+	flag.Parse()
+
+	if *subject == "" {
+		log.Fatal("-subject is required")
+	}
+	if !slices.Contains(auth.AdminRoles, auth.Role(*role)) && *role != string(auth.RoleService) {
+		log.Fatal("-role must be support, finance, superadmin or service; user tokens are issued through the API")
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	keys, err := auth.NewKeyring(cfg.App.JWTKeyID, cfg.App.JWTSecret, cfg.App.JWTPreviousKeys)
+	if err != nil {
+		log.Fatalf("Failed to load JWT keys: %v", err)
+	}
+	tokens := auth.NewTokens(keys, cfg.App.JWTAccessTTL, cfg.App.JWTRefreshTTL, clock.Real())
+
+	token, err := tokens.Sign(*subject, auth.Role(*role), auth.TokenTypeAccess, *ttl)
+	if err != nil {
+		log.Fatalf("Failed to sign token: %v", err)
+	}
+	fmt.Println(token)
+}
@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"github.com/google/uuid"
+)
+
+// Role says what a caller may do
+type Role string
+
+const (
+	RoleUser       Role = "user"       // Acts only on their own user ID
+	RoleSupport    Role = "support"    // Customer support: looks users up, freezes wallets, settles disputes
+	RoleFinance    Role = "finance"    // Money operations: adjustments, reconciliation, fee, limit and interest rules
+	RoleSuperadmin Role = "superadmin" // Every admin action, including managing API keys and reading the audit log
+	RoleService    Role = "service"    // Another backend acting for any user
+	RoleAPIKey     Role = "api_key"    // A server-to-server client limited to its key's scopes
+)
+
+// AdminRoles lists the roles that may use the admin API
+var AdminRoles = []Role{RoleSupport, RoleFinance, RoleSuperadmin}
+
+// Scope is one permission an API key can be granted
+type Scope string
+
+const (
+	ScopeWalletFund     Scope = "wallet:fund"
+	ScopeWalletWithdraw Scope = "wallet:withdraw"
+	ScopeWalletTransfer Scope = "wallet:transfer"
+	ScopeUsersRead      Scope = "users:read"
+)
+
+// Scopes lists every scope an API key can be granted
+var Scopes = map[Scope]bool{
+	ScopeWalletFund:     true,
+	ScopeWalletWithdraw: true,
+	ScopeWalletTransfer: true,
+	ScopeUsersRead:      true,
+}
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	Subject string // The user ID for users; a name for admins and services; the key ID for API keys
+	Role    Role
+	Scopes  []Scope // What an API key may do; unused for other roles
+}
+
+// HasRole reports whether the principal holds any of the roles
+func (p *Principal) HasRole(roles ...Role) bool {
+	for _, role := range roles {
+		if p.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// HasScope reports whether the principal was granted the scope
+func (p *Principal) HasScope(scope Scope) bool {
+	for _, granted := range p.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// IsUser reports whether the principal is the given user
+func (p *Principal) IsUser(userID uuid.UUID) bool {
+	return p.Role == RoleUser && p.Subject == userID.String()
+}
@@ -0,0 +1,205 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/clock"
+
+	"github.com/google/uuid"
+)
+
+// Errors
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrTokenExpired = errors.New("token has expired")
+	ErrUnknownKey   = errors.New("token is signed with an unknown key")
+)
+
+// TokenType separates short-lived access tokens from the refresh tokens
+// that renew them
+type TokenType string
+
+const (
+	TokenTypeAccess  TokenType = "access"
+	TokenTypeRefresh TokenType = "refresh"
+)
+
+// Claims is the payload of a token
+type Claims struct {
+	Subject   string    `json:"sub"`
+	Role      Role      `json:"role"`
+	Type      TokenType `json:"typ"`
+	ID        string    `json:"jti"`
+	IssuedAt  int64     `json:"iat"`
+	ExpiresAt int64     `json:"exp"`
+}
+
+// Principal returns who the token was issued to
+func (c *Claims) Principal() *Principal {
+	return &Principal{Subject: c.Subject, Role: c.Role}
+}
+
+// TokenPair is what issuing or refreshing returns
+type TokenPair struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"` // Seconds until the access token expires
+}
+
+// header is the JOSE header of a token
+type header struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+	KeyID     string `json:"kid"`
+}
+
+// Keyring holds the HMAC keys tokens are signed with, by key ID. New tokens
+// are signed with the active key; tokens signed with a previous key stay
+// valid until they expire, so the secret can be rotated without logging
+// everyone out.
+type Keyring struct {
+	active string
+	keys   map[string][]byte
+}
+
+// NewKeyring creates a keyring that signs with secret under activeID and
+// also accepts the previous secrets, keyed by their IDs
+func NewKeyring(activeID, secret string, previous map[string]string) (*Keyring, error) {
+	if activeID == "" || secret == "" {
+		return nil, errors.New("a key ID and secret are required")
+	}
+
+	k := &Keyring{active: activeID, keys: map[string][]byte{activeID: []byte(secret)}}
+	for id, value := range previous {
+		if id == activeID {
+			return nil, fmt.Errorf("key %s is both active and previous", id)
+		}
+		if value == "" {
+			return nil, fmt.Errorf("key %s has no secret", id)
+		}
+		k.keys[id] = []byte(value)
+	}
+	return k, nil
+}
+
+// Tokens issues and verifies HS256 JSON Web Tokens
+type Tokens struct {
+	keys       *Keyring
+	clock      clock.Clock
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+}
+
+// NewTokens creates a token service signing with the keyring
+func NewTokens(keys *Keyring, accessTTL, refreshTTL time.Duration, clk clock.Clock) *Tokens {
+	return &Tokens{keys: keys, clock: clk, accessTTL: accessTTL, refreshTTL: refreshTTL}
+}
+
+// Issue creates an access token and a refresh token for a subject
+func (t *Tokens) Issue(subject string, role Role) (*TokenPair, error) {
+	access, err := t.Sign(subject, role, TokenTypeAccess, t.accessTTL)
+	if err != nil {
+		return nil, err
+	}
+	refresh, err := t.Sign(subject, role, TokenTypeRefresh, t.refreshTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:  access,
+		RefreshToken: refresh,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(t.accessTTL / time.Second),
+	}, nil
+}
+
+// Sign creates one token of the given type that lives for ttl
+func (t *Tokens) Sign(subject string, role Role, tokenType TokenType, ttl time.Duration) (string, error) {
+	now := t.clock.Now()
+	claims := Claims{
+		Subject:   subject,
+		Role:      role,
+		Type:      tokenType,
+		ID:        uuid.New().String(),
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(ttl).Unix(),
+	}
+
+	headerJSON, err := json.Marshal(header{Algorithm: "HS256", Type: "JWT", KeyID: t.keys.active})
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := encodeSegment(headerJSON) + "." + encodeSegment(claimsJSON)
+	return signingInput + "." + encodeSegment(sign(t.keys.keys[t.keys.active], signingInput)), nil
+}
+
+// Verify checks a token's signature, expiry and type and returns its claims
+func (t *Tokens) Verify(token string, tokenType TokenType) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidToken
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, ErrInvalidToken
+	}
+	// Only HS256 is accepted, whatever the header asks for
+	if h.Algorithm != "HS256" {
+		return nil, ErrInvalidToken
+	}
+	key, ok := t.keys.keys[h.KeyID]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(signature, sign(key, parts[0]+"."+parts[1])) {
+		return nil, ErrInvalidToken
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if claims.Type != tokenType || claims.Subject == "" || claims.Role == "" {
+		return nil, ErrInvalidToken
+	}
+	if t.clock.Now().Unix() >= claims.ExpiresAt {
+		return nil, ErrTokenExpired
+	}
+
+	return &claims, nil
+}
+
+func sign(key []byte, signingInput string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(signingInput))
+	return mac.Sum(nil)
+}
+
+func encodeSegment(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,170 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/clock"
+)
+
+// Request signing errors
+var (
+	ErrSignatureMissing = errors.New("request is not signed")
+	ErrSignatureInvalid = errors.New("request signature does not match")
+	ErrSignatureStale   = errors.New("request timestamp is outside the allowed window")
+	ErrNonceReused      = errors.New("request nonce has already been used")
+)
+
+const (
+	minNonceLength     = 16
+	maxNonceLength     = 128
+	nonceSweepInterval = time.Minute // How often expired nonces are dropped from memory
+)
+
+// SignedRequest is what a client signs, as received by the server
+type SignedRequest struct {
+	KeyID     string
+	Method    string
+	Path      string // Path and query string, as sent
+	Timestamp string // Unix seconds
+	Nonce     string
+	Body      []byte
+	Signature string // Hex HMAC-SHA256 of the canonical request
+}
+
+// NonceStore remembers the nonces of accepted requests until they expire
+type NonceStore interface {
+	// Remember records the nonce and reports false if it is already known
+	Remember(nonce string, expiresAt time.Time) (bool, error)
+}
+
+// RequestSigner verifies HMAC-signed requests. Each client shares a secret
+// with the service under a key ID and signs the method, path, timestamp,
+// nonce and body hash; requests whose timestamp is more than the window
+// away from now, or whose nonce was seen within the window, are refused.
+type RequestSigner struct {
+	keys   map[string][]byte
+	window time.Duration
+	nonces NonceStore
+	clock  clock.Clock
+}
+
+// NewRequestSigner creates a signer accepting the given secrets by key ID
+func NewRequestSigner(keys map[string]string, window time.Duration, nonces NonceStore, clk clock.Clock) (*RequestSigner, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("at least one signing key is required")
+	}
+	if window <= 0 {
+		return nil, errors.New("the signing window must be positive")
+	}
+
+	s := &RequestSigner{keys: make(map[string][]byte), window: window, nonces: nonces, clock: clk}
+	for id, secret := range keys {
+		if id == "" || secret == "" {
+			return nil, fmt.Errorf("signing key %q has no secret", id)
+		}
+		s.keys[id] = []byte(secret)
+	}
+	return s, nil
+}
+
+// Verify checks a request's signature, timestamp and nonce. The nonce is
+// only recorded once everything else checks out, so a forged request
+// cannot burn a nonce the real client is about to use.
+func (s *RequestSigner) Verify(req SignedRequest) error {
+	if req.KeyID == "" || req.Timestamp == "" || req.Nonce == "" || req.Signature == "" {
+		return ErrSignatureMissing
+	}
+	if len(req.Nonce) < minNonceLength || len(req.Nonce) > maxNonceLength {
+		return ErrSignatureInvalid
+	}
+
+	key, ok := s.keys[req.KeyID]
+	if !ok {
+		return ErrSignatureInvalid
+	}
+	signature, err := hex.DecodeString(req.Signature)
+	if err != nil || !hmac.Equal(signature, signRequest(key, req)) {
+		return ErrSignatureInvalid
+	}
+
+	seconds, err := strconv.ParseInt(req.Timestamp, 10, 64)
+	if err != nil {
+		return ErrSignatureInvalid
+	}
+	timestamp := time.Unix(seconds, 0)
+	now := s.clock.Now()
+	if timestamp.Before(now.Add(-s.window)) || timestamp.After(now.Add(s.window)) {
+		return ErrSignatureStale
+	}
+
+	// The timestamp check refuses the request once the window has passed,
+	// so the nonce only needs keeping until then
+	fresh, err := s.nonces.Remember(req.KeyID+":"+req.Nonce, timestamp.Add(s.window))
+	if err != nil {
+		return fmt.Errorf("failed to record nonce: %w", err)
+	}
+	if !fresh {
+		return ErrNonceReused
+	}
+	return nil
+}
+
+// SignRequest returns the hex signature a client sends for a request
+func SignRequest(secret string, req SignedRequest) string {
+	return hex.EncodeToString(signRequest([]byte(secret), req))
+}
+
+// signRequest computes the HMAC over the canonical request: the method,
+// path, timestamp, nonce and hex SHA-256 of the body, one per line
+func signRequest(key []byte, req SignedRequest) []byte {
+	bodyHash := sha256.Sum256(req.Body)
+	canonical := req.Method + "\n" + req.Path + "\n" + req.Timestamp + "\n" + req.Nonce + "\n" + hex.EncodeToString(bodyHash[:])
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(canonical))
+	return mac.Sum(nil)
+}
+
+// MemoryNonceStore keeps nonces in memory. It suits a single instance;
+// instances behind a load balancer need a shared store.
+type MemoryNonceStore struct {
+	mu        sync.Mutex
+	nonces    map[string]time.Time
+	lastSweep time.Time
+	clock     clock.Clock
+}
+
+// NewMemoryNonceStore creates an empty in-memory nonce store
+func NewMemoryNonceStore(clk clock.Clock) *MemoryNonceStore {
+	return &MemoryNonceStore{nonces: make(map[string]time.Time), clock: clk}
+}
+
+// Remember records the nonce. Expired nonces are dropped now and then so
+// the store stays the size of one window's traffic.
+func (m *MemoryNonceStore) Remember(nonce string, expiresAt time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.clock.Now()
+	if now.Sub(m.lastSweep) >= nonceSweepInterval {
+		for known, expiry := range m.nonces {
+			if !now.Before(expiry) {
+				delete(m.nonces, known)
+			}
+		}
+		m.lastSweep = now
+	}
+
+	if expiry, ok := m.nonces[nonce]; ok && now.Before(expiry) {
+		return false, nil
+	}
+	m.nonces[nonce] = expiresAt
+	return true, nil
+}
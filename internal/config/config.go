@@ -5,6 +5,8 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -27,18 +29,36 @@ type DatabaseConfig struct {
 
 // ServerConfig holds server configuration
 type ServerConfig struct {
-	Host string
-	Port string
+	Host           string
+	Port           string
+	TrustedProxies []string // Proxies whose X-Forwarded-For is believed; none by default
 }
 
 // AppConfig holds application configuration
 type AppConfig struct {
 	Env             string
 	JWTSecret       string
+	JWTKeyID        string            // Key ID new tokens are signed under
+	JWTPreviousKeys map[string]string // Rotated-out secrets by key ID, still accepted until their tokens expire
+	JWTAccessTTL    time.Duration
+	JWTRefreshTTL   time.Duration
 	DefaultPageSize int
 	MaxPageSize     int
+	FXRatesFile     string
+
+	RequestSigningKeys   map[string]string // Shared secrets clients sign money-moving requests with, by key ID
+	RequestSigningWindow time.Duration     // How far a signed request's timestamp may be from now
+
+	SchedulerMaxAttempts  int
+	SchedulerRetryBackoff time.Duration
+
+	AdjustmentApprovalThreshold  int64            // Manual adjustments above this need a second approver
+	AdjustmentApprovalThresholds map[string]int64 // Per-currency overrides of the threshold
 }
 
+// defaultJWTSecret is only good enough for local development
+const defaultJWTSecret = "default_secret"
+
 // Load loads configuration from environment variables
 func Load() (*Config, error) {
 	// Try to load .env file
@@ -61,6 +81,67 @@ func Load() (*Config, error) {
 		}
 	}
 
+	schedulerMaxAttempts := 3
+	schedulerRetryBackoff := 15 * time.Minute
+
+	if val := os.Getenv("SCHEDULER_MAX_ATTEMPTS"); val != "" {
+		if parsed, err := strconv.Atoi(val); err == nil {
+			schedulerMaxAttempts = parsed
+		}
+	}
+
+	if val := os.Getenv("SCHEDULER_RETRY_BACKOFF"); val != "" {
+		if parsed, err := time.ParseDuration(val); err == nil {
+			schedulerRetryBackoff = parsed
+		}
+	}
+
+	jwtAccessTTL := 15 * time.Minute
+	jwtRefreshTTL := 7 * 24 * time.Hour
+
+	if val := os.Getenv("JWT_ACCESS_TTL"); val != "" {
+		if parsed, err := time.ParseDuration(val); err == nil {
+			jwtAccessTTL = parsed
+		}
+	}
+
+	if val := os.Getenv("JWT_REFRESH_TTL"); val != "" {
+		if parsed, err := time.ParseDuration(val); err == nil {
+			jwtRefreshTTL = parsed
+		}
+	}
+
+	jwtPreviousKeys, err := parseKeys("JWT_PREVIOUS_KEYS", os.Getenv("JWT_PREVIOUS_KEYS"))
+	if err != nil {
+		return nil, err
+	}
+
+	requestSigningKeys, err := parseKeys("REQUEST_SIGNING_KEYS", os.Getenv("REQUEST_SIGNING_KEYS"))
+	if err != nil {
+		return nil, err
+	}
+
+	requestSigningWindow := 5 * time.Minute
+
+	if val := os.Getenv("REQUEST_SIGNING_WINDOW"); val != "" {
+		if parsed, err := time.ParseDuration(val); err == nil {
+			requestSigningWindow = parsed
+		}
+	}
+
+	adjustmentApprovalThreshold := int64(100000)
+
+	if val := os.Getenv("ADJUSTMENT_APPROVAL_THRESHOLD"); val != "" {
+		if parsed, err := strconv.ParseInt(val, 10, 64); err == nil {
+			adjustmentApprovalThreshold = parsed
+		}
+	}
+
+	adjustmentApprovalThresholds, err := parseAmounts("ADJUSTMENT_APPROVAL_THRESHOLDS", os.Getenv("ADJUSTMENT_APPROVAL_THRESHOLDS"))
+	if err != nil {
+		return nil, err
+	}
+
 	config := &Config{
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -72,12 +153,28 @@ func Load() (*Config, error) {
 		Server: ServerConfig{
 			Host: getEnv("SERVER_HOST", "localhost"),
 			Port: getEnv("SERVER_PORT", "8080"),
+
+			TrustedProxies: splitList(os.Getenv("TRUSTED_PROXIES")),
 		},
 		App: AppConfig{
 			Env:             getEnv("APP_ENV", "development"),
-			JWTSecret:       getEnv("JWT_SECRET", "default_secret"),
+			JWTSecret:       getEnv("JWT_SECRET", defaultJWTSecret),
+			JWTKeyID:        getEnv("JWT_KEY_ID", "default"),
+			JWTPreviousKeys: jwtPreviousKeys,
+			JWTAccessTTL:    jwtAccessTTL,
+			JWTRefreshTTL:   jwtRefreshTTL,
 			DefaultPageSize: defaultPageSize,
 			MaxPageSize:     maxPageSize,
+			FXRatesFile:     getEnv("FX_RATES_FILE", ""),
+
+			RequestSigningKeys:   requestSigningKeys,
+			RequestSigningWindow: requestSigningWindow,
+
+			SchedulerMaxAttempts:  schedulerMaxAttempts,
+			SchedulerRetryBackoff: schedulerRetryBackoff,
+
+			AdjustmentApprovalThreshold:  adjustmentApprovalThreshold,
+			AdjustmentApprovalThresholds: adjustmentApprovalThresholds,
 		},
 	}
 
@@ -85,6 +182,12 @@ func Load() (*Config, error) {
 	if config.Database.Name == "" {
 		return nil, fmt.Errorf("database name is required")
 	}
+	if config.App.Env == "production" && config.App.JWTSecret == defaultJWTSecret {
+		return nil, fmt.Errorf("JWT_SECRET must be set in production")
+	}
+	if config.App.Env == "production" && len(config.App.RequestSigningKeys) == 0 {
+		return nil, fmt.Errorf("REQUEST_SIGNING_KEYS must be set in production")
+	}
 
 	return config, nil
 }
@@ -105,6 +208,45 @@ func (c *Config) GetServerAddress() string {
 	return fmt.Sprintf("%s:%s", c.Server.Host, c.Server.Port)
 }
 
+// parseKeys reads the named comma-separated list of id:secret pairs
+func parseKeys(name, value string) (map[string]string, error) {
+	keys := make(map[string]string)
+	for _, pair := range splitList(value) {
+		id, secret, ok := strings.Cut(pair, ":")
+		if !ok || id == "" || secret == "" {
+			return nil, fmt.Errorf("invalid %s entry %q; expected id:secret", name, pair)
+		}
+		keys[id] = secret
+	}
+	return keys, nil
+}
+
+// parseAmounts reads the named comma-separated list of CODE:amount pairs,
+// keyed by upper-case currency code
+func parseAmounts(name, value string) (map[string]int64, error) {
+	amounts := make(map[string]int64)
+	for _, pair := range splitList(value) {
+		code, raw, ok := strings.Cut(pair, ":")
+		amount, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+		if !ok || strings.TrimSpace(code) == "" || err != nil || amount < 0 {
+			return nil, fmt.Errorf("invalid %s entry %q; expected CODE:amount", name, pair)
+		}
+		amounts[strings.ToUpper(strings.TrimSpace(code))] = amount
+	}
+	return amounts, nil
+}
+
+// splitList reads a comma-separated list, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // getEnv gets an environment variable with a fallback value
 func getEnv(key, fallback string) string {
 	if value := os.Getenv(key); value != "" {
@@ -0,0 +1,206 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidExpression is returned for expressions that cannot be parsed
+var ErrInvalidExpression = errors.New("invalid cron expression")
+
+// searchLimit bounds how far ahead Next looks for a matching time, so
+// expressions that never match (such as 30 February) terminate
+const searchLimit = 5 * 365 * 24 * time.Hour
+
+// macros maps the shorthand expressions to their five-field form
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// bounds describes the allowed values of one field
+type bounds struct {
+	min, max int
+}
+
+var (
+	minuteBounds = bounds{0, 59}
+	hourBounds   = bounds{0, 23}
+	domBounds    = bounds{1, 31}
+	monthBounds  = bounds{1, 12}
+	dowBounds    = bounds{0, 7} // Both 0 and 7 are Sunday
+)
+
+// Schedule is a parsed five-field cron expression:
+//
+//	minute hour day-of-month month day-of-week
+//
+// Fields accept *, single values, ranges (1-5), lists (1,15) and steps
+// (*/15, 1-30/2). Times are evaluated in UTC.
+type Schedule struct {
+	expr    string
+	minute  uint64
+	hour    uint64
+	dom     uint64
+	month   uint64
+	dow     uint64
+	domStar bool
+	dowStar bool
+}
+
+// Parse parses a five-field cron expression or one of the @ macros
+func Parse(expr string) (*Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	full := expr
+	if macro, ok := macros[strings.ToLower(expr)]; ok {
+		full = macro
+	}
+
+	fields := strings.Fields(full)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w: expected 5 fields, got %d", ErrInvalidExpression, len(fields))
+	}
+
+	s := &Schedule{expr: expr}
+	var err error
+	if s.minute, err = parseField(fields[0], minuteBounds); err != nil {
+		return nil, err
+	}
+	if s.hour, err = parseField(fields[1], hourBounds); err != nil {
+		return nil, err
+	}
+	if s.dom, err = parseField(fields[2], domBounds); err != nil {
+		return nil, err
+	}
+	if s.month, err = parseField(fields[3], monthBounds); err != nil {
+		return nil, err
+	}
+	if s.dow, err = parseField(fields[4], dowBounds); err != nil {
+		return nil, err
+	}
+
+	// Sunday may be written as 7
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domStar = fields[2] == "*"
+	s.dowStar = fields[4] == "*"
+	return s, nil
+}
+
+// String returns the expression the schedule was parsed from
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+// Next returns the first matching minute strictly after the given time, or
+// the zero time if nothing matches within five years
+func (s *Schedule) Next(after time.Time) time.Time {
+	t := after.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(searchLimit)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, time.UTC)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches applies cron's day rule: when both day fields are restricted
+// a day matching either one is enough
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// parseField turns one comma-separated field into a bitset of allowed values
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		partBits, err := parsePart(part, b)
+		if err != nil {
+			return 0, err
+		}
+		bits |= partBits
+	}
+	return bits, nil
+}
+
+// parsePart parses one list item: *, n, a-b, with an optional /step
+func parsePart(part string, b bounds) (uint64, error) {
+	rangePart, step := part, 1
+	if i := strings.Index(part, "/"); i >= 0 {
+		var err error
+		rangePart = part[:i]
+		if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+			return 0, fmt.Errorf("%w: bad step in %q", ErrInvalidExpression, part)
+		}
+	}
+
+	start, end := b.min, b.max
+	switch {
+	case rangePart == "*":
+	case strings.Contains(rangePart, "-"):
+		bounds := strings.SplitN(rangePart, "-", 2)
+		var err error
+		if start, err = parseValue(bounds[0], b); err != nil {
+			return 0, err
+		}
+		if end, err = parseValue(bounds[1], b); err != nil {
+			return 0, err
+		}
+		if start > end {
+			return 0, fmt.Errorf("%w: empty range %q", ErrInvalidExpression, part)
+		}
+	default:
+		value, err := parseValue(rangePart, b)
+		if err != nil {
+			return 0, err
+		}
+		start = value
+		// A single value with a step runs to the end of the field
+		if step == 1 {
+			end = value
+		}
+	}
+
+	var bits uint64
+	for v := start; v <= end; v += step {
+		bits |= 1 << uint(v)
+	}
+	return bits, nil
+}
+
+func parseValue(s string, b bounds) (int, error) {
+	value, err := strconv.Atoi(s)
+	if err != nil || value < b.min || value > b.max {
+		return 0, fmt.Errorf("%w: value %q out of range %d-%d", ErrInvalidExpression, s, b.min, b.max)
+	}
+	return value, nil
+}
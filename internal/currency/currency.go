@@ -0,0 +1,69 @@
+package currency
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Default is the currency of the wallet every user is created with
+const Default = "USD"
+
+// Currency describes an ISO 4217 currency
+type Currency struct {
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+	Exponent int    `json:"exponent"` // Number of minor-unit digits, e.g. 2 for cents
+}
+
+// currencies holds the currencies the service can hold wallets in
+var currencies = map[string]Currency{
+	"AUD": {Code: "AUD", Name: "Australian Dollar", Exponent: 2},
+	"BHD": {Code: "BHD", Name: "Bahraini Dinar", Exponent: 3},
+	"CAD": {Code: "CAD", Name: "Canadian Dollar", Exponent: 2},
+	"CHF": {Code: "CHF", Name: "Swiss Franc", Exponent: 2},
+	"EUR": {Code: "EUR", Name: "Euro", Exponent: 2},
+	"GBP": {Code: "GBP", Name: "Pound Sterling", Exponent: 2},
+	"GHS": {Code: "GHS", Name: "Ghana Cedi", Exponent: 2},
+	"JPY": {Code: "JPY", Name: "Yen", Exponent: 0},
+	"KES": {Code: "KES", Name: "Kenyan Shilling", Exponent: 2},
+	"KRW": {Code: "KRW", Name: "Won", Exponent: 0},
+	"KWD": {Code: "KWD", Name: "Kuwaiti Dinar", Exponent: 3},
+	"NGN": {Code: "NGN", Name: "Naira", Exponent: 2},
+	"OMR": {Code: "OMR", Name: "Rial Omani", Exponent: 3},
+	"USD": {Code: "USD", Name: "US Dollar", Exponent: 2},
+	"ZAR": {Code: "ZAR", Name: "Rand", Exponent: 2},
+}
+
+// Lookup returns the currency for an ISO 4217 code, ignoring case
+func Lookup(code string) (Currency, bool) {
+	c, ok := currencies[strings.ToUpper(strings.TrimSpace(code))]
+	return c, ok
+}
+
+// Exponent returns the minor-unit exponent of a currency, defaulting to 2 for unknown codes
+func Exponent(code string) int {
+	if c, ok := Lookup(code); ok {
+		return c.Exponent
+	}
+	return 2
+}
+
+// Format renders an amount in minor units as a decimal string, e.g. 1050 USD as "10.50"
+func Format(amount int64, code string) string {
+	exponent := Exponent(code)
+	if exponent == 0 {
+		return fmt.Sprintf("%d", amount)
+	}
+
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	divisor := int64(1)
+	for i := 0; i < exponent; i++ {
+		divisor *= 10
+	}
+	return fmt.Sprintf("%s%d.%0*d", sign, amount/divisor, exponent, amount%divisor)
+}
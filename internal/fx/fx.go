@@ -0,0 +1,100 @@
+package fx
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// RateDecimals is the precision rates are rounded to before they are quoted
+// or applied, so the stored rate is exactly the one used
+const RateDecimals = 10
+
+// remainderDecimals is enough to print any remainder exactly: rates carry
+// RateDecimals digits and exponents differ by at most three
+const remainderDecimals = RateDecimals + 6
+
+var (
+	ErrRateUnavailable = errors.New("exchange rate unavailable")
+	ErrInvalidRate     = errors.New("invalid exchange rate")
+)
+
+// RateProvider supplies the rate to convert one major unit of a currency into another
+type RateProvider interface {
+	Rate(from, to string) (*big.Rat, error)
+}
+
+// ParseRate parses a decimal rate such as "1.0825", rejecting non-positive values
+func ParseRate(s string) (*big.Rat, error) {
+	rate, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok || rate.Sign() <= 0 {
+		return nil, ErrInvalidRate
+	}
+	return rate, nil
+}
+
+// RoundRate rounds a rate half-up to RateDecimals places
+func RoundRate(rate *big.Rat) *big.Rat {
+	rounded, _ := new(big.Rat).SetString(rate.FloatString(RateDecimals))
+	return rounded
+}
+
+// FormatRate renders a rate with RateDecimals places
+func FormatRate(rate *big.Rat) string {
+	return rate.FloatString(RateDecimals)
+}
+
+// Conversion is the outcome of applying a rate to an amount in minor units
+type Conversion struct {
+	TargetAmount int64
+	// Remainder is the fraction of one target minor unit dropped when
+	// rounding down, so source*rate == target + remainder exactly
+	Remainder *big.Rat
+}
+
+// RemainderString renders the remainder exactly as a decimal
+func (c Conversion) RemainderString() string {
+	return c.Remainder.FloatString(remainderDecimals)
+}
+
+// Convert applies a rate to an amount held in minor units of a currency
+// with fromExponent digits, yielding minor units of a currency with
+// toExponent digits. The target is rounded down; the remainder records
+// what was dropped.
+func Convert(amount int64, rate *big.Rat, fromExponent, toExponent int) (Conversion, error) {
+	if amount <= 0 {
+		return Conversion{}, fmt.Errorf("amount must be positive")
+	}
+	if rate == nil || rate.Sign() <= 0 {
+		return Conversion{}, ErrInvalidRate
+	}
+
+	exact := new(big.Rat).Mul(new(big.Rat).SetInt64(amount), rate)
+	scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(toExponent-fromExponent))), nil))
+	if toExponent >= fromExponent {
+		exact.Mul(exact, scale)
+	} else {
+		exact.Quo(exact, scale)
+	}
+
+	target := new(big.Int).Quo(exact.Num(), exact.Denom())
+	if !target.IsInt64() {
+		return Conversion{}, fmt.Errorf("converted amount overflows")
+	}
+
+	remainder := new(big.Rat).Sub(exact, new(big.Rat).SetInt(target))
+	return Conversion{TargetAmount: target.Int64(), Remainder: remainder}, nil
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// pairKey builds the lookup key for a currency pair
+func pairKey(from, to string) string {
+	return strings.ToUpper(from) + "/" + strings.ToUpper(to)
+}
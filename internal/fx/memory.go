@@ -0,0 +1,46 @@
+package fx
+
+import (
+	"math/big"
+	"sync"
+)
+
+// MemoryProvider serves rates set at runtime; it is meant for tests and
+// local development. A pair without a rate of its own falls back to the
+// inverse of the opposite pair.
+type MemoryProvider struct {
+	mu    sync.RWMutex
+	rates map[string]*big.Rat
+}
+
+// NewMemoryProvider creates an empty in-memory provider
+func NewMemoryProvider() *MemoryProvider {
+	return &MemoryProvider{rates: make(map[string]*big.Rat)}
+}
+
+// Set stores the rate for converting from into to
+func (p *MemoryProvider) Set(from, to, rate string) error {
+	parsed, err := ParseRate(rate)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.rates[pairKey(from, to)] = parsed
+	return nil
+}
+
+// Rate returns how many units of to one unit of from buys
+func (p *MemoryProvider) Rate(from, to string) (*big.Rat, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if rate, ok := p.rates[pairKey(from, to)]; ok {
+		return new(big.Rat).Set(rate), nil
+	}
+	if rate, ok := p.rates[pairKey(to, from)]; ok {
+		return new(big.Rat).Inv(rate), nil
+	}
+	return nil, ErrRateUnavailable
+}
@@ -0,0 +1,71 @@
+package fx
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+)
+
+// StaticProvider serves rates from a fixed table quoted against a base
+// currency; cross rates are derived through the base
+type StaticProvider struct {
+	base  string
+	rates map[string]*big.Rat
+}
+
+// staticFile is the on-disk format of a static rate table, e.g.
+//
+//	{"base": "USD", "rates": {"EUR": "0.92", "JPY": "151.30"}}
+type staticFile struct {
+	Base  string            `json:"base"`
+	Rates map[string]string `json:"rates"`
+}
+
+// NewStaticProvider builds a provider from rates giving one unit of base in each currency
+func NewStaticProvider(base string, rates map[string]string) (*StaticProvider, error) {
+	p := &StaticProvider{
+		base:  strings.ToUpper(base),
+		rates: map[string]*big.Rat{strings.ToUpper(base): big.NewRat(1, 1)},
+	}
+	for code, value := range rates {
+		rate, err := ParseRate(value)
+		if err != nil {
+			return nil, fmt.Errorf("rate for %s: %w", code, err)
+		}
+		p.rates[strings.ToUpper(code)] = rate
+	}
+	return p, nil
+}
+
+// LoadStaticProvider reads a rate table from a JSON file
+func LoadStaticProvider(path string) (*StaticProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rates file: %w", err)
+	}
+
+	var file staticFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse rates file: %w", err)
+	}
+	if file.Base == "" {
+		return nil, fmt.Errorf("rates file has no base currency")
+	}
+
+	return NewStaticProvider(file.Base, file.Rates)
+}
+
+// Rate returns how many units of to one unit of from buys
+func (p *StaticProvider) Rate(from, to string) (*big.Rat, error) {
+	fromRate, ok := p.rates[strings.ToUpper(from)]
+	if !ok {
+		return nil, ErrRateUnavailable
+	}
+	toRate, ok := p.rates[strings.ToUpper(to)]
+	if !ok {
+		return nil, ErrRateUnavailable
+	}
+	return new(big.Rat).Quo(toRate, fromRate), nil
+}
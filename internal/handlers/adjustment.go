@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Adjustment DTOs
+
+type ProposeAdjustmentRequest struct {
+	Direction string `json:"direction" binding:"required,oneof=credit debit"`
+	Amount    int64  `json:"amount" binding:"required,min=1"`
+	Reason    string `json:"reason" binding:"required"`
+	Evidence  string `json:"evidence" binding:"required"` // Ticket, statement or document backing the adjustment
+	Reference string `json:"reference" binding:"required"`
+}
+
+type ReviewAdjustmentRequest struct {
+	Comment string `json:"comment"`
+}
+
+type ListAdjustmentsQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending approved rejected"`
+}
+
+// Adjustment Handlers
+
+func (h *Handlers) ProposeAdjustment(c *gin.Context) {
+	walletID, err := uuid.Parse(c.Param("wallet_id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid wallet ID", err)
+		return
+	}
+
+	var req ProposeAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	adjustment, err := h.useCases.Adjustment.Propose(&models.BalanceAdjustment{
+		WalletID:   walletID,
+		Direction:  models.AdjustmentDirection(req.Direction),
+		Amount:     req.Amount,
+		Reason:     req.Reason,
+		Evidence:   req.Evidence,
+		Reference:  req.Reference,
+		ProposedBy: adminActor(c),
+	})
+	if err != nil {
+		handleAdjustmentError(c, err, "Failed to propose adjustment")
+		return
+	}
+
+	successResponse(c, "Adjustment proposed successfully", adjustment)
+}
+
+func (h *Handlers) ListAdjustments(c *gin.Context) {
+	var query ListAdjustmentsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	adjustments, err := h.useCases.Adjustment.List(models.AdjustmentStatus(query.Status))
+	if err != nil {
+		errorResponse(c, http.StatusInternalServerError, "Failed to list adjustments", err)
+		return
+	}
+
+	successResponse(c, "Adjustments retrieved successfully", adjustments)
+}
+
+func (h *Handlers) GetAdjustment(c *gin.Context) {
+	adjustmentID, err := uuid.Parse(c.Param("adjustment_id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid adjustment ID", err)
+		return
+	}
+
+	adjustment, err := h.useCases.Adjustment.Get(adjustmentID)
+	if err != nil {
+		handleAdjustmentError(c, err, "Failed to get adjustment")
+		return
+	}
+
+	successResponse(c, "Adjustment retrieved successfully", adjustment)
+}
+
+func (h *Handlers) ApproveAdjustment(c *gin.Context) {
+	h.reviewAdjustment(c, h.useCases.Adjustment.Approve, "Adjustment approved successfully", "Failed to approve adjustment")
+}
+
+func (h *Handlers) RejectAdjustment(c *gin.Context) {
+	h.reviewAdjustment(c, h.useCases.Adjustment.Reject, "Adjustment rejected successfully", "Failed to reject adjustment")
+}
+
+// reviewAdjustment records the requesting admin's decision on an adjustment
+func (h *Handlers) reviewAdjustment(c *gin.Context, decide func(uuid.UUID, string, string) (*models.BalanceAdjustment, error), success, failure string) {
+	adjustmentID, err := uuid.Parse(c.Param("adjustment_id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid adjustment ID", err)
+		return
+	}
+
+	// The comment is optional, so an empty body is fine
+	var req ReviewAdjustmentRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			errorResponse(c, http.StatusBadRequest, "Invalid request data", err)
+			return
+		}
+	}
+
+	adjustment, err := decide(adjustmentID, adminActor(c), req.Comment)
+	if err != nil {
+		if walletStatusResponse(c, err) {
+			return
+		}
+		handleAdjustmentError(c, err, failure)
+		return
+	}
+
+	successResponse(c, success, adjustment)
+}
+
+func handleAdjustmentError(c *gin.Context, err error, message string) {
+	switch err {
+	case usecases.ErrWalletNotFound:
+		errorResponse(c, http.StatusNotFound, "Wallet not found", err)
+	case usecases.ErrAdjustmentNotFound:
+		errorResponse(c, http.StatusNotFound, "Adjustment not found", err)
+	case usecases.ErrInvalidAmount:
+		errorResponse(c, http.StatusBadRequest, "Invalid amount", err)
+	case usecases.ErrInvalidAdjustment, usecases.ErrAdjustmentActorRequired:
+		errorResponse(c, http.StatusBadRequest, "Invalid adjustment", err)
+	case usecases.ErrInsufficientFunds:
+		errorResponse(c, http.StatusBadRequest, "Insufficient funds", err)
+	case usecases.ErrSelfApproval, usecases.ErrAdjustmentReviewed:
+		errorResponse(c, http.StatusForbidden, "A different admin must review this adjustment", err)
+	case usecases.ErrAdjustmentNotPending:
+		errorResponse(c, http.StatusConflict, "Adjustment has already been decided", err)
+	case usecases.ErrTransactionExists:
+		errorResponse(c, http.StatusConflict, "Reference already used by another transaction", err)
+	default:
+		errorResponse(c, http.StatusInternalServerError, message, err)
+	}
+}
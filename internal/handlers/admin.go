@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/middleware"
+	"github.com/Code-Linx/wallet-service/internal/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Admin DTOs
+
+type SearchUsersQuery struct {
+	Query    string `form:"q"` // User ID, or part of a name or email; empty lists everyone
+	Page     int    `form:"page,default=1" binding:"min=1"`
+	PageSize int    `form:"page_size,default=10" binding:"min=1,max=100"`
+}
+
+type AuditLogQuery struct {
+	Actor    string     `form:"actor"`
+	Route    string     `form:"route"` // Route pattern, e.g. /api/v1/admin/wallets/:wallet_id/status
+	Since    *time.Time `form:"since" time_format:"2006-01-02T15:04:05Z07:00"`
+	Until    *time.Time `form:"until" time_format:"2006-01-02T15:04:05Z07:00"`
+	Page     int        `form:"page,default=1" binding:"min=1"`
+	PageSize int        `form:"page_size,default=10" binding:"min=1,max=100"`
+}
+
+// Admin Handlers
+
+func (h *Handlers) SearchUsers(c *gin.Context) {
+	var query SearchUsersQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	users, total, err := h.useCases.Admin.SearchUsers(query.Query, query.Page, query.PageSize)
+	if err != nil {
+		errorResponse(c, http.StatusInternalServerError, "Failed to search users", err)
+		return
+	}
+
+	paginatedResponse(c, "Users retrieved successfully", users, query.Page, query.PageSize, total)
+}
+
+func (h *Handlers) ListAuditLog(c *gin.Context) {
+	var query AuditLogQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	filter := repositories.AuditLogFilter{
+		Actor: query.Actor,
+		Route: query.Route,
+		Since: query.Since,
+		Until: query.Until,
+	}
+	entries, total, err := h.useCases.Admin.ListAuditLog(filter, query.Page, query.PageSize)
+	if err != nil {
+		errorResponse(c, http.StatusInternalServerError, "Failed to list audit log", err)
+		return
+	}
+
+	paginatedResponse(c, "Audit log retrieved successfully", entries, query.Page, query.PageSize, total)
+}
+
+// adminActor names the admin making the request, for the records admin
+// actions leave behind
+func adminActor(c *gin.Context) string {
+	if principal, ok := middleware.CurrentPrincipal(c); ok {
+		return principal.Subject
+	}
+	return ""
+}
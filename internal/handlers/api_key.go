@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/middleware"
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// API Key DTOs
+
+type CreateAPIKeyRequest struct {
+	Name       string     `json:"name" binding:"required,max=64"`
+	Scopes     []string   `json:"scopes" binding:"required,min=1"`
+	AllowedIPs []string   `json:"allowed_ips"` // IPs or CIDR ranges; empty allows any
+	ExpiresAt  *time.Time `json:"expires_at"`  // Omit for a key that does not expire
+}
+
+// API Key Handlers
+
+// CreateAPIKey returns the new key's secret; this response is the only
+// place it appears
+func (h *Handlers) CreateAPIKey(c *gin.Context) {
+	var req CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	key := &models.APIKey{
+		Name:       req.Name,
+		Scopes:     req.Scopes,
+		AllowedIPs: req.AllowedIPs,
+		ExpiresAt:  req.ExpiresAt,
+	}
+	if principal, ok := middleware.CurrentPrincipal(c); ok {
+		key.CreatedBy = principal.Subject
+	}
+
+	key, err := h.useCases.APIKey.Create(key)
+	if err != nil {
+		handleAPIKeyError(c, err, "Failed to create API key")
+		return
+	}
+
+	successResponse(c, "API key created successfully", key)
+}
+
+func (h *Handlers) ListAPIKeys(c *gin.Context) {
+	keys, err := h.useCases.APIKey.List()
+	if err != nil {
+		handleAPIKeyError(c, err, "Failed to list API keys")
+		return
+	}
+
+	successResponse(c, "API keys retrieved successfully", keys)
+}
+
+func (h *Handlers) RevokeAPIKey(c *gin.Context) {
+	keyID, err := uuid.Parse(c.Param("key_id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid API key ID", err)
+		return
+	}
+
+	key, err := h.useCases.APIKey.Revoke(keyID)
+	if err != nil {
+		handleAPIKeyError(c, err, "Failed to revoke API key")
+		return
+	}
+
+	successResponse(c, "API key revoked successfully", key)
+}
+
+func handleAPIKeyError(c *gin.Context, err error, message string) {
+	switch err {
+	case usecases.ErrAPIKeyNotFound:
+		errorResponse(c, http.StatusNotFound, "API key not found", err)
+	case usecases.ErrInvalidAPIKey:
+		errorResponse(c, http.StatusBadRequest, "Invalid API key", err)
+	default:
+		errorResponse(c, http.StatusInternalServerError, message, err)
+	}
+}
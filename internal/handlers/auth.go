@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Code-Linx/wallet-service/internal/auth"
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Auth DTOs
+
+type IssueTokenRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// Auth Handlers
+
+// IssueToken lets a trusted service that has already signed a user in get
+// tokens for that user. Admin and service tokens are minted with cmd/token.
+func (h *Handlers) IssueToken(c *gin.Context) {
+	var req IssueTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	if _, err := h.useCases.User.GetUserByID(userID); err != nil {
+		handleAuthError(c, err, "Failed to issue token")
+		return
+	}
+
+	pair, err := h.tokens.Issue(userID.String(), auth.RoleUser)
+	if err != nil {
+		handleAuthError(c, err, "Failed to issue token")
+		return
+	}
+
+	successResponse(c, "Token issued successfully", pair)
+}
+
+// RefreshToken swaps a refresh token for a new pair, signed with the
+// current key
+func (h *Handlers) RefreshToken(c *gin.Context) {
+	var req RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	claims, err := h.tokens.Verify(req.RefreshToken, auth.TokenTypeRefresh)
+	if err != nil {
+		handleAuthError(c, err, "Failed to refresh token")
+		return
+	}
+
+	// A user's tokens stop renewing once the user is gone
+	if claims.Role == auth.RoleUser {
+		userID, err := uuid.Parse(claims.Subject)
+		if err != nil {
+			handleAuthError(c, auth.ErrInvalidToken, "Failed to refresh token")
+			return
+		}
+		if _, err := h.useCases.User.GetUserByID(userID); err != nil {
+			handleAuthError(c, err, "Failed to refresh token")
+			return
+		}
+	}
+
+	pair, err := h.tokens.Issue(claims.Subject, claims.Role)
+	if err != nil {
+		handleAuthError(c, err, "Failed to refresh token")
+		return
+	}
+
+	successResponse(c, "Token refreshed successfully", pair)
+}
+
+func handleAuthError(c *gin.Context, err error, message string) {
+	switch err {
+	case usecases.ErrUserNotFound:
+		errorResponse(c, http.StatusNotFound, "User not found", err)
+	case auth.ErrInvalidToken, auth.ErrTokenExpired, auth.ErrUnknownKey:
+		errorResponse(c, http.StatusUnauthorized, "Invalid refresh token", err)
+	default:
+		errorResponse(c, http.StatusInternalServerError, message, err)
+	}
+}
@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Escrow DTOs
+
+type CreateEscrowRequest struct {
+	ToUserID       string `json:"to_user_id" binding:"required"`
+	Amount         int64  `json:"amount" binding:"required,min=1"`
+	Currency       string `json:"currency"` // Defaults to USD
+	Reference      string `json:"reference" binding:"required"`
+	TimeoutSeconds int64  `json:"timeout_seconds" binding:"min=0"` // Defaults to 14 days
+}
+
+type DisputeEscrowRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+type ResolveEscrowRequest struct {
+	Resolution string `json:"resolution" binding:"required,oneof=release refund"`
+	Reason     string `json:"reason" binding:"required"`
+}
+
+// Escrow Handlers
+
+func (h *Handlers) CreateEscrow(c *gin.Context) {
+	fromUserID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	var req CreateEscrowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	toUserID, err := uuid.Parse(req.ToUserID)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid recipient user ID", err)
+		return
+	}
+
+	timeout := time.Duration(req.TimeoutSeconds) * time.Second
+	escrow, err := h.useCases.Wallet.CreateEscrow(fromUserID, toUserID, req.Amount, req.Currency, timeout, req.Reference)
+	if err != nil {
+		handleEscrowError(c, err, "Failed to create escrow")
+		return
+	}
+
+	successResponse(c, "Escrow created successfully", escrow)
+}
+
+func (h *Handlers) GetEscrow(c *gin.Context) {
+	userID, escrowID, ok := parseEscrowParams(c)
+	if !ok {
+		return
+	}
+
+	escrow, err := h.useCases.Wallet.GetEscrow(userID, escrowID)
+	if err != nil {
+		handleEscrowError(c, err, "Failed to get escrow")
+		return
+	}
+
+	successResponse(c, "Escrow retrieved successfully", escrow)
+}
+
+func (h *Handlers) ReleaseEscrow(c *gin.Context) {
+	userID, escrowID, ok := parseEscrowParams(c)
+	if !ok {
+		return
+	}
+
+	escrow, err := h.useCases.Wallet.ReleaseEscrow(userID, escrowID)
+	if err != nil {
+		handleEscrowError(c, err, "Failed to release escrow")
+		return
+	}
+
+	successResponse(c, "Escrow released successfully", escrow)
+}
+
+func (h *Handlers) CancelEscrow(c *gin.Context) {
+	userID, escrowID, ok := parseEscrowParams(c)
+	if !ok {
+		return
+	}
+
+	escrow, err := h.useCases.Wallet.CancelEscrow(userID, escrowID)
+	if err != nil {
+		handleEscrowError(c, err, "Failed to cancel escrow")
+		return
+	}
+
+	successResponse(c, "Escrow cancelled successfully", escrow)
+}
+
+func (h *Handlers) DisputeEscrow(c *gin.Context) {
+	userID, escrowID, ok := parseEscrowParams(c)
+	if !ok {
+		return
+	}
+
+	var req DisputeEscrowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	escrow, err := h.useCases.Wallet.DisputeEscrow(userID, escrowID, req.Reason)
+	if err != nil {
+		handleEscrowError(c, err, "Failed to dispute escrow")
+		return
+	}
+
+	successResponse(c, "Escrow disputed successfully", escrow)
+}
+
+func (h *Handlers) ResolveEscrow(c *gin.Context) {
+	escrowID, err := uuid.Parse(c.Param("escrow_id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid escrow ID", err)
+		return
+	}
+
+	var req ResolveEscrowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	escrow, err := h.useCases.Wallet.ResolveEscrow(escrowID, models.EscrowResolution(req.Resolution), req.Reason, adminActor(c))
+	if err != nil {
+		handleEscrowError(c, err, "Failed to resolve escrow")
+		return
+	}
+
+	successResponse(c, "Escrow resolved successfully", escrow)
+}
+
+// parseEscrowParams reads the user and escrow IDs from the path, writing an error response if either is invalid
+func parseEscrowParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	escrowID, err := uuid.Parse(c.Param("escrow_id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid escrow ID", err)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return userID, escrowID, true
+}
+
+func handleEscrowError(c *gin.Context, err error, message string) {
+	if limitExceededResponse(c, err) || walletStatusResponse(c, err) {
+		return
+	}
+
+	switch err {
+	case usecases.ErrUserNotFound:
+		errorResponse(c, http.StatusNotFound, "User not found", err)
+	case usecases.ErrRecipientNotFound:
+		errorResponse(c, http.StatusNotFound, "Recipient not found", err)
+	case usecases.ErrWalletNotFound:
+		errorResponse(c, http.StatusNotFound, "Wallet not found", err)
+	case usecases.ErrEscrowNotFound:
+		errorResponse(c, http.StatusNotFound, "Escrow not found", err)
+	case usecases.ErrEscrowForbidden:
+		errorResponse(c, http.StatusForbidden, "Not allowed for this user", err)
+	case usecases.ErrEscrowNotPending:
+		errorResponse(c, http.StatusConflict, "Escrow is no longer pending", err)
+	case usecases.ErrEscrowNotDisputed:
+		errorResponse(c, http.StatusConflict, "Escrow is not disputed", err)
+	case usecases.ErrEscrowExpired:
+		errorResponse(c, http.StatusConflict, "Escrow has timed out", err)
+	case usecases.ErrEscrowReasonRequired:
+		errorResponse(c, http.StatusBadRequest, "Reason is required", err)
+	case usecases.ErrInvalidResolution:
+		errorResponse(c, http.StatusBadRequest, "Invalid resolution", err)
+	case usecases.ErrInvalidAmount:
+		errorResponse(c, http.StatusBadRequest, "Invalid amount", err)
+	case usecases.ErrInsufficientFunds:
+		errorResponse(c, http.StatusBadRequest, "Insufficient funds", err)
+	case usecases.ErrSameUser:
+		errorResponse(c, http.StatusBadRequest, "Cannot transfer to the same user", err)
+	case usecases.ErrUnsupportedCurrency:
+		errorResponse(c, http.StatusBadRequest, "Unsupported currency", err)
+	case usecases.ErrCurrencyMismatch:
+		errorResponse(c, http.StatusBadRequest, "Recipient has no wallet in this currency", err)
+	default:
+		errorResponse(c, http.StatusInternalServerError, message, err)
+	}
+}
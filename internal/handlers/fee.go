@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Fee DTOs
+
+type CreateFeeRuleRequest struct {
+	TransactionType string           `json:"transaction_type" binding:"required,oneof=credit debit transfer"`
+	Currency        string           `json:"currency"` // Empty matches any currency
+	Tier            string           `json:"tier"`     // Empty matches any tier
+	Kind            string           `json:"kind" binding:"required,oneof=flat percentage tiered"`
+	FlatAmount      int64            `json:"flat_amount" binding:"min=0"`
+	BasisPoints     int64            `json:"basis_points" binding:"min=0,max=10000"`
+	MinFee          int64            `json:"min_fee" binding:"min=0"`
+	MaxFee          int64            `json:"max_fee" binding:"min=0"`
+	Tiers           []models.FeeTier `json:"tiers"`
+}
+
+type FeeQuoteQuery struct {
+	Type     string `form:"type" binding:"required,oneof=credit debit transfer"`
+	Amount   int64  `form:"amount" binding:"required,min=1"`
+	Currency string `form:"currency"` // Defaults to USD
+}
+
+// Fee Handlers
+
+func (h *Handlers) CreateFeeRule(c *gin.Context) {
+	var req CreateFeeRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	rule, err := h.useCases.Fee.CreateRule(&models.FeeRule{
+		TransactionType: models.TransactionType(req.TransactionType),
+		Currency:        req.Currency,
+		Tier:            req.Tier,
+		Kind:            models.FeeKind(req.Kind),
+		FlatAmount:      req.FlatAmount,
+		BasisPoints:     req.BasisPoints,
+		MinFee:          req.MinFee,
+		MaxFee:          req.MaxFee,
+		Tiers:           req.Tiers,
+	})
+	if err != nil {
+		handleFeeError(c, err, "Failed to create fee rule")
+		return
+	}
+
+	successResponse(c, "Fee rule created successfully", rule)
+}
+
+func (h *Handlers) ListFeeRules(c *gin.Context) {
+	rules, err := h.useCases.Fee.ListRules()
+	if err != nil {
+		errorResponse(c, http.StatusInternalServerError, "Failed to list fee rules", err)
+		return
+	}
+
+	successResponse(c, "Fee rules retrieved successfully", rules)
+}
+
+func (h *Handlers) DeleteFeeRule(c *gin.Context) {
+	ruleID, err := uuid.Parse(c.Param("rule_id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid fee rule ID", err)
+		return
+	}
+
+	if err := h.useCases.Fee.DeleteRule(ruleID); err != nil {
+		handleFeeError(c, err, "Failed to delete fee rule")
+		return
+	}
+
+	successResponse(c, "Fee rule deleted successfully", nil)
+}
+
+func (h *Handlers) QuoteFee(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	var query FeeQuoteQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	quote, err := h.useCases.Fee.QuoteFee(userID, models.TransactionType(query.Type), query.Amount, query.Currency)
+	if err != nil {
+		handleFeeError(c, err, "Failed to quote fee")
+		return
+	}
+
+	successResponse(c, "Fee quoted successfully", quote)
+}
+
+func handleFeeError(c *gin.Context, err error, message string) {
+	switch err {
+	case usecases.ErrUserNotFound:
+		errorResponse(c, http.StatusNotFound, "User not found", err)
+	case usecases.ErrFeeRuleNotFound:
+		errorResponse(c, http.StatusNotFound, "Fee rule not found", err)
+	case usecases.ErrInvalidFeeRule:
+		errorResponse(c, http.StatusBadRequest, "Invalid fee rule", err)
+	case usecases.ErrNoFeesForType:
+		errorResponse(c, http.StatusBadRequest, "Fees are not charged on this transaction type", err)
+	case usecases.ErrUnsupportedCurrency:
+		errorResponse(c, http.StatusBadRequest, "Unsupported currency", err)
+	case usecases.ErrInvalidAmount:
+		errorResponse(c, http.StatusBadRequest, "Invalid amount", err)
+	default:
+		errorResponse(c, http.StatusInternalServerError, message, err)
+	}
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// FX DTOs
+
+type QuoteConversionRequest struct {
+	Amount       int64  `json:"amount" binding:"required,min=1"`
+	FromCurrency string `json:"from_currency" binding:"required,len=3"`
+	ToCurrency   string `json:"to_currency" binding:"required,len=3"`
+}
+
+type ConvertFundsRequest struct {
+	Amount       int64  `json:"amount" binding:"required,min=1"`
+	FromCurrency string `json:"from_currency" binding:"required,len=3"`
+	ToCurrency   string `json:"to_currency" binding:"required,len=3"`
+	QuoteID      string `json:"quote_id"` // Optional; locks the quoted rate
+	Reference    string `json:"reference" binding:"required"`
+}
+
+// FX Handlers
+
+func (h *Handlers) QuoteConversion(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	var req QuoteConversionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	quote, err := h.useCases.Wallet.QuoteConversion(userID, req.Amount, req.FromCurrency, req.ToCurrency)
+	if err != nil {
+		handleFXError(c, err, "Failed to quote conversion")
+		return
+	}
+
+	successResponse(c, "Conversion quoted successfully", quote)
+}
+
+func (h *Handlers) ConvertFunds(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	var req ConvertFundsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	var quoteID *uuid.UUID
+	if req.QuoteID != "" {
+		parsed, err := uuid.Parse(req.QuoteID)
+		if err != nil {
+			errorResponse(c, http.StatusBadRequest, "Invalid quote ID", err)
+			return
+		}
+		quoteID = &parsed
+	}
+
+	transaction, err := h.useCases.Wallet.ConvertFunds(userID, req.Amount, req.FromCurrency, req.ToCurrency, quoteID, req.Reference)
+	if err != nil {
+		handleFXError(c, err, "Failed to convert funds")
+		return
+	}
+
+	successResponse(c, "Funds converted successfully", transaction)
+}
+
+func handleFXError(c *gin.Context, err error, message string) {
+	if limitExceededResponse(c, err) || walletStatusResponse(c, err) {
+		return
+	}
+
+	switch err {
+	case usecases.ErrUserNotFound:
+		errorResponse(c, http.StatusNotFound, "User not found", err)
+	case usecases.ErrWalletNotFound:
+		errorResponse(c, http.StatusNotFound, "Wallet not found", err)
+	case usecases.ErrQuoteNotFound:
+		errorResponse(c, http.StatusNotFound, "Quote not found", err)
+	case usecases.ErrQuoteExpired:
+		errorResponse(c, http.StatusConflict, "Quote has expired", err)
+	case usecases.ErrQuoteUsed:
+		errorResponse(c, http.StatusConflict, "Quote has already been used", err)
+	case usecases.ErrQuoteMismatch:
+		errorResponse(c, http.StatusBadRequest, "Conversion does not match the quote", err)
+	case usecases.ErrRateUnavailable:
+		errorResponse(c, http.StatusServiceUnavailable, "Exchange rate unavailable", err)
+	case usecases.ErrUnsupportedCurrency:
+		errorResponse(c, http.StatusBadRequest, "Unsupported currency", err)
+	case usecases.ErrSameCurrency:
+		errorResponse(c, http.StatusBadRequest, "Cannot convert a currency into itself", err)
+	case usecases.ErrInsufficientFunds:
+		errorResponse(c, http.StatusBadRequest, "Insufficient funds", err)
+	case usecases.ErrInvalidAmount:
+		errorResponse(c, http.StatusBadRequest, "Invalid amount", err)
+	default:
+		errorResponse(c, http.StatusInternalServerError, message, err)
+	}
+}
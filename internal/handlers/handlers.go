@@ -1,8 +1,12 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
 
+	"github.com/Code-Linx/wallet-service/internal/auth"
+	"github.com/Code-Linx/wallet-service/internal/middleware"
+	"github.com/Code-Linx/wallet-service/internal/models"
 	"github.com/Code-Linx/wallet-service/internal/usecases"
 
 	"github.com/gin-gonic/gin"
@@ -12,6 +16,19 @@ import (
 // Handlers holds all HTTP handlers
 type Handlers struct {
 	useCases *usecases.UseCases
+	tokens   *auth.Tokens
+	signer   *auth.RequestSigner // Nil leaves request signing off
+}
+
+// movedRoutes maps routes that used to sit outside /admin to where they are now
+var movedRoutes = map[string]string{
+	"/fees/rules":              "/admin/fees/rules",
+	"/fees/rules/:rule_id":     "/admin/fees/rules/:rule_id",
+	"/limits/rules":            "/admin/limits/rules",
+	"/limits/rules/:rule_id":   "/admin/limits/rules/:rule_id",
+	"/interest/rules":          "/admin/interest/rules",
+	"/interest/rules/:rule_id": "/admin/interest/rules/:rule_id",
+	"/reconciliation/run":      "/admin/reconciliation/run",
 }
 
 func (h *Handlers) SetupRouter(handlers *Handlers) *gin.Engine {
@@ -19,31 +36,167 @@ func (h *Handlers) SetupRouter(handlers *Handlers) *gin.Engine {
 
 	api := router.Group("/api/v1")
 
-	{
-		// User routes
-	api.POST("/users", handlers.CreateUser)
-	api.GET("/users/:id", handlers.GetUser)
+	// Every route but the health check and token refresh needs an access
+	// token or an API key. Users may only act on their own :id; services act
+	// for any user. API keys only reach routes that name one of their scopes.
+	authenticated := api.Group("", middleware.Authenticate(handlers.tokens, handlers.useCases.APIKey))
+	selfCheck := middleware.RequireSelfOrRole("id", auth.RoleSuperadmin, auth.RoleService)
+	staffCheck := middleware.RequireRole(auth.RoleSuperadmin, auth.RoleService)
+	self := authenticated.Group("", selfCheck)
+	staff := authenticated.Group("", staffCheck)
+
+	// Admin routes are open to the admin roles, and each names the roles it
+	// needs on top; superadmins may use them all. Every request is recorded
+	// in the audit log with the admin who made it.
+	admin := authenticated.Group("", middleware.RequireRole(auth.AdminRoles...), middleware.Audit(handlers.useCases.Admin))
+	support := middleware.RequireAdmin(auth.RoleSupport)
+	finance := middleware.RequireAdmin(auth.RoleFinance)
+	supportOrFinance := middleware.RequireAdmin(auth.RoleSupport, auth.RoleFinance)
+	superadmin := middleware.RequireAdmin()
+
+	// Requests that move money must also be signed, so an amount cannot be
+	// changed in transit
+	signed := authenticated
+	if handlers.signer != nil {
+		signed = authenticated.Group("", middleware.RequireSignature(handlers.signer))
+	}
 
-	// Wallet routes
-	api.POST("/users/:id/wallet/fund", handlers.FundWallet)
-	api.POST("/users/:id/wallet/withdraw", handlers.WithdrawFunds)
-	api.POST("/users/:id/wallet/transfer", handlers.TransferFunds)
-	api.GET("/users/:id/wallet/transactions", handlers.GetTransactionHistory)
+	{
+		// Auth routes
+		staff.POST("/auth/token", handlers.IssueToken)
+		api.POST("/auth/refresh", handlers.RefreshToken)
 
-	// Reconciliation route
-	api.POST("/reconciliation/run", handlers.RunReconciliation)
+		// User routes
+		staff.POST("/users", handlers.CreateUser)
+		authenticated.GET("/users/:id", middleware.RequireScopeOr(auth.ScopeUsersRead, selfCheck), handlers.GetUser)
+
+		// Wallet routes
+		self.POST("/users/:id/wallets", handlers.OpenWallet)
+		authenticated.GET("/users/:id/wallets", middleware.RequireScopeOr(auth.ScopeUsersRead, selfCheck), handlers.ListWallets)
+		signed.POST("/users/:id/wallet/fund", middleware.RequireScopeOr(auth.ScopeWalletFund, selfCheck), handlers.FundWallet)
+		signed.POST("/users/:id/wallet/withdraw", middleware.RequireScopeOr(auth.ScopeWalletWithdraw, selfCheck), handlers.WithdrawFunds)
+		signed.POST("/users/:id/wallet/transfer", middleware.RequireScopeOr(auth.ScopeWalletTransfer, selfCheck), handlers.TransferFunds)
+		signed.POST("/users/:id/wallet/split", middleware.RequireScopeOr(auth.ScopeWalletTransfer, selfCheck), handlers.SplitPayment)
+		authenticated.GET("/users/:id/wallet/transactions", middleware.RequireScopeOr(auth.ScopeUsersRead, selfCheck), handlers.GetTransactionHistory)
+
+		// Escrow routes
+		self.POST("/users/:id/escrows", handlers.CreateEscrow)
+		self.GET("/users/:id/escrows/:escrow_id", handlers.GetEscrow)
+		self.POST("/users/:id/escrows/:escrow_id/release", handlers.ReleaseEscrow)
+		self.POST("/users/:id/escrows/:escrow_id/cancel", handlers.CancelEscrow)
+		self.POST("/users/:id/escrows/:escrow_id/dispute", handlers.DisputeEscrow)
+		admin.POST("/admin/escrows/:escrow_id/resolve", support, handlers.ResolveEscrow)
+
+		// Payment request routes
+		self.POST("/users/:id/payment-requests", handlers.CreatePaymentRequest)
+		self.GET("/users/:id/payment-requests/incoming", handlers.ListIncomingPaymentRequests)
+		self.GET("/users/:id/payment-requests/outgoing", handlers.ListOutgoingPaymentRequests)
+		self.GET("/users/:id/payment-requests/:request_id", handlers.GetPaymentRequest)
+		self.POST("/users/:id/payment-requests/:request_id/accept", handlers.AcceptPaymentRequest)
+		self.POST("/users/:id/payment-requests/:request_id/decline", handlers.DeclinePaymentRequest)
+
+		// Pocket routes
+		self.POST("/users/:id/pockets", handlers.CreatePocket)
+		self.POST("/users/:id/pockets/move", handlers.MoveFunds)
+
+		// Hold routes
+		self.POST("/users/:id/wallet/holds", handlers.PlaceHold)
+		self.GET("/users/:id/wallet/holds/:hold_id", handlers.GetHold)
+		self.POST("/users/:id/wallet/holds/:hold_id/capture", handlers.CaptureHold)
+		self.POST("/users/:id/wallet/holds/:hold_id/void", handlers.VoidHold)
+
+		// FX routes
+		self.POST("/users/:id/wallet/fx/quotes", handlers.QuoteConversion)
+		self.POST("/users/:id/wallet/convert", handlers.ConvertFunds)
+
+		// Fee routes
+		self.GET("/users/:id/wallet/fees/quote", handlers.QuoteFee)
+		admin.POST("/admin/fees/rules", finance, handlers.CreateFeeRule)
+		admin.GET("/admin/fees/rules", finance, handlers.ListFeeRules)
+		admin.DELETE("/admin/fees/rules/:rule_id", finance, handlers.DeleteFeeRule)
+
+		// Limit routes
+		admin.POST("/admin/limits/rules", finance, handlers.CreateLimitRule)
+		admin.GET("/admin/limits/rules", finance, handlers.ListLimitRules)
+		admin.DELETE("/admin/limits/rules/:rule_id", finance, handlers.DeleteLimitRule)
+
+		// Interest routes
+		self.GET("/users/:id/wallets/:wallet_id/interest", handlers.ListInterestAccruals)
+		admin.POST("/admin/interest/rules", finance, handlers.CreateInterestRule)
+		admin.GET("/admin/interest/rules", finance, handlers.ListInterestRules)
+		admin.DELETE("/admin/interest/rules/:rule_id", finance, handlers.DeleteInterestRule)
+
+		// Scheduled transfer routes
+		self.POST("/users/:id/scheduled-transfers", handlers.CreateScheduledTransfer)
+		self.GET("/users/:id/scheduled-transfers", handlers.ListScheduledTransfers)
+		self.GET("/users/:id/scheduled-transfers/:transfer_id", handlers.GetScheduledTransfer)
+		self.PATCH("/users/:id/scheduled-transfers/:transfer_id", handlers.UpdateScheduledTransfer)
+		self.DELETE("/users/:id/scheduled-transfers/:transfer_id", handlers.CancelScheduledTransfer)
+		self.GET("/users/:id/scheduled-transfers/:transfer_id/runs", handlers.ListScheduledTransferRuns)
+
+		// Payout routes
+		self.POST("/users/:id/payouts", handlers.CreatePayoutBatch)
+		self.GET("/users/:id/payouts", handlers.ListPayoutBatches)
+		self.GET("/users/:id/payouts/:batch_id", handlers.GetPayoutBatch)
+
+		// Promotional credit routes
+		self.GET("/users/:id/wallet/promo-credits", handlers.ListPromoCredits)
+		admin.POST("/admin/users/:id/promo-credits", finance, handlers.GrantPromoCredit)
+		admin.POST("/admin/users/:id/merchant", support, handlers.SetMerchant)
+
+		// Voucher routes
+		self.POST("/users/:id/vouchers/redeem", handlers.RedeemVoucher)
+		admin.POST("/admin/voucher-batches", finance, handlers.GenerateVoucherBatch)
+		admin.GET("/admin/voucher-batches", finance, handlers.ListVoucherBatches)
+		admin.GET("/admin/voucher-batches/:batch_id", finance, handlers.GetVoucherBatch)
+		admin.GET("/admin/voucher-batches/:batch_id/export", finance, handlers.ExportVoucherBatch)
+
+		// Wallet status routes
+		admin.POST("/admin/wallets/:wallet_id/status", support, handlers.ChangeWalletStatus)
+		admin.GET("/admin/wallets/:wallet_id/status-history", support, handlers.ListWalletStatusChanges)
+		admin.POST("/admin/wallets/:wallet_id/credit-line", finance, handlers.SetCreditLine)
+
+		// API key routes
+		admin.POST("/admin/api-keys", superadmin, handlers.CreateAPIKey)
+		admin.GET("/admin/api-keys", superadmin, handlers.ListAPIKeys)
+		admin.DELETE("/admin/api-keys/:key_id", superadmin, handlers.RevokeAPIKey)
+
+		// Admin user and audit routes
+		admin.GET("/admin/users", supportOrFinance, handlers.SearchUsers)
+		admin.GET("/admin/users/:id", supportOrFinance, handlers.GetUser)
+		admin.GET("/admin/users/:id/transactions", supportOrFinance, handlers.GetTransactionHistory)
+		admin.POST("/admin/reconciliation/run", finance, handlers.RunReconciliation)
+		admin.GET("/admin/audit-log", superadmin, handlers.ListAuditLog)
+
+		// Adjustment routes: one admin proposes, others approve or reject
+		admin.POST("/admin/wallets/:wallet_id/adjustments", finance, handlers.ProposeAdjustment)
+		admin.GET("/admin/adjustments", finance, handlers.ListAdjustments)
+		admin.GET("/admin/adjustments/:adjustment_id", finance, handlers.GetAdjustment)
+		admin.POST("/admin/adjustments/:adjustment_id/approve", finance, handlers.ApproveAdjustment)
+		admin.POST("/admin/adjustments/:adjustment_id/reject", finance, handlers.RejectAdjustment)
+
+		// Refund routes
+		staff.POST("/transactions/:id/refund", handlers.RefundTransaction)
+		staff.POST("/transactions/:id/reverse", handlers.ReverseTransaction)
+
+		// Routes that moved under /admin answer 410 Gone with their new path
+		for from, to := range movedRoutes {
+			api.Any(from, movedTo("/api/v1"+to))
+		}
 
-	// Health check route
-	api.GET("/health", handlers.HealthCheck)
+		// Health check route
+		api.GET("/health", handlers.HealthCheck)
 	}
 
 	return router
 }
 
 // NewHandlers creates new handler instances
-func NewHandlers(useCases *usecases.UseCases) *Handlers {
+func NewHandlers(useCases *usecases.UseCases, tokens *auth.Tokens, signer *auth.RequestSigner) *Handlers {
 	return &Handlers{
 		useCases: useCases,
+		tokens:   tokens,
+		signer:   signer,
 	}
 }
 
@@ -54,25 +207,33 @@ type CreateUserRequest struct {
 	Email string `json:"email" binding:"required,email"`
 }
 
+type OpenWalletRequest struct {
+	Currency string `json:"currency" binding:"required,len=3"`
+}
+
 type FundWalletRequest struct {
 	Amount    int64  `json:"amount" binding:"required,min=1"`
+	Currency  string `json:"currency"` // Defaults to USD
 	Reference string `json:"reference" binding:"required"`
 }
 
 type WithdrawFundsRequest struct {
 	Amount    int64  `json:"amount" binding:"required,min=1"`
+	Currency  string `json:"currency"` // Defaults to USD
 	Reference string `json:"reference" binding:"required"`
 }
 
 type TransferFundsRequest struct {
 	ToUserID  string `json:"to_user_id" binding:"required"`
 	Amount    int64  `json:"amount" binding:"required,min=1"`
+	Currency  string `json:"currency"` // Defaults to USD
 	Reference string `json:"reference" binding:"required"`
 }
 
 type TransactionHistoryQuery struct {
-	Page     int `form:"page,default=1" binding:"min=1"`
-	PageSize int `form:"page_size,default=10" binding:"min=1,max=100"`
+	Page     int    `form:"page,default=1" binding:"min=1"`
+	PageSize int    `form:"page_size,default=10" binding:"min=1,max=100"`
+	WalletID string `form:"wallet_id"` // Only transactions that touched this wallet
 }
 
 type APIResponse struct {
@@ -100,6 +261,13 @@ func successResponse(c *gin.Context, message string, data interface{}) {
 	})
 }
 
+// movedTo answers a route that has moved, naming its new path
+func movedTo(path string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		errorResponse(c, http.StatusGone, "This route has moved", fmt.Errorf("use %s", path))
+	}
+}
+
 func errorResponse(c *gin.Context, statusCode int, message string, err error) {
 	response := APIResponse{
 		Success: false,
@@ -111,7 +279,7 @@ func errorResponse(c *gin.Context, statusCode int, message string, err error) {
 	c.JSON(statusCode, response)
 }
 
-func paginatedResponse(c *gin.Context, data interface{}, page, pageSize int, total int64) {
+func paginatedResponse(c *gin.Context, message string, data interface{}, page, pageSize int, total int64) {
 	totalPages := int((total + int64(pageSize) - 1) / int64(pageSize))
 
 	response := PaginatedResponse{
@@ -124,7 +292,7 @@ func paginatedResponse(c *gin.Context, data interface{}, page, pageSize int, tot
 
 	c.JSON(http.StatusOK, APIResponse{
 		Success: true,
-		Message: "Transactions retrieved successfully",
+		Message: message,
 		Data:    response,
 	})
 }
@@ -174,6 +342,66 @@ func (h *Handlers) GetUser(c *gin.Context) {
 
 // Wallet Handlers
 
+func (h *Handlers) OpenWallet(c *gin.Context) {
+	userIDStr := c.Param("id")
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	var req OpenWalletRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	wallet, err := h.useCases.Wallet.OpenWallet(userID, req.Currency)
+	if err != nil {
+		if err == usecases.ErrUserNotFound {
+			errorResponse(c, http.StatusNotFound, "User not found", err)
+			return
+		}
+		if err == usecases.ErrUnsupportedCurrency {
+			errorResponse(c, http.StatusBadRequest, "Unsupported currency", err)
+			return
+		}
+		if err == usecases.ErrWalletExists {
+			errorResponse(c, http.StatusConflict, "Wallet already exists", err)
+			return
+		}
+		if err == usecases.ErrWalletClosed {
+			errorResponse(c, http.StatusConflict, "Wallet for this currency has been closed", err)
+			return
+		}
+		errorResponse(c, http.StatusInternalServerError, "Failed to open wallet", err)
+		return
+	}
+
+	successResponse(c, "Wallet opened successfully", wallet)
+}
+
+func (h *Handlers) ListWallets(c *gin.Context) {
+	userIDStr := c.Param("id")
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	wallets, err := h.useCases.Wallet.ListWallets(userID)
+	if err != nil {
+		if err == usecases.ErrUserNotFound {
+			errorResponse(c, http.StatusNotFound, "User not found", err)
+			return
+		}
+		errorResponse(c, http.StatusInternalServerError, "Failed to list wallets", err)
+		return
+	}
+
+	successResponse(c, "Wallets retrieved successfully", wallets)
+}
+
 func (h *Handlers) FundWallet(c *gin.Context) {
 	userIDStr := c.Param("id")
 	userID, err := uuid.Parse(userIDStr)
@@ -188,7 +416,7 @@ func (h *Handlers) FundWallet(c *gin.Context) {
 		return
 	}
 
-	transaction, err := h.useCases.Wallet.FundWallet(userID, req.Amount, req.Reference)
+	transaction, err := h.useCases.Wallet.FundWallet(userID, req.Amount, req.Currency, req.Reference)
 	if err != nil {
 		if err == usecases.ErrUserNotFound {
 			errorResponse(c, http.StatusNotFound, "User not found", err)
@@ -198,6 +426,24 @@ func (h *Handlers) FundWallet(c *gin.Context) {
 			errorResponse(c, http.StatusBadRequest, "Invalid amount", err)
 			return
 		}
+		if err == usecases.ErrUnsupportedCurrency {
+			errorResponse(c, http.StatusBadRequest, "Unsupported currency", err)
+			return
+		}
+		if err == usecases.ErrWalletNotFound {
+			errorResponse(c, http.StatusNotFound, "Wallet not found", err)
+			return
+		}
+		if err == usecases.ErrInsufficientFunds {
+			errorResponse(c, http.StatusBadRequest, "Insufficient funds to cover the fee", err)
+			return
+		}
+		if limitExceededResponse(c, err) {
+			return
+		}
+		if walletStatusResponse(c, err) {
+			return
+		}
 		errorResponse(c, http.StatusInternalServerError, "Failed to fund wallet", err)
 		return
 	}
@@ -219,7 +465,7 @@ func (h *Handlers) WithdrawFunds(c *gin.Context) {
 		return
 	}
 
-	transaction, err := h.useCases.Wallet.WithdrawFunds(userID, req.Amount, req.Reference)
+	transaction, err := h.useCases.Wallet.WithdrawFunds(userID, req.Amount, req.Currency, req.Reference)
 	if err != nil {
 		if err == usecases.ErrUserNotFound {
 			errorResponse(c, http.StatusNotFound, "User not found", err)
@@ -233,6 +479,20 @@ func (h *Handlers) WithdrawFunds(c *gin.Context) {
 			errorResponse(c, http.StatusBadRequest, "Insufficient funds", err)
 			return
 		}
+		if err == usecases.ErrUnsupportedCurrency {
+			errorResponse(c, http.StatusBadRequest, "Unsupported currency", err)
+			return
+		}
+		if err == usecases.ErrWalletNotFound {
+			errorResponse(c, http.StatusNotFound, "Wallet not found", err)
+			return
+		}
+		if limitExceededResponse(c, err) {
+			return
+		}
+		if walletStatusResponse(c, err) {
+			return
+		}
 		errorResponse(c, http.StatusInternalServerError, "Failed to withdraw funds", err)
 		return
 	}
@@ -260,12 +520,16 @@ func (h *Handlers) TransferFunds(c *gin.Context) {
 		return
 	}
 
-	transaction, err := h.useCases.Wallet.TransferFunds(fromUserID, toUserID, req.Amount, req.Reference)
+	transaction, err := h.useCases.Wallet.TransferFunds(fromUserID, toUserID, req.Amount, req.Currency, req.Reference)
 	if err != nil {
 		if err == usecases.ErrUserNotFound {
 			errorResponse(c, http.StatusNotFound, "User not found", err)
 			return
 		}
+		if err == usecases.ErrRecipientNotFound {
+			errorResponse(c, http.StatusNotFound, "Recipient not found", err)
+			return
+		}
 		if err == usecases.ErrInvalidAmount {
 			errorResponse(c, http.StatusBadRequest, "Invalid amount", err)
 			return
@@ -278,6 +542,24 @@ func (h *Handlers) TransferFunds(c *gin.Context) {
 			errorResponse(c, http.StatusBadRequest, "Cannot transfer to the same user", err)
 			return
 		}
+		if err == usecases.ErrUnsupportedCurrency {
+			errorResponse(c, http.StatusBadRequest, "Unsupported currency", err)
+			return
+		}
+		if err == usecases.ErrWalletNotFound {
+			errorResponse(c, http.StatusNotFound, "Wallet not found", err)
+			return
+		}
+		if err == usecases.ErrCurrencyMismatch {
+			errorResponse(c, http.StatusBadRequest, "Recipient has no wallet in this currency", err)
+			return
+		}
+		if limitExceededResponse(c, err) {
+			return
+		}
+		if walletStatusResponse(c, err) {
+			return
+		}
 		errorResponse(c, http.StatusInternalServerError, "Failed to transfer funds", err)
 		return
 	}
@@ -299,13 +581,28 @@ func (h *Handlers) GetTransactionHistory(c *gin.Context) {
 		return
 	}
 
-	transactions, total, err := h.useCases.Wallet.GetTransactionHistory(userID, query.Page, query.PageSize)
+	var transactions []models.Transaction
+	var total int64
+	if query.WalletID != "" {
+		walletID, err := uuid.Parse(query.WalletID)
+		if err != nil {
+			errorResponse(c, http.StatusBadRequest, "Invalid wallet ID", err)
+			return
+		}
+		transactions, total, err = h.useCases.Wallet.GetWalletTransactionHistory(userID, walletID, query.Page, query.PageSize)
+	} else {
+		transactions, total, err = h.useCases.Wallet.GetTransactionHistory(userID, query.Page, query.PageSize)
+	}
 	if err != nil {
+		if err == usecases.ErrWalletNotFound {
+			errorResponse(c, http.StatusNotFound, "Wallet not found", err)
+			return
+		}
 		errorResponse(c, http.StatusInternalServerError, "Failed to get transaction history", err)
 		return
 	}
 
-	paginatedResponse(c, transactions, query.Page, query.PageSize, total)
+	paginatedResponse(c, "Transactions retrieved successfully", transactions, query.Page, query.PageSize, total)
 }
 
 // Reconciliation Handlers
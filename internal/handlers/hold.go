@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Hold DTOs
+
+type PlaceHoldRequest struct {
+	Amount     int64  `json:"amount" binding:"required,min=1"`
+	Currency   string `json:"currency"` // Defaults to USD
+	Reference  string `json:"reference" binding:"required"`
+	TTLSeconds int64  `json:"ttl_seconds" binding:"min=0"`
+}
+
+type CaptureHoldRequest struct {
+	Amount int64 `json:"amount" binding:"min=0"` // Zero captures the full hold
+}
+
+// Hold Handlers
+
+func (h *Handlers) PlaceHold(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	var req PlaceHoldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	ttl := time.Duration(req.TTLSeconds) * time.Second
+	hold, err := h.useCases.Wallet.PlaceHold(userID, req.Amount, req.Currency, ttl, req.Reference)
+	if err != nil {
+		if err == usecases.ErrUserNotFound {
+			errorResponse(c, http.StatusNotFound, "User not found", err)
+			return
+		}
+		if err == usecases.ErrInvalidAmount {
+			errorResponse(c, http.StatusBadRequest, "Invalid amount", err)
+			return
+		}
+		if err == usecases.ErrInsufficientFunds {
+			errorResponse(c, http.StatusBadRequest, "Insufficient funds", err)
+			return
+		}
+		if err == usecases.ErrUnsupportedCurrency {
+			errorResponse(c, http.StatusBadRequest, "Unsupported currency", err)
+			return
+		}
+		if err == usecases.ErrWalletNotFound {
+			errorResponse(c, http.StatusNotFound, "Wallet not found", err)
+			return
+		}
+		if walletStatusResponse(c, err) {
+			return
+		}
+		errorResponse(c, http.StatusInternalServerError, "Failed to place hold", err)
+		return
+	}
+
+	successResponse(c, "Hold placed successfully", hold)
+}
+
+func (h *Handlers) GetHold(c *gin.Context) {
+	userID, holdID, ok := parseHoldParams(c)
+	if !ok {
+		return
+	}
+
+	hold, err := h.useCases.Wallet.GetHold(userID, holdID)
+	if err != nil {
+		handleHoldError(c, err, "Failed to get hold")
+		return
+	}
+
+	successResponse(c, "Hold retrieved successfully", hold)
+}
+
+func (h *Handlers) CaptureHold(c *gin.Context) {
+	userID, holdID, ok := parseHoldParams(c)
+	if !ok {
+		return
+	}
+
+	var req CaptureHoldRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			errorResponse(c, http.StatusBadRequest, "Invalid request data", err)
+			return
+		}
+	}
+
+	transaction, err := h.useCases.Wallet.CaptureHold(userID, holdID, req.Amount)
+	if err != nil {
+		handleHoldError(c, err, "Failed to capture hold")
+		return
+	}
+
+	successResponse(c, "Hold captured successfully", transaction)
+}
+
+func (h *Handlers) VoidHold(c *gin.Context) {
+	userID, holdID, ok := parseHoldParams(c)
+	if !ok {
+		return
+	}
+
+	hold, err := h.useCases.Wallet.VoidHold(userID, holdID)
+	if err != nil {
+		handleHoldError(c, err, "Failed to void hold")
+		return
+	}
+
+	successResponse(c, "Hold voided successfully", hold)
+}
+
+// parseHoldParams reads the user and hold IDs from the path, writing an error response if either is invalid
+func parseHoldParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	holdID, err := uuid.Parse(c.Param("hold_id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid hold ID", err)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return userID, holdID, true
+}
+
+func handleHoldError(c *gin.Context, err error, message string) {
+	if walletStatusResponse(c, err) {
+		return
+	}
+
+	switch err {
+	case usecases.ErrHoldNotFound:
+		errorResponse(c, http.StatusNotFound, "Hold not found", err)
+	case usecases.ErrHoldNotActive:
+		errorResponse(c, http.StatusConflict, "Hold is no longer active", err)
+	case usecases.ErrHoldExpired:
+		errorResponse(c, http.StatusConflict, "Hold has expired", err)
+	case usecases.ErrInvalidAmount:
+		errorResponse(c, http.StatusBadRequest, "Invalid amount", err)
+	default:
+		errorResponse(c, http.StatusInternalServerError, message, err)
+	}
+}
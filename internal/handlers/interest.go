@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Interest DTOs
+
+type CreateInterestRuleRequest struct {
+	Product       string `json:"product" binding:"omitempty,oneof=primary pocket"` // Empty matches any product
+	Tier          string `json:"tier"`                                             // Empty matches any tier
+	Currency      string `json:"currency"`                                         // Empty matches any currency
+	AnnualRateBps int64  `json:"annual_rate_bps" binding:"min=0,max=10000"`
+}
+
+// Interest Handlers
+
+func (h *Handlers) CreateInterestRule(c *gin.Context) {
+	var req CreateInterestRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	rule, err := h.useCases.Interest.CreateRule(&models.InterestRateRule{
+		Product:       models.InterestProduct(req.Product),
+		Tier:          req.Tier,
+		Currency:      req.Currency,
+		AnnualRateBps: req.AnnualRateBps,
+	})
+	if err != nil {
+		handleInterestError(c, err, "Failed to create interest rule")
+		return
+	}
+
+	successResponse(c, "Interest rule created successfully", rule)
+}
+
+func (h *Handlers) ListInterestRules(c *gin.Context) {
+	rules, err := h.useCases.Interest.ListRules()
+	if err != nil {
+		errorResponse(c, http.StatusInternalServerError, "Failed to list interest rules", err)
+		return
+	}
+
+	successResponse(c, "Interest rules retrieved successfully", rules)
+}
+
+func (h *Handlers) DeleteInterestRule(c *gin.Context) {
+	ruleID, err := uuid.Parse(c.Param("rule_id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid interest rule ID", err)
+		return
+	}
+
+	if err := h.useCases.Interest.DeleteRule(ruleID); err != nil {
+		handleInterestError(c, err, "Failed to delete interest rule")
+		return
+	}
+
+	successResponse(c, "Interest rule deleted successfully", nil)
+}
+
+func (h *Handlers) ListInterestAccruals(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	walletID, err := uuid.Parse(c.Param("wallet_id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid wallet ID", err)
+		return
+	}
+
+	accruals, err := h.useCases.Interest.ListAccruals(userID, walletID)
+	if err != nil {
+		handleInterestError(c, err, "Failed to list interest accruals")
+		return
+	}
+
+	successResponse(c, "Interest accruals retrieved successfully", accruals)
+}
+
+func handleInterestError(c *gin.Context, err error, message string) {
+	switch err {
+	case usecases.ErrWalletNotFound:
+		errorResponse(c, http.StatusNotFound, "Wallet not found", err)
+	case usecases.ErrInterestRuleNotFound:
+		errorResponse(c, http.StatusNotFound, "Interest rule not found", err)
+	case usecases.ErrInvalidInterestRule:
+		errorResponse(c, http.StatusBadRequest, "Invalid interest rule", err)
+	case usecases.ErrUnsupportedCurrency:
+		errorResponse(c, http.StatusBadRequest, "Unsupported currency", err)
+	default:
+		errorResponse(c, http.StatusInternalServerError, message, err)
+	}
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Limit DTOs
+
+type CreateLimitRuleRequest struct {
+	TransactionType string `json:"transaction_type" binding:"required,oneof=credit debit transfer conversion"`
+	Currency        string `json:"currency"` // Empty matches any currency
+	UserID          string `json:"user_id"`  // Set for a per-user limit
+	Tier            string `json:"tier"`     // Set for a per-tier limit
+	Period          string `json:"period" binding:"required,oneof=transaction day week month"`
+	MaxAmount       int64  `json:"max_amount" binding:"min=0"`
+	MaxCount        int64  `json:"max_count" binding:"min=0"`
+}
+
+// Limit Handlers
+
+func (h *Handlers) CreateLimitRule(c *gin.Context) {
+	var req CreateLimitRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	rule := &models.LimitRule{
+		TransactionType: models.TransactionType(req.TransactionType),
+		Currency:        req.Currency,
+		Tier:            req.Tier,
+		Period:          models.LimitPeriod(req.Period),
+		MaxAmount:       req.MaxAmount,
+		MaxCount:        req.MaxCount,
+	}
+	if req.UserID != "" {
+		userID, err := uuid.Parse(req.UserID)
+		if err != nil {
+			errorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+			return
+		}
+		rule.UserID = &userID
+	}
+
+	rule, err := h.useCases.Limit.CreateRule(rule)
+	if err != nil {
+		handleLimitRuleError(c, err, "Failed to create limit rule")
+		return
+	}
+
+	successResponse(c, "Limit rule created successfully", rule)
+}
+
+func (h *Handlers) ListLimitRules(c *gin.Context) {
+	rules, err := h.useCases.Limit.ListRules()
+	if err != nil {
+		errorResponse(c, http.StatusInternalServerError, "Failed to list limit rules", err)
+		return
+	}
+
+	successResponse(c, "Limit rules retrieved successfully", rules)
+}
+
+func (h *Handlers) DeleteLimitRule(c *gin.Context) {
+	ruleID, err := uuid.Parse(c.Param("rule_id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid limit rule ID", err)
+		return
+	}
+
+	if err := h.useCases.Limit.DeleteRule(ruleID); err != nil {
+		handleLimitRuleError(c, err, "Failed to delete limit rule")
+		return
+	}
+
+	successResponse(c, "Limit rule deleted successfully", nil)
+}
+
+func handleLimitRuleError(c *gin.Context, err error, message string) {
+	switch err {
+	case usecases.ErrUserNotFound:
+		errorResponse(c, http.StatusNotFound, "User not found", err)
+	case usecases.ErrLimitRuleNotFound:
+		errorResponse(c, http.StatusNotFound, "Limit rule not found", err)
+	case usecases.ErrInvalidLimitRule:
+		errorResponse(c, http.StatusBadRequest, "Invalid limit rule", err)
+	case usecases.ErrUnsupportedCurrency:
+		errorResponse(c, http.StatusBadRequest, "Unsupported currency", err)
+	default:
+		errorResponse(c, http.StatusInternalServerError, message, err)
+	}
+}
+
+// limitExceededResponse writes a 429 with the details of the limit hit and
+// reports whether err was a limit error
+func limitExceededResponse(c *gin.Context, err error) bool {
+	var limitErr *usecases.LimitExceededError
+	if !errors.As(err, &limitErr) {
+		return false
+	}
+
+	if limitErr.ResetsAt != nil {
+		retryAfter := int(time.Until(*limitErr.ResetsAt).Seconds()) + 1
+		if retryAfter > 0 {
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+		}
+	}
+
+	c.JSON(http.StatusTooManyRequests, APIResponse{
+		Success: false,
+		Message: "Transaction limit exceeded",
+		Data:    limitErr,
+		Error:   limitErr.Error(),
+	})
+	return true
+}
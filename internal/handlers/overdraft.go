@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Overdraft DTOs
+
+type SetCreditLineRequest struct {
+	CreditLimit      int64 `json:"credit_limit" binding:"min=0"`       // 0 removes the credit line
+	OverdraftRateBps int64 `json:"overdraft_rate_bps" binding:"min=0"` // Annual, in basis points
+}
+
+// Overdraft Handlers
+
+func (h *Handlers) SetCreditLine(c *gin.Context) {
+	walletID, err := uuid.Parse(c.Param("wallet_id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid wallet ID", err)
+		return
+	}
+
+	var req SetCreditLineRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	wallet, err := h.useCases.Wallet.SetCreditLine(walletID, req.CreditLimit, req.OverdraftRateBps)
+	if err != nil {
+		handleOverdraftError(c, err, "Failed to set credit line")
+		return
+	}
+
+	successResponse(c, "Credit line updated successfully", wallet)
+}
+
+func handleOverdraftError(c *gin.Context, err error, message string) {
+	switch err {
+	case usecases.ErrWalletNotFound:
+		errorResponse(c, http.StatusNotFound, "Wallet not found", err)
+	case usecases.ErrWalletClosed:
+		errorResponse(c, http.StatusConflict, "Wallet is closed", err)
+	case usecases.ErrInvalidCreditLine:
+		errorResponse(c, http.StatusBadRequest, "Invalid credit line", err)
+	case usecases.ErrCreditLimitBelowUsage:
+		errorResponse(c, http.StatusConflict, "Wallet is overdrawn by more than the new limit", err)
+	default:
+		errorResponse(c, http.StatusInternalServerError, message, err)
+	}
+}
@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Payment Request DTOs
+
+type CreatePaymentRequestRequest struct {
+	PayerID          string `json:"payer_id" binding:"required"`
+	Amount           int64  `json:"amount" binding:"required,min=1"`
+	Currency         string `json:"currency"` // Defaults to USD
+	Memo             string `json:"memo" binding:"max=255"`
+	Reference        string `json:"reference" binding:"required"`
+	ExpiresInSeconds int64  `json:"expires_in_seconds" binding:"min=0"` // Defaults to 7 days
+}
+
+type ListPaymentRequestsQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending accepted declined expired"`
+}
+
+// Payment Request Handlers
+
+func (h *Handlers) CreatePaymentRequest(c *gin.Context) {
+	requesterID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	var req CreatePaymentRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	payerID, err := uuid.Parse(req.PayerID)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid payer user ID", err)
+		return
+	}
+
+	request := &models.PaymentRequest{
+		RequesterID: requesterID,
+		PayerID:     payerID,
+		Amount:      req.Amount,
+		Currency:    req.Currency,
+		Memo:        req.Memo,
+		Reference:   req.Reference,
+	}
+
+	ttl := time.Duration(req.ExpiresInSeconds) * time.Second
+	request, err = h.useCases.PaymentRequest.Create(request, ttl)
+	if err != nil {
+		handlePaymentRequestError(c, err, "Failed to create payment request")
+		return
+	}
+
+	successResponse(c, "Payment request created successfully", request)
+}
+
+func (h *Handlers) ListIncomingPaymentRequests(c *gin.Context) {
+	h.listPaymentRequests(c, h.useCases.PaymentRequest.ListIncoming)
+}
+
+func (h *Handlers) ListOutgoingPaymentRequests(c *gin.Context) {
+	h.listPaymentRequests(c, h.useCases.PaymentRequest.ListOutgoing)
+}
+
+func (h *Handlers) GetPaymentRequest(c *gin.Context) {
+	userID, requestID, ok := parsePaymentRequestParams(c)
+	if !ok {
+		return
+	}
+
+	request, err := h.useCases.PaymentRequest.Get(userID, requestID)
+	if err != nil {
+		handlePaymentRequestError(c, err, "Failed to get payment request")
+		return
+	}
+
+	successResponse(c, "Payment request retrieved successfully", request)
+}
+
+func (h *Handlers) AcceptPaymentRequest(c *gin.Context) {
+	userID, requestID, ok := parsePaymentRequestParams(c)
+	if !ok {
+		return
+	}
+
+	request, err := h.useCases.PaymentRequest.Accept(userID, requestID)
+	if err != nil {
+		handlePaymentRequestError(c, err, "Failed to accept payment request")
+		return
+	}
+
+	successResponse(c, "Payment request accepted successfully", request)
+}
+
+func (h *Handlers) DeclinePaymentRequest(c *gin.Context) {
+	userID, requestID, ok := parsePaymentRequestParams(c)
+	if !ok {
+		return
+	}
+
+	request, err := h.useCases.PaymentRequest.Decline(userID, requestID)
+	if err != nil {
+		handlePaymentRequestError(c, err, "Failed to decline payment request")
+		return
+	}
+
+	successResponse(c, "Payment request declined successfully", request)
+}
+
+// listPaymentRequests serves one direction of a user's payment requests
+func (h *Handlers) listPaymentRequests(c *gin.Context, list func(uuid.UUID, models.PaymentRequestStatus) ([]models.PaymentRequest, error)) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	var query ListPaymentRequestsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	requests, err := list(userID, models.PaymentRequestStatus(query.Status))
+	if err != nil {
+		handlePaymentRequestError(c, err, "Failed to list payment requests")
+		return
+	}
+
+	successResponse(c, "Payment requests retrieved successfully", requests)
+}
+
+// parsePaymentRequestParams reads the user and payment request IDs from the path, writing an error response if either is invalid
+func parsePaymentRequestParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	requestID, err := uuid.Parse(c.Param("request_id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid payment request ID", err)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return userID, requestID, true
+}
+
+func handlePaymentRequestError(c *gin.Context, err error, message string) {
+	if limitExceededResponse(c, err) || walletStatusResponse(c, err) {
+		return
+	}
+
+	switch err {
+	case usecases.ErrUserNotFound:
+		errorResponse(c, http.StatusNotFound, "User not found", err)
+	case usecases.ErrWalletNotFound:
+		errorResponse(c, http.StatusNotFound, "Wallet not found", err)
+	case usecases.ErrPaymentRequestNotFound:
+		errorResponse(c, http.StatusNotFound, "Payment request not found", err)
+	case usecases.ErrPaymentRequestForbidden:
+		errorResponse(c, http.StatusForbidden, "Not allowed for this user", err)
+	case usecases.ErrPaymentRequestNotPending:
+		errorResponse(c, http.StatusConflict, "Payment request has already been answered", err)
+	case usecases.ErrPaymentRequestExpired:
+		errorResponse(c, http.StatusConflict, "Payment request has expired", err)
+	case usecases.ErrTransactionExists:
+		errorResponse(c, http.StatusConflict, "Reference already used by another payment request", err)
+	case usecases.ErrMemoTooLong:
+		errorResponse(c, http.StatusBadRequest, "Memo is too long", err)
+	case usecases.ErrInvalidAmount:
+		errorResponse(c, http.StatusBadRequest, "Invalid amount", err)
+	case usecases.ErrInsufficientFunds:
+		errorResponse(c, http.StatusBadRequest, "Insufficient funds", err)
+	case usecases.ErrSameUser:
+		errorResponse(c, http.StatusBadRequest, "Cannot request money from yourself", err)
+	case usecases.ErrUnsupportedCurrency:
+		errorResponse(c, http.StatusBadRequest, "Unsupported currency", err)
+	case usecases.ErrCurrencyMismatch:
+		errorResponse(c, http.StatusBadRequest, "Requester has no wallet in this currency", err)
+	default:
+		errorResponse(c, http.StatusInternalServerError, message, err)
+	}
+}
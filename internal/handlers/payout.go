@@ -0,0 +1,279 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Payout DTOs
+
+type CreatePayoutBatchRequest struct {
+	Reference string              `json:"reference" binding:"required"`
+	Currency  string              `json:"currency"` // Defaults to USD
+	Mode      string              `json:"mode" binding:"required,oneof=all_or_nothing best_effort"`
+	Items     []PayoutItemRequest `json:"items" binding:"required,min=1"`
+}
+
+type PayoutItemRequest struct {
+	ToUserID  string `json:"to_user_id"`
+	Amount    int64  `json:"amount"`
+	Reference string `json:"reference"`
+}
+
+// UploadPayoutBatchRequest holds the form fields sent alongside a CSV upload
+type UploadPayoutBatchRequest struct {
+	Reference string `form:"reference" binding:"required"`
+	Currency  string `form:"currency"`
+	Mode      string `form:"mode" binding:"required,oneof=all_or_nothing best_effort"`
+}
+
+// payoutCSVColumns are the columns a payout CSV must have, in any order
+var payoutCSVColumns = []string{"to_user_id", "amount", "reference"}
+
+// Payout Handlers
+
+// CreatePayoutBatch accepts a batch as JSON, or as a CSV file uploaded in a
+// multipart form field named "file"
+func (h *Handlers) CreatePayoutBatch(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	var batch *models.PayoutBatch
+	if strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+		batch, err = bindPayoutCSV(c)
+	} else {
+		batch, err = bindPayoutJSON(c)
+	}
+	if err != nil {
+		if !payoutValidationResponse(c, err) {
+			errorResponse(c, http.StatusBadRequest, "Invalid request data", err)
+		}
+		return
+	}
+	batch.UserID = userID
+
+	batch, err = h.useCases.Payout.CreateBatch(batch)
+	if err != nil {
+		handlePayoutError(c, err, "Failed to create payout batch")
+		return
+	}
+
+	successResponse(c, "Payout batch accepted", batch)
+}
+
+func (h *Handlers) ListPayoutBatches(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	batches, err := h.useCases.Payout.ListBatches(userID)
+	if err != nil {
+		handlePayoutError(c, err, "Failed to list payout batches")
+		return
+	}
+
+	successResponse(c, "Payout batches retrieved successfully", batches)
+}
+
+func (h *Handlers) GetPayoutBatch(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	batchID, err := uuid.Parse(c.Param("batch_id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid payout batch ID", err)
+		return
+	}
+
+	batch, err := h.useCases.Payout.GetBatch(userID, batchID)
+	if err != nil {
+		handlePayoutError(c, err, "Failed to get payout batch")
+		return
+	}
+
+	successResponse(c, "Payout batch retrieved successfully", batch)
+}
+
+func bindPayoutJSON(c *gin.Context) (*models.PayoutBatch, error) {
+	var req CreatePayoutBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return nil, err
+	}
+
+	batch := &models.PayoutBatch{
+		Reference: req.Reference,
+		Currency:  req.Currency,
+		Mode:      models.PayoutMode(req.Mode),
+	}
+
+	var problems []usecases.PayoutItemError
+	for i, itemReq := range req.Items {
+		toUserID, err := uuid.Parse(itemReq.ToUserID)
+		if err != nil {
+			problems = append(problems, usecases.PayoutItemError{Position: i + 1, Reference: itemReq.Reference, Error: "invalid recipient user ID"})
+			continue
+		}
+		batch.Items = append(batch.Items, models.PayoutItem{
+			ToUserID:  toUserID,
+			Amount:    itemReq.Amount,
+			Reference: itemReq.Reference,
+		})
+	}
+	if len(problems) > 0 {
+		return nil, &usecases.PayoutValidationError{Items: problems}
+	}
+
+	return batch, nil
+}
+
+func bindPayoutCSV(c *gin.Context) (*models.PayoutBatch, error) {
+	var req UploadPayoutBatchRequest
+	if err := c.ShouldBind(&req); err != nil {
+		return nil, err
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		return nil, fmt.Errorf("a CSV file is required in the \"file\" field: %w", err)
+	}
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	items, err := parsePayoutCSV(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.PayoutBatch{
+		Reference: req.Reference,
+		Currency:  req.Currency,
+		Mode:      models.PayoutMode(req.Mode),
+		Items:     items,
+	}, nil
+}
+
+// parsePayoutCSV reads payout items from a CSV with a header row naming the
+// to_user_id, amount and reference columns
+func parsePayoutCSV(r io.Reader) ([]models.PayoutItem, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range payoutCSVColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("CSV is missing the %q column", name)
+		}
+	}
+
+	var items []models.PayoutItem
+	var problems []usecases.PayoutItemError
+	for position := 1; ; position++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV row %d: %w", position, err)
+		}
+		if len(items)+len(problems) >= usecases.MaxPayoutItems {
+			return nil, fmt.Errorf("a batch holds at most %d items", usecases.MaxPayoutItems)
+		}
+
+		reference := strings.TrimSpace(record[columns["reference"]])
+		toUserID, err := uuid.Parse(strings.TrimSpace(record[columns["to_user_id"]]))
+		if err != nil {
+			problems = append(problems, usecases.PayoutItemError{Position: position, Reference: reference, Error: "invalid recipient user ID"})
+			continue
+		}
+		amount, err := strconv.ParseInt(strings.TrimSpace(record[columns["amount"]]), 10, 64)
+		if err != nil {
+			problems = append(problems, usecases.PayoutItemError{Position: position, Reference: reference, Error: "amount must be a whole number of minor units"})
+			continue
+		}
+
+		items = append(items, models.PayoutItem{
+			ToUserID:  toUserID,
+			Amount:    amount,
+			Reference: reference,
+		})
+	}
+
+	if len(problems) > 0 {
+		return nil, &usecases.PayoutValidationError{Items: problems}
+	}
+	if len(items) == 0 {
+		return nil, errors.New("CSV has no payout rows")
+	}
+	return items, nil
+}
+
+func handlePayoutError(c *gin.Context, err error, message string) {
+	if payoutValidationResponse(c, err) {
+		return
+	}
+
+	switch err {
+	case usecases.ErrUserNotFound:
+		errorResponse(c, http.StatusNotFound, "User not found", err)
+	case usecases.ErrWalletNotFound:
+		errorResponse(c, http.StatusNotFound, "Wallet not found", err)
+	case usecases.ErrPayoutBatchNotFound:
+		errorResponse(c, http.StatusNotFound, "Payout batch not found", err)
+	case usecases.ErrInvalidPayoutBatch:
+		errorResponse(c, http.StatusBadRequest, "Invalid payout batch", err)
+	case usecases.ErrTransactionExists:
+		errorResponse(c, http.StatusConflict, "Batch reference already used", err)
+	case usecases.ErrUnsupportedCurrency:
+		errorResponse(c, http.StatusBadRequest, "Unsupported currency", err)
+	case usecases.ErrInsufficientFunds:
+		errorResponse(c, http.StatusBadRequest, "Insufficient funds", err)
+	default:
+		errorResponse(c, http.StatusInternalServerError, message, err)
+	}
+}
+
+// payoutValidationResponse writes a 400 listing every invalid item and
+// reports whether err was a batch validation error
+func payoutValidationResponse(c *gin.Context, err error) bool {
+	var validationErr *usecases.PayoutValidationError
+	if !errors.As(err, &validationErr) {
+		return false
+	}
+
+	c.JSON(http.StatusBadRequest, APIResponse{
+		Success: false,
+		Message: "Invalid payout batch",
+		Data:    validationErr,
+		Error:   validationErr.Error(),
+	})
+	return true
+}
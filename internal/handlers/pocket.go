@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Pocket DTOs
+
+type CreatePocketRequest struct {
+	Name     string `json:"name" binding:"required"`
+	Currency string `json:"currency"` // Defaults to USD
+}
+
+type MoveFundsRequest struct {
+	FromWalletID string `json:"from_wallet_id" binding:"required"`
+	ToWalletID   string `json:"to_wallet_id" binding:"required"`
+	Amount       int64  `json:"amount" binding:"required,min=1"`
+	Reference    string `json:"reference" binding:"required"`
+}
+
+// Pocket Handlers
+
+func (h *Handlers) CreatePocket(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	var req CreatePocketRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	pocket, err := h.useCases.Wallet.CreatePocket(userID, req.Name, req.Currency)
+	if err != nil {
+		handlePocketError(c, err, "Failed to create pocket")
+		return
+	}
+
+	successResponse(c, "Pocket created successfully", pocket)
+}
+
+func (h *Handlers) MoveFunds(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	var req MoveFundsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	fromWalletID, err := uuid.Parse(req.FromWalletID)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid source wallet ID", err)
+		return
+	}
+
+	toWalletID, err := uuid.Parse(req.ToWalletID)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid destination wallet ID", err)
+		return
+	}
+
+	transaction, err := h.useCases.Wallet.MoveFunds(userID, fromWalletID, toWalletID, req.Amount, req.Reference)
+	if err != nil {
+		handlePocketError(c, err, "Failed to move funds")
+		return
+	}
+
+	successResponse(c, "Funds moved successfully", transaction)
+}
+
+func handlePocketError(c *gin.Context, err error, message string) {
+	if walletStatusResponse(c, err) {
+		return
+	}
+
+	switch err {
+	case usecases.ErrUserNotFound:
+		errorResponse(c, http.StatusNotFound, "User not found", err)
+	case usecases.ErrWalletNotFound:
+		errorResponse(c, http.StatusNotFound, "Wallet not found", err)
+	case usecases.ErrUnsupportedCurrency:
+		errorResponse(c, http.StatusBadRequest, "Unsupported currency", err)
+	case usecases.ErrInvalidPocketName:
+		errorResponse(c, http.StatusBadRequest, "Invalid pocket name", err)
+	case usecases.ErrPocketExists:
+		errorResponse(c, http.StatusConflict, "Pocket already exists", err)
+	case usecases.ErrInvalidAmount:
+		errorResponse(c, http.StatusBadRequest, "Invalid amount", err)
+	case usecases.ErrSameWallet:
+		errorResponse(c, http.StatusBadRequest, "Cannot move funds to the same wallet", err)
+	case usecases.ErrCurrencyMismatch:
+		errorResponse(c, http.StatusBadRequest, "Wallets must be in the same currency", err)
+	case usecases.ErrInsufficientFunds:
+		errorResponse(c, http.StatusBadRequest, "Insufficient funds", err)
+	default:
+		errorResponse(c, http.StatusInternalServerError, message, err)
+	}
+}
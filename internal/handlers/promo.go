@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Promo DTOs
+
+type GrantPromoCreditRequest struct {
+	Amount           int64      `json:"amount" binding:"required,min=1"`
+	Currency         string     `json:"currency"` // Defaults to USD
+	Description      string     `json:"description"`
+	Reference        string     `json:"reference" binding:"required"`
+	ExpiresAt        *time.Time `json:"expires_at"`                         // Either this
+	ExpiresInSeconds int64      `json:"expires_in_seconds" binding:"min=0"` // or this
+}
+
+type ListPromoCreditsQuery struct {
+	Currency string `form:"currency"` // Defaults to USD
+}
+
+type SetMerchantRequest struct {
+	IsMerchant *bool `json:"is_merchant" binding:"required"`
+}
+
+// Promo Handlers
+
+func (h *Handlers) GrantPromoCredit(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	var req GrantPromoCreditRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	if (req.ExpiresAt == nil) == (req.ExpiresInSeconds == 0) {
+		errorResponse(c, http.StatusBadRequest, "Invalid request data", errors.New("give exactly one of expires_at and expires_in_seconds"))
+		return
+	}
+	expiresAt := time.Now().Add(time.Duration(req.ExpiresInSeconds) * time.Second)
+	if req.ExpiresAt != nil {
+		expiresAt = *req.ExpiresAt
+	}
+
+	bucket, err := h.useCases.Promo.Grant(userID, req.Amount, req.Currency, expiresAt, req.Description, req.Reference)
+	if err != nil {
+		if walletStatusResponse(c, err) {
+			return
+		}
+		handlePromoError(c, err, "Failed to grant promotional credit")
+		return
+	}
+
+	successResponse(c, "Promotional credit granted successfully", bucket)
+}
+
+func (h *Handlers) ListPromoCredits(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	var query ListPromoCreditsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	buckets, err := h.useCases.Promo.ListBuckets(userID, query.Currency)
+	if err != nil {
+		handlePromoError(c, err, "Failed to list promotional credit")
+		return
+	}
+
+	successResponse(c, "Promotional credit retrieved successfully", buckets)
+}
+
+func (h *Handlers) SetMerchant(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	var req SetMerchantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	user, err := h.useCases.User.SetMerchant(userID, *req.IsMerchant)
+	if err != nil {
+		handlePromoError(c, err, "Failed to update user")
+		return
+	}
+
+	successResponse(c, "User updated successfully", user)
+}
+
+func handlePromoError(c *gin.Context, err error, message string) {
+	switch err {
+	case usecases.ErrUserNotFound:
+		errorResponse(c, http.StatusNotFound, "User not found", err)
+	case usecases.ErrWalletNotFound:
+		errorResponse(c, http.StatusNotFound, "User has no wallet in this currency", err)
+	case usecases.ErrInvalidAmount:
+		errorResponse(c, http.StatusBadRequest, "Invalid amount", err)
+	case usecases.ErrInvalidPromoExpiry:
+		errorResponse(c, http.StatusBadRequest, "Expiry must be in the future", err)
+	case usecases.ErrUnsupportedCurrency:
+		errorResponse(c, http.StatusBadRequest, "Unsupported currency", err)
+	case usecases.ErrTransactionExists:
+		errorResponse(c, http.StatusConflict, "Reference already used by another transaction", err)
+	default:
+		errorResponse(c, http.StatusInternalServerError, message, err)
+	}
+}
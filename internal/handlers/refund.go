@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Refund DTOs
+
+type RefundTransactionRequest struct {
+	Amount    int64  `json:"amount" binding:"required,min=1"`
+	Reference string `json:"reference" binding:"required"`
+}
+
+type ReverseTransactionRequest struct {
+	Reference string `json:"reference" binding:"required"`
+}
+
+// Refund Handlers
+
+func (h *Handlers) RefundTransaction(c *gin.Context) {
+	transactionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid transaction ID", err)
+		return
+	}
+
+	var req RefundTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	transaction, err := h.useCases.Wallet.RefundTransaction(transactionID, req.Amount, req.Reference)
+	if err != nil {
+		handleRefundError(c, err, "Failed to refund transaction")
+		return
+	}
+
+	successResponse(c, "Transaction refunded successfully", transaction)
+}
+
+func (h *Handlers) ReverseTransaction(c *gin.Context) {
+	transactionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid transaction ID", err)
+		return
+	}
+
+	var req ReverseTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	transaction, err := h.useCases.Wallet.ReverseTransaction(transactionID, req.Reference)
+	if err != nil {
+		handleRefundError(c, err, "Failed to reverse transaction")
+		return
+	}
+
+	successResponse(c, "Transaction reversed successfully", transaction)
+}
+
+func handleRefundError(c *gin.Context, err error, message string) {
+	if walletStatusResponse(c, err) {
+		return
+	}
+
+	switch err {
+	case usecases.ErrTransactionNotFound:
+		errorResponse(c, http.StatusNotFound, "Transaction not found", err)
+	case usecases.ErrTransactionNotRefundable:
+		errorResponse(c, http.StatusConflict, "Transaction cannot be refunded", err)
+	case usecases.ErrRefundExceedsRemaining:
+		errorResponse(c, http.StatusConflict, "Refund exceeds the amount left to refund", err)
+	case usecases.ErrInsufficientFunds:
+		errorResponse(c, http.StatusBadRequest, "Insufficient funds", err)
+	case usecases.ErrInvalidAmount:
+		errorResponse(c, http.StatusBadRequest, "Invalid amount", err)
+	default:
+		errorResponse(c, http.StatusInternalServerError, message, err)
+	}
+}
@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Scheduled Transfer DTOs
+
+type CreateScheduledTransferRequest struct {
+	ToUserID    string     `json:"to_user_id" binding:"required"`
+	Amount      int64      `json:"amount" binding:"required,min=1"`
+	Currency    string     `json:"currency"` // Defaults to USD
+	Description string     `json:"description"`
+	Schedule    string     `json:"schedule"` // Cron expression; omit for a one-off transfer
+	StartAt     *time.Time `json:"start_at"` // Required for a one-off transfer; defaults to now for a recurring one
+	EndsAt      *time.Time `json:"ends_at"`
+}
+
+type UpdateScheduledTransferRequest struct {
+	Amount      *int64  `json:"amount" binding:"omitempty,min=1"`
+	Description *string `json:"description"`
+	Status      *string `json:"status" binding:"omitempty,oneof=active paused"`
+}
+
+// Scheduled Transfer Handlers
+
+func (h *Handlers) CreateScheduledTransfer(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	var req CreateScheduledTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	toUserID, err := uuid.Parse(req.ToUserID)
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid recipient user ID", err)
+		return
+	}
+
+	var startAt time.Time
+	if req.StartAt != nil {
+		startAt = *req.StartAt
+	} else if req.Schedule == "" {
+		errorResponse(c, http.StatusBadRequest, "A one-off transfer needs a start_at time", usecases.ErrInvalidSchedule)
+		return
+	}
+
+	transfer := &models.ScheduledTransfer{
+		UserID:      userID,
+		ToUserID:    toUserID,
+		Amount:      req.Amount,
+		Currency:    req.Currency,
+		Description: req.Description,
+		Schedule:    req.Schedule,
+		EndsAt:      req.EndsAt,
+	}
+
+	transfer, err = h.useCases.Scheduled.Create(transfer, startAt)
+	if err != nil {
+		handleScheduledTransferError(c, err, "Failed to create scheduled transfer")
+		return
+	}
+
+	successResponse(c, "Scheduled transfer created successfully", transfer)
+}
+
+func (h *Handlers) ListScheduledTransfers(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	transfers, err := h.useCases.Scheduled.List(userID)
+	if err != nil {
+		handleScheduledTransferError(c, err, "Failed to list scheduled transfers")
+		return
+	}
+
+	successResponse(c, "Scheduled transfers retrieved successfully", transfers)
+}
+
+func (h *Handlers) GetScheduledTransfer(c *gin.Context) {
+	userID, transferID, ok := parseScheduledTransferParams(c)
+	if !ok {
+		return
+	}
+
+	transfer, err := h.useCases.Scheduled.Get(userID, transferID)
+	if err != nil {
+		handleScheduledTransferError(c, err, "Failed to get scheduled transfer")
+		return
+	}
+
+	successResponse(c, "Scheduled transfer retrieved successfully", transfer)
+}
+
+func (h *Handlers) UpdateScheduledTransfer(c *gin.Context) {
+	userID, transferID, ok := parseScheduledTransferParams(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateScheduledTransferRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	update := usecases.ScheduledTransferUpdate{
+		Amount:      req.Amount,
+		Description: req.Description,
+	}
+	if req.Status != nil {
+		status := models.ScheduledTransferStatus(*req.Status)
+		update.Status = &status
+	}
+
+	transfer, err := h.useCases.Scheduled.Update(userID, transferID, update)
+	if err != nil {
+		handleScheduledTransferError(c, err, "Failed to update scheduled transfer")
+		return
+	}
+
+	successResponse(c, "Scheduled transfer updated successfully", transfer)
+}
+
+func (h *Handlers) CancelScheduledTransfer(c *gin.Context) {
+	userID, transferID, ok := parseScheduledTransferParams(c)
+	if !ok {
+		return
+	}
+
+	transfer, err := h.useCases.Scheduled.Cancel(userID, transferID)
+	if err != nil {
+		handleScheduledTransferError(c, err, "Failed to cancel scheduled transfer")
+		return
+	}
+
+	successResponse(c, "Scheduled transfer cancelled successfully", transfer)
+}
+
+func (h *Handlers) ListScheduledTransferRuns(c *gin.Context) {
+	userID, transferID, ok := parseScheduledTransferParams(c)
+	if !ok {
+		return
+	}
+
+	runs, err := h.useCases.Scheduled.ListRuns(userID, transferID)
+	if err != nil {
+		handleScheduledTransferError(c, err, "Failed to list scheduled transfer runs")
+		return
+	}
+
+	successResponse(c, "Scheduled transfer runs retrieved successfully", runs)
+}
+
+// parseScheduledTransferParams parses the user and scheduled transfer IDs from the path
+func parseScheduledTransferParams(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	transferID, err := uuid.Parse(c.Param("transfer_id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid scheduled transfer ID", err)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return userID, transferID, true
+}
+
+func handleScheduledTransferError(c *gin.Context, err error, message string) {
+	switch err {
+	case usecases.ErrUserNotFound:
+		errorResponse(c, http.StatusNotFound, "User not found", err)
+	case usecases.ErrScheduledTransferNotFound:
+		errorResponse(c, http.StatusNotFound, "Scheduled transfer not found", err)
+	case usecases.ErrScheduledTransferClosed:
+		errorResponse(c, http.StatusConflict, "Scheduled transfer has already finished", err)
+	case usecases.ErrInvalidSchedule:
+		errorResponse(c, http.StatusBadRequest, "Invalid schedule", err)
+	case usecases.ErrSameUser:
+		errorResponse(c, http.StatusBadRequest, "Cannot transfer to the same user", err)
+	case usecases.ErrUnsupportedCurrency:
+		errorResponse(c, http.StatusBadRequest, "Unsupported currency", err)
+	case usecases.ErrInvalidAmount:
+		errorResponse(c, http.StatusBadRequest, "Invalid amount", err)
+	default:
+		errorResponse(c, http.StatusInternalServerError, message, err)
+	}
+}
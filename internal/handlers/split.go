@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Split Payment DTOs
+
+type SplitShareRequest struct {
+	ToUserID    string `json:"to_user_id" binding:"required"`
+	Amount      int64  `json:"amount" binding:"min=0"`       // Exact amount
+	BasisPoints int64  `json:"basis_points" binding:"min=0"` // Or a share of the total; 1 basis point is 0.01%
+}
+
+type SplitPaymentRequest struct {
+	Amount     int64               `json:"amount" binding:"min=0"` // Required for percentage shares
+	Currency   string              `json:"currency"`               // Defaults to USD
+	Reference  string              `json:"reference" binding:"required"`
+	Recipients []SplitShareRequest `json:"recipients" binding:"required,min=1,dive"`
+}
+
+// Split Payment Handlers
+
+func (h *Handlers) SplitPayment(c *gin.Context) {
+	fromUserID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	var req SplitPaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	shares := make([]usecases.SplitShare, len(req.Recipients))
+	for i, recipient := range req.Recipients {
+		toUserID, err := uuid.Parse(recipient.ToUserID)
+		if err != nil {
+			errorResponse(c, http.StatusBadRequest, "Invalid recipient user ID", err)
+			return
+		}
+		shares[i] = usecases.SplitShare{
+			ToUserID:    toUserID,
+			Amount:      recipient.Amount,
+			BasisPoints: recipient.BasisPoints,
+		}
+	}
+
+	transaction, err := h.useCases.Wallet.SplitPayment(fromUserID, req.Amount, req.Currency, shares, req.Reference)
+	if err != nil {
+		handleSplitPaymentError(c, err, "Failed to split payment")
+		return
+	}
+
+	successResponse(c, "Payment split successfully", transaction)
+}
+
+func handleSplitPaymentError(c *gin.Context, err error, message string) {
+	if limitExceededResponse(c, err) || walletStatusResponse(c, err) {
+		return
+	}
+
+	switch err {
+	case usecases.ErrUserNotFound:
+		errorResponse(c, http.StatusNotFound, "User not found", err)
+	case usecases.ErrRecipientNotFound:
+		errorResponse(c, http.StatusNotFound, "Recipient not found", err)
+	case usecases.ErrWalletNotFound:
+		errorResponse(c, http.StatusNotFound, "Wallet not found", err)
+	case usecases.ErrInvalidSplit:
+		errorResponse(c, http.StatusBadRequest, "Invalid split", err)
+	case usecases.ErrInvalidAmount:
+		errorResponse(c, http.StatusBadRequest, "Invalid amount", err)
+	case usecases.ErrInsufficientFunds:
+		errorResponse(c, http.StatusBadRequest, "Insufficient funds", err)
+	case usecases.ErrSameUser:
+		errorResponse(c, http.StatusBadRequest, "Cannot transfer to the same user", err)
+	case usecases.ErrUnsupportedCurrency:
+		errorResponse(c, http.StatusBadRequest, "Unsupported currency", err)
+	case usecases.ErrCurrencyMismatch:
+		errorResponse(c, http.StatusBadRequest, "Recipient has no wallet in this currency", err)
+	default:
+		errorResponse(c, http.StatusInternalServerError, message, err)
+	}
+}
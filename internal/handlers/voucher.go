@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Voucher DTOs
+
+type GenerateVoucherBatchRequest struct {
+	Count          int       `json:"count" binding:"required,min=1,max=1000"`
+	Amount         int64     `json:"amount" binding:"required,min=1"`
+	Currency       string    `json:"currency"` // Defaults to USD
+	Description    string    `json:"description"`
+	MaxRedemptions int       `json:"max_redemptions" binding:"min=0"` // Defaults to 1, a single-use code
+	ExpiresAt      time.Time `json:"expires_at" binding:"required"`
+}
+
+type GenerateVoucherBatchQuery struct {
+	Format string `form:"format" binding:"omitempty,oneof=json csv"`
+}
+
+type RedeemVoucherRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// Voucher Handlers
+
+// GenerateVoucherBatch creates a batch of codes. This response is the only
+// place the codes appear; with ?format=csv it is a CSV file.
+func (h *Handlers) GenerateVoucherBatch(c *gin.Context) {
+	var query GenerateVoucherBatchQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	var req GenerateVoucherBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	batch := &models.VoucherBatch{
+		Description:    req.Description,
+		Amount:         req.Amount,
+		Currency:       req.Currency,
+		MaxRedemptions: req.MaxRedemptions,
+		ExpiresAt:      req.ExpiresAt,
+	}
+
+	batch, err := h.useCases.Voucher.GenerateBatch(batch, req.Count)
+	if err != nil {
+		handleVoucherError(c, err, "Failed to generate vouchers")
+		return
+	}
+
+	if query.Format == "csv" {
+		writeVoucherCSV(c, batch, true)
+		return
+	}
+	successResponse(c, "Vouchers generated successfully", batch)
+}
+
+func (h *Handlers) ListVoucherBatches(c *gin.Context) {
+	batches, err := h.useCases.Voucher.ListBatches()
+	if err != nil {
+		handleVoucherError(c, err, "Failed to list voucher batches")
+		return
+	}
+
+	successResponse(c, "Voucher batches retrieved successfully", batches)
+}
+
+func (h *Handlers) GetVoucherBatch(c *gin.Context) {
+	batch, ok := h.voucherBatch(c)
+	if !ok {
+		return
+	}
+
+	successResponse(c, "Voucher batch retrieved successfully", batch)
+}
+
+// ExportVoucherBatch returns the batch's vouchers and how far each has been
+// redeemed as CSV. Codes are not stored, so only their hints are included.
+func (h *Handlers) ExportVoucherBatch(c *gin.Context) {
+	batch, ok := h.voucherBatch(c)
+	if !ok {
+		return
+	}
+
+	writeVoucherCSV(c, batch, false)
+}
+
+func (h *Handlers) RedeemVoucher(c *gin.Context) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid user ID", err)
+		return
+	}
+
+	var req RedeemVoucherRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	transaction, err := h.useCases.Voucher.Redeem(userID, req.Code)
+	if err != nil {
+		if limitExceededResponse(c, err) || walletStatusResponse(c, err) {
+			return
+		}
+		handleVoucherError(c, err, "Failed to redeem voucher")
+		return
+	}
+
+	successResponse(c, "Voucher redeemed successfully", transaction)
+}
+
+// voucherBatch loads the batch named in the path, writing the error response
+// if it cannot
+func (h *Handlers) voucherBatch(c *gin.Context) (*models.VoucherBatch, bool) {
+	batchID, err := uuid.Parse(c.Param("batch_id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid batch ID", err)
+		return nil, false
+	}
+
+	batch, err := h.useCases.Voucher.GetBatch(batchID)
+	if err != nil {
+		handleVoucherError(c, err, "Failed to get voucher batch")
+		return nil, false
+	}
+	return batch, true
+}
+
+// writeVoucherCSV writes one row per voucher in the batch, with the code
+// itself only when it is known
+func writeVoucherCSV(c *gin.Context, batch *models.VoucherBatch, withCodes bool) {
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=vouchers-%s.csv", batch.ID))
+	c.Header("Content-Type", "text/csv")
+	c.Status(http.StatusOK)
+
+	header := []string{"voucher_id", "code_hint", "amount", "currency", "max_redemptions", "redemptions", "status", "expires_at"}
+	if withCodes {
+		header = append([]string{"code"}, header...)
+	}
+
+	w := csv.NewWriter(c.Writer)
+	w.Write(header)
+	for _, voucher := range batch.Vouchers {
+		row := []string{
+			voucher.ID.String(),
+			voucher.CodeHint,
+			strconv.FormatInt(voucher.Amount, 10),
+			voucher.Currency,
+			strconv.Itoa(voucher.MaxRedemptions),
+			strconv.Itoa(voucher.Redemptions),
+			string(voucher.Status),
+			voucher.ExpiresAt.UTC().Format(time.RFC3339),
+		}
+		if withCodes {
+			row = append([]string{voucher.Code}, row...)
+		}
+		w.Write(row)
+	}
+	w.Flush()
+}
+
+func handleVoucherError(c *gin.Context, err error, message string) {
+	switch err {
+	case usecases.ErrUserNotFound:
+		errorResponse(c, http.StatusNotFound, "User not found", err)
+	case usecases.ErrWalletNotFound:
+		errorResponse(c, http.StatusNotFound, "User has no wallet in the voucher currency", err)
+	case usecases.ErrVoucherNotFound:
+		errorResponse(c, http.StatusNotFound, "Voucher not found", err)
+	case usecases.ErrVoucherBatchNotFound:
+		errorResponse(c, http.StatusNotFound, "Voucher batch not found", err)
+	case usecases.ErrVoucherExpired:
+		errorResponse(c, http.StatusConflict, "Voucher has expired", err)
+	case usecases.ErrVoucherRedeemed:
+		errorResponse(c, http.StatusConflict, "Voucher has already been redeemed", err)
+	case usecases.ErrInvalidVoucherBatch:
+		errorResponse(c, http.StatusBadRequest, "Invalid voucher batch", err)
+	case usecases.ErrInvalidAmount:
+		errorResponse(c, http.StatusBadRequest, "Invalid amount", err)
+	case usecases.ErrUnsupportedCurrency:
+		errorResponse(c, http.StatusBadRequest, "Unsupported currency", err)
+	case usecases.ErrInsufficientFunds:
+		errorResponse(c, http.StatusBadRequest, "Insufficient funds to cover the fee", err)
+	default:
+		errorResponse(c, http.StatusInternalServerError, message, err)
+	}
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// Wallet Status DTOs
+
+type ChangeWalletStatusRequest struct {
+	Status          string `json:"status" binding:"required,oneof=active frozen suspended closed"`
+	Reason          string `json:"reason" binding:"required"`
+	SweepToWalletID string `json:"sweep_to_wallet_id"` // Closing only; receives any remaining balance
+}
+
+// Wallet Status Handlers
+
+func (h *Handlers) ChangeWalletStatus(c *gin.Context) {
+	walletID, err := uuid.Parse(c.Param("wallet_id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid wallet ID", err)
+		return
+	}
+
+	var req ChangeWalletStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid request data", err)
+		return
+	}
+
+	var sweepToWalletID *uuid.UUID
+	if req.SweepToWalletID != "" {
+		parsed, err := uuid.Parse(req.SweepToWalletID)
+		if err != nil {
+			errorResponse(c, http.StatusBadRequest, "Invalid sweep wallet ID", err)
+			return
+		}
+		sweepToWalletID = &parsed
+	}
+
+	wallet, err := h.useCases.Wallet.ChangeWalletStatus(walletID, models.WalletStatus(req.Status), req.Reason, adminActor(c), sweepToWalletID)
+	if err != nil {
+		handleWalletStatusError(c, err, "Failed to change wallet status")
+		return
+	}
+
+	successResponse(c, "Wallet status changed successfully", wallet)
+}
+
+func (h *Handlers) ListWalletStatusChanges(c *gin.Context) {
+	walletID, err := uuid.Parse(c.Param("wallet_id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid wallet ID", err)
+		return
+	}
+
+	changes, err := h.useCases.Wallet.ListWalletStatusChanges(walletID)
+	if err != nil {
+		handleWalletStatusError(c, err, "Failed to list wallet status changes")
+		return
+	}
+
+	successResponse(c, "Wallet status history retrieved successfully", changes)
+}
+
+func handleWalletStatusError(c *gin.Context, err error, message string) {
+	switch err {
+	case usecases.ErrWalletNotFound:
+		errorResponse(c, http.StatusNotFound, "Wallet not found", err)
+	case usecases.ErrWalletClosed:
+		errorResponse(c, http.StatusConflict, "Wallet is closed", err)
+	case usecases.ErrWalletSuspended:
+		errorResponse(c, http.StatusConflict, "Sweep wallet is suspended", err)
+	case usecases.ErrWalletNotEmpty:
+		errorResponse(c, http.StatusConflict, "Wallet still holds funds", err)
+	case usecases.ErrPocketsNotEmpty:
+		errorResponse(c, http.StatusConflict, "Pockets still hold funds", err)
+	case usecases.ErrInvalidWalletStatus:
+		errorResponse(c, http.StatusBadRequest, "Invalid wallet status change", err)
+	case usecases.ErrStatusReasonRequired:
+		errorResponse(c, http.StatusBadRequest, "Reason and actor are required", err)
+	case usecases.ErrCurrencyMismatch:
+		errorResponse(c, http.StatusBadRequest, "Sweep wallet must be in the same currency", err)
+	default:
+		errorResponse(c, http.StatusInternalServerError, message, err)
+	}
+}
+
+// walletStatusResponse writes a 403 when an operation was refused because
+// of a wallet's status and reports whether it did
+func walletStatusResponse(c *gin.Context, err error) bool {
+	switch err {
+	case usecases.ErrWalletFrozen:
+		errorResponse(c, http.StatusForbidden, "Wallet is frozen", err)
+	case usecases.ErrWalletSuspended:
+		errorResponse(c, http.StatusForbidden, "Wallet is suspended", err)
+	case usecases.ErrWalletClosed:
+		errorResponse(c, http.StatusForbidden, "Wallet is closed", err)
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxAuditBodyBytes caps how much of a request body the audit log keeps
+const maxAuditBodyBytes = 16 << 10
+
+// AuditRecorder stores audit log entries
+type AuditRecorder interface {
+	RecordAction(entry *models.AuditLogEntry) error
+}
+
+// Audit records every request that reaches it, with the caller, the route,
+// its parameters and body, and the status it ended with. Requests a later
+// role check refuses are recorded too.
+func Audit(recorder AuditRecorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var body []byte
+		if c.Request.Body != nil {
+			var err error
+			body, err = io.ReadAll(c.Request.Body)
+			if err != nil {
+				abort(c, http.StatusBadRequest, "Failed to read request body", err)
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		c.Next()
+
+		entry := &models.AuditLogEntry{
+			Method:     c.Request.Method,
+			Route:      c.FullPath(),
+			Query:      c.Request.URL.RawQuery,
+			Body:       string(body[:min(len(body), maxAuditBodyBytes)]),
+			StatusCode: c.Writer.Status(),
+			ClientIP:   c.ClientIP(),
+		}
+		if principal, ok := CurrentPrincipal(c); ok {
+			entry.Actor = principal.Subject
+			entry.ActorRole = string(principal.Role)
+		}
+		if len(c.Params) > 0 {
+			entry.Params = make(map[string]string, len(c.Params))
+			for _, param := range c.Params {
+				entry.Params[param.Key] = param.Value
+			}
+		}
+
+		// The response has gone by now, so a failure can only be logged
+		if err := recorder.RecordAction(entry); err != nil {
+			log.Printf("Failed to record admin action on %s: %v", entry.Route, err)
+		}
+	}
+}
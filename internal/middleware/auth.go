@@ -0,0 +1,145 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Code-Linx/wallet-service/internal/auth"
+	"github.com/Code-Linx/wallet-service/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// principalKey is where the authenticated caller is kept on the request context
+const principalKey = "Principal"
+
+var (
+	errMissingToken = errors.New("missing bearer token")
+	errForbidden    = errors.New("not allowed for this caller")
+)
+
+// APIKeyAuthenticator checks the API key a client presents
+type APIKeyAuthenticator interface {
+	Authenticate(rawKey, clientIP string) (*models.APIKey, error)
+}
+
+// Authenticate requires either an API key in the X-API-Key header or a
+// valid access token in the Authorization header, and makes the caller
+// available to later handlers
+func Authenticate(tokens *auth.Tokens, keys APIKeyAuthenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if rawKey := c.GetHeader("X-API-Key"); rawKey != "" {
+			key, err := keys.Authenticate(rawKey, c.ClientIP())
+			if err != nil {
+				abort(c, http.StatusUnauthorized, "Invalid API key", err)
+				return
+			}
+
+			scopes := make([]auth.Scope, len(key.Scopes))
+			for i, scope := range key.Scopes {
+				scopes[i] = auth.Scope(scope)
+			}
+			c.Set(principalKey, &auth.Principal{Subject: key.ID.String(), Role: auth.RoleAPIKey, Scopes: scopes})
+			c.Next()
+			return
+		}
+
+		token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			abort(c, http.StatusUnauthorized, "Authentication required", errMissingToken)
+			return
+		}
+
+		claims, err := tokens.Verify(token, auth.TokenTypeAccess)
+		if err != nil {
+			abort(c, http.StatusUnauthorized, "Invalid access token", err)
+			return
+		}
+
+		c.Set(principalKey, claims.Principal())
+		c.Next()
+	}
+}
+
+// RequireRole only lets through callers holding one of the roles
+func RequireRole(roles ...auth.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := CurrentPrincipal(c)
+		if !ok || !principal.HasRole(roles...) {
+			abort(c, http.StatusForbidden, "Forbidden", errForbidden)
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireAdmin lets through superadmins and admins holding one of the
+// roles; with no roles, only superadmins
+func RequireAdmin(roles ...auth.Role) gin.HandlerFunc {
+	return RequireRole(append([]auth.Role{auth.RoleSuperadmin}, roles...)...)
+}
+
+// RequireSelfOrRole lets users through only when the user ID in the named
+// path parameter is their own; callers holding one of the roles may act on
+// any user
+func RequireSelfOrRole(param string, roles ...auth.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := CurrentPrincipal(c)
+		if !ok {
+			abort(c, http.StatusForbidden, "Forbidden", errForbidden)
+			return
+		}
+		if principal.HasRole(roles...) {
+			c.Next()
+			return
+		}
+
+		userID, err := uuid.Parse(c.Param(param))
+		if err != nil || !principal.IsUser(userID) {
+			abort(c, http.StatusForbidden, "Forbidden", errForbidden)
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireScopeOr lets API keys through only when they hold the scope, and
+// leaves every other caller to the otherwise check. API keys can only reach
+// routes that name the scope they need this way.
+func RequireScopeOr(scope auth.Scope, otherwise gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := CurrentPrincipal(c)
+		if !ok || principal.Role != auth.RoleAPIKey {
+			otherwise(c)
+			return
+		}
+
+		if !principal.HasScope(scope) {
+			abort(c, http.StatusForbidden, "Forbidden", fmt.Errorf("API key lacks the %s scope", scope))
+			return
+		}
+		c.Next()
+	}
+}
+
+// CurrentPrincipal returns the caller authenticated for the request
+func CurrentPrincipal(c *gin.Context) (*auth.Principal, bool) {
+	value, ok := c.Get(principalKey)
+	if !ok {
+		return nil, false
+	}
+	principal, ok := value.(*auth.Principal)
+	return principal, ok
+}
+
+// abort stops the request with the same body shape the handlers use for errors
+func abort(c *gin.Context, statusCode int, message string, err error) {
+	c.AbortWithStatusJSON(statusCode, gin.H{
+		"success": false,
+		"message": message,
+		"error":   err.Error(),
+	})
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Code-Linx/wallet-service/internal/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Headers a signed request carries
+const (
+	SignatureKeyIDHeader     = "X-Signature-Key-ID"
+	SignatureTimestampHeader = "X-Signature-Timestamp"
+	SignatureNonceHeader     = "X-Signature-Nonce"
+	SignatureHeader          = "X-Signature"
+)
+
+// maxSignedBodyBytes caps how much of a body is read to check its signature
+const maxSignedBodyBytes = 1 << 20
+
+// RequireSignature refuses requests that are not signed with one of the
+// signer's keys, are too old or too far in the future, or replay a nonce.
+// The body is read to hash it and put back for the handler.
+func RequireSignature(signer *auth.RequestSigner) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxSignedBodyBytes+1))
+		if err != nil {
+			abort(c, http.StatusBadRequest, "Failed to read request body", err)
+			return
+		}
+		if len(body) > maxSignedBodyBytes {
+			abort(c, http.StatusRequestEntityTooLarge, "Request body too large", fmt.Errorf("signed bodies are limited to %d bytes", maxSignedBodyBytes))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		err = signer.Verify(auth.SignedRequest{
+			KeyID:     c.GetHeader(SignatureKeyIDHeader),
+			Method:    c.Request.Method,
+			Path:      c.Request.URL.RequestURI(),
+			Timestamp: c.GetHeader(SignatureTimestampHeader),
+			Nonce:     c.GetHeader(SignatureNonceHeader),
+			Body:      body,
+			Signature: c.GetHeader(SignatureHeader),
+		})
+		switch {
+		case err == nil:
+			c.Next()
+		case errors.Is(err, auth.ErrSignatureMissing), errors.Is(err, auth.ErrSignatureInvalid):
+			abort(c, http.StatusUnauthorized, "Invalid request signature", err)
+		case errors.Is(err, auth.ErrSignatureStale), errors.Is(err, auth.ErrNonceReused):
+			abort(c, http.StatusUnauthorized, "Request expired or replayed", err)
+		default:
+			abort(c, http.StatusInternalServerError, "Failed to verify request signature", err)
+		}
+	}
+}
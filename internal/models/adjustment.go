@@ -0,0 +1,104 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AdjustmentDirection says whether a manual adjustment adds money to a
+// wallet or takes it away
+type AdjustmentDirection string
+
+const (
+	AdjustmentDirectionCredit AdjustmentDirection = "credit"
+	AdjustmentDirectionDebit  AdjustmentDirection = "debit"
+)
+
+// AdjustmentStatus tracks a proposed adjustment through review
+type AdjustmentStatus string
+
+const (
+	AdjustmentStatusPending  AdjustmentStatus = "pending"  // Waiting for approvals
+	AdjustmentStatusApproved AdjustmentStatus = "approved" // Posted to the ledger
+	AdjustmentStatusRejected AdjustmentStatus = "rejected"
+)
+
+// AdjustmentDecisionType is what a reviewer decided
+type AdjustmentDecisionType string
+
+const (
+	AdjustmentDecisionApprove AdjustmentDecisionType = "approve"
+	AdjustmentDecisionReject  AdjustmentDecisionType = "reject"
+)
+
+// BalanceAdjustment is a manual correction to a wallet's balance. One admin
+// proposes it and others review it; it only reaches the ledger once it has
+// as many approvals as it requires.
+type BalanceAdjustment struct {
+	ID                uuid.UUID            `json:"id" gorm:"type:char(36);primary_key"`
+	WalletID          uuid.UUID            `json:"wallet_id" gorm:"type:char(36);not null;index"`
+	UserID            uuid.UUID            `json:"user_id" gorm:"type:char(36);not null"`
+	Direction         AdjustmentDirection  `json:"direction" gorm:"type:varchar(10);not null"`
+	Amount            int64                `json:"amount" gorm:"not null"` // Store in smallest currency unit
+	Currency          string               `json:"currency" gorm:"type:char(3);not null"`
+	Reason            string               `json:"reason" gorm:"type:text;not null"`
+	Evidence          string               `json:"evidence" gorm:"type:text;not null"`                 // Ticket, statement or document backing the adjustment
+	Reference         string               `json:"reference" gorm:"type:varchar(255);unique;not null"` // Reference of the transaction approval posts
+	Status            AdjustmentStatus     `json:"status" gorm:"type:varchar(20);not null;default:'pending';index"`
+	RequiredApprovals int                  `json:"required_approvals" gorm:"not null;default:1"`
+	ProposedBy        string               `json:"proposed_by" gorm:"type:varchar(255);not null"`
+	TransactionID     *uuid.UUID           `json:"transaction_id,omitempty" gorm:"type:char(36)"`
+	DecidedAt         *time.Time           `json:"decided_at,omitempty"`
+	CreatedAt         time.Time            `json:"created_at"`
+	UpdatedAt         time.Time            `json:"updated_at"`
+	Decisions         []AdjustmentDecision `json:"decisions,omitempty" gorm:"foreignKey:AdjustmentID"`
+}
+
+// AdjustmentDecision records one reviewer approving or rejecting an adjustment
+type AdjustmentDecision struct {
+	ID           uuid.UUID              `json:"id" gorm:"type:char(36);primary_key"`
+	AdjustmentID uuid.UUID              `json:"adjustment_id" gorm:"type:char(36);not null;index"`
+	Actor        string                 `json:"actor" gorm:"type:varchar(255);not null"`
+	Decision     AdjustmentDecisionType `json:"decision" gorm:"type:varchar(10);not null"`
+	Comment      string                 `json:"comment,omitempty" gorm:"type:text"`
+	CreatedAt    time.Time              `json:"created_at"`
+}
+
+// ApprovedBy reports whether the actor has already approved the adjustment
+func (a *BalanceAdjustment) ApprovedBy(actor string) bool {
+	for _, decision := range a.Decisions {
+		if decision.Actor == actor && decision.Decision == AdjustmentDecisionApprove {
+			return true
+		}
+	}
+	return false
+}
+
+// Approvals counts the approvals the adjustment has collected
+func (a *BalanceAdjustment) Approvals() int {
+	count := 0
+	for _, decision := range a.Decisions {
+		if decision.Decision == AdjustmentDecisionApprove {
+			count++
+		}
+	}
+	return count
+}
+
+// BeforeCreate hook for BalanceAdjustment model
+func (a *BalanceAdjustment) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// BeforeCreate hook for AdjustmentDecision model
+func (d *AdjustmentDecision) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// APIKey lets another backend call the API with the scopes it was granted.
+// Only a hash of the key is stored; the key itself is returned once, when
+// it is created.
+type APIKey struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:char(36);primary_key"`
+	Name       string     `json:"name" gorm:"type:varchar(64);not null"`
+	Key        string     `json:"key,omitempty" gorm:"-"`                      // Only set in the response that created it
+	KeyHash    string     `json:"-" gorm:"type:char(64);not null;uniqueIndex"` // Hex SHA-256 of the key
+	Prefix     string     `json:"prefix" gorm:"type:varchar(16);not null"`     // Start of the key, to tell keys apart
+	Scopes     []string   `json:"scopes" gorm:"type:text;serializer:json"`
+	AllowedIPs []string   `json:"allowed_ips,omitempty" gorm:"type:text;serializer:json"` // IPs or CIDR ranges; empty allows any
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`                                   // Nil never expires
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	LastUsedIP string     `json:"last_used_ip,omitempty" gorm:"type:varchar(45)"`
+	CreatedBy  string     `json:"created_by" gorm:"type:varchar(255)"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// BeforeCreate hook for APIKey model
+func (k *APIKey) BeforeCreate(tx *gorm.DB) error {
+	if k.ID == uuid.Nil {
+		k.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AuditLogEntry records one request made to the admin API: who made it,
+// what they asked for and how it ended
+type AuditLogEntry struct {
+	ID         uuid.UUID         `json:"id" gorm:"type:char(36);primary_key"`
+	Actor      string            `json:"actor" gorm:"type:varchar(255);not null;index"` // Subject of the admin's token
+	ActorRole  string            `json:"actor_role" gorm:"type:varchar(32);not null"`
+	Method     string            `json:"method" gorm:"type:varchar(10);not null"`
+	Route      string            `json:"route" gorm:"type:varchar(255);not null;index"`     // Route pattern, e.g. /api/v1/admin/wallets/:wallet_id/status
+	Params     map[string]string `json:"params,omitempty" gorm:"type:text;serializer:json"` // Path parameters, naming what was acted on
+	Query      string            `json:"query,omitempty" gorm:"type:text"`
+	Body       string            `json:"body,omitempty" gorm:"type:text"`
+	StatusCode int               `json:"status_code" gorm:"not null"`
+	ClientIP   string            `json:"client_ip" gorm:"type:varchar(45)"`
+	CreatedAt  time.Time         `json:"created_at" gorm:"index"`
+}
+
+// BeforeCreate hook for AuditLogEntry model
+func (e *AuditLogEntry) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EscrowResolution is how an admin settles a disputed escrow
+type EscrowResolution string
+
+const (
+	EscrowResolutionRelease EscrowResolution = "release" // Pay the recipient
+	EscrowResolutionRefund  EscrowResolution = "refund"  // Return the funds to the sender
+)
+
+// EscrowEvent records a change in the status of an escrow transaction
+type EscrowEvent struct {
+	ID            uuid.UUID         `json:"id" gorm:"type:char(36);primary_key"`
+	TransactionID uuid.UUID         `json:"transaction_id" gorm:"type:char(36);not null;index"`
+	FromStatus    TransactionStatus `json:"from_status" gorm:"type:varchar(20);not null"`
+	ToStatus      TransactionStatus `json:"to_status" gorm:"type:varchar(20);not null"`
+	Actor         string            `json:"actor" gorm:"not null"` // User ID, admin, or "system" for timeouts
+	Reason        string            `json:"reason,omitempty"`
+	CreatedAt     time.Time         `json:"created_at"`
+}
+
+// BeforeCreate hook for EscrowEvent model
+func (e *EscrowEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// FeeKind represents how a fee rule computes its fee
+type FeeKind string
+
+const (
+	FeeKindFlat       FeeKind = "flat"
+	FeeKindPercentage FeeKind = "percentage"
+	FeeKindTiered     FeeKind = "tiered"
+)
+
+// FeeTier is one band of a tiered fee rule
+type FeeTier struct {
+	UpTo        int64 `json:"up_to"`        // Inclusive upper bound of the band; zero means no bound
+	FlatAmount  int64 `json:"flat_amount"`  // Store in smallest currency unit
+	BasisPoints int64 `json:"basis_points"` // 1 basis point is 0.01%
+}
+
+// FeeRule configures the fee charged on a type of transaction. Empty
+// Currency or Tier match any; the most specific matching rule applies.
+type FeeRule struct {
+	ID              uuid.UUID       `json:"id" gorm:"type:char(36);primary_key"`
+	TransactionType TransactionType `json:"transaction_type" gorm:"type:varchar(20);not null;index"`
+	Currency        string          `json:"currency" gorm:"type:char(3);not null;default:''"`
+	Tier            string          `json:"tier" gorm:"type:varchar(32);not null;default:''"`
+	Kind            FeeKind         `json:"kind" gorm:"type:varchar(20);not null"`
+	FlatAmount      int64           `json:"flat_amount" gorm:"default:0"`                     // Store in smallest currency unit
+	BasisPoints     int64           `json:"basis_points" gorm:"default:0"`                    // 1 basis point is 0.01%
+	MinFee          int64           `json:"min_fee" gorm:"default:0"`                         // Store in smallest currency unit
+	MaxFee          int64           `json:"max_fee" gorm:"default:0"`                         // Zero means no cap
+	Tiers           []FeeTier       `json:"tiers,omitempty" gorm:"type:text;serializer:json"` // Bands in ascending order
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+}
+
+// FeeQuote previews the fee for a transaction before it is made
+type FeeQuote struct {
+	TransactionType TransactionType `json:"transaction_type"`
+	Currency        string          `json:"currency"`
+	Amount          int64           `json:"amount"`
+	Fee             int64           `json:"fee"`
+	WalletChange    int64           `json:"wallet_change"` // Signed change to the payer's balance, fee included
+	RuleID          *uuid.UUID      `json:"rule_id,omitempty"`
+}
+
+// BeforeCreate hook for FeeRule model
+func (r *FeeRule) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
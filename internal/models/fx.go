@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// FXQuote locks an exchange rate for a user's conversion for a short window
+type FXQuote struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:char(36);primary_key"`
+	UserID         uuid.UUID  `json:"user_id" gorm:"type:char(36);not null;index"`
+	SourceCurrency string     `json:"source_currency" gorm:"type:char(3);not null"`
+	TargetCurrency string     `json:"target_currency" gorm:"type:char(3);not null"`
+	SourceAmount   int64      `json:"source_amount" gorm:"not null"` // Store in smallest currency unit
+	TargetAmount   int64      `json:"target_amount" gorm:"not null"` // Store in smallest currency unit
+	Rate           string     `json:"rate" gorm:"type:varchar(32);not null"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	TransactionID  *uuid.UUID `json:"transaction_id,omitempty" gorm:"type:char(36)"` // Set once the quote has been used
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// FXConversion records how a conversion transaction turned the source
+// amount into the target amount. Rounding always favours the target amount
+// down; Remainder is the exact fraction of a target minor unit dropped.
+type FXConversion struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:char(36);primary_key"`
+	TransactionID  uuid.UUID  `json:"transaction_id" gorm:"type:char(36);not null;uniqueIndex"`
+	QuoteID        *uuid.UUID `json:"quote_id,omitempty" gorm:"type:char(36)"`
+	SourceCurrency string     `json:"source_currency" gorm:"type:char(3);not null"`
+	TargetCurrency string     `json:"target_currency" gorm:"type:char(3);not null"`
+	SourceAmount   int64      `json:"source_amount" gorm:"not null"` // Store in smallest currency unit
+	TargetAmount   int64      `json:"target_amount" gorm:"not null"` // Store in smallest currency unit
+	Rate           string     `json:"rate" gorm:"type:varchar(32);not null"`
+	Remainder      string     `json:"remainder" gorm:"type:varchar(40);not null"` // Fraction of a target minor unit lost to rounding
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// BeforeCreate hook for FXQuote model
+func (q *FXQuote) BeforeCreate(tx *gorm.DB) error {
+	if q.ID == uuid.Nil {
+		q.ID = uuid.New()
+	}
+	return nil
+}
+
+// BeforeCreate hook for FXConversion model
+func (c *FXConversion) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// HoldStatus represents the status of an authorization hold
+type HoldStatus string
+
+const (
+	HoldStatusActive   HoldStatus = "active"
+	HoldStatusCaptured HoldStatus = "captured"
+	HoldStatusVoided   HoldStatus = "voided"
+	HoldStatusExpired  HoldStatus = "expired"
+)
+
+// Hold represents funds reserved on a wallet until they are captured, voided or expire
+type Hold struct {
+	ID                   uuid.UUID    `json:"id" gorm:"type:char(36);primary_key"`
+	WalletID             uuid.UUID    `json:"wallet_id" gorm:"type:char(36);not null;index"`
+	UserID               uuid.UUID    `json:"user_id" gorm:"type:char(36);not null;index"`
+	Amount               int64        `json:"amount" gorm:"not null"` // Store in smallest currency unit
+	Currency             string       `json:"currency" gorm:"type:char(3);not null;default:'USD'"`
+	CapturedAmount       int64        `json:"captured_amount" gorm:"default:0"`
+	Status               HoldStatus   `json:"status" gorm:"type:varchar(20);default:'active';index"`
+	Reference            string       `json:"reference" gorm:"unique;not null"` // For idempotency
+	ExpiresAt            time.Time    `json:"expires_at" gorm:"index"`
+	CaptureTransactionID *uuid.UUID   `json:"capture_transaction_id,omitempty" gorm:"type:char(36)"`
+	CreatedAt            time.Time    `json:"created_at"`
+	UpdatedAt            time.Time    `json:"updated_at"`
+	CaptureTransaction   *Transaction `json:"capture_transaction,omitempty" gorm:"foreignKey:CaptureTransactionID"`
+}
+
+// BeforeCreate hook for Hold model
+func (h *Hold) BeforeCreate(tx *gorm.DB) error {
+	if h.ID == uuid.Nil {
+		h.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// InterestProduct is the kind of wallet an interest rate applies to
+type InterestProduct string
+
+const (
+	InterestProductPrimary InterestProduct = "primary" // A user's spendable wallet
+	InterestProductPocket  InterestProduct = "pocket"  // A named savings pocket
+)
+
+// InterestRateRule sets the annual interest paid on positive balances.
+// Empty Product, Tier or Currency match any; the most specific matching
+// rule applies. Deleted rules are kept so past days can still be accrued at
+// the rate they had.
+type InterestRateRule struct {
+	ID            uuid.UUID       `json:"id" gorm:"type:char(36);primary_key"`
+	Product       InterestProduct `json:"product" gorm:"type:varchar(20);not null;default:''"`
+	Tier          string          `json:"tier" gorm:"type:varchar(32);not null;default:''"`
+	Currency      string          `json:"currency" gorm:"type:char(3);not null;default:''"`
+	AnnualRateBps int64           `json:"annual_rate_bps" gorm:"not null"` // 1 basis point is 0.01% a year
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt  `json:"-" gorm:"index"`
+}
+
+// ActiveAt reports whether the rule was in force at t
+func (r *InterestRateRule) ActiveAt(t time.Time) bool {
+	return !r.CreatedAt.After(t) && (!r.DeletedAt.Valid || r.DeletedAt.Time.After(t))
+}
+
+// InterestAccrual records the interest one wallet earned on one day. Accrued
+// is exact: it is in units of 1/InterestScale of the smallest currency unit,
+// so nothing is lost to rounding until the month is paid out.
+type InterestAccrual struct {
+	ID            uuid.UUID  `json:"id" gorm:"type:char(36);primary_key"`
+	WalletID      uuid.UUID  `json:"wallet_id" gorm:"type:char(36);not null;uniqueIndex:idx_interest_accruals_wallet_date"`
+	Date          string     `json:"date" gorm:"type:char(10);not null;uniqueIndex:idx_interest_accruals_wallet_date"` // YYYY-MM-DD, UTC
+	Month         string     `json:"month" gorm:"type:char(7);not null;index"`                                         // YYYY-MM the accrual is paid with
+	Balance       int64      `json:"balance" gorm:"not null"`                                                          // Cash balance interest was earned on
+	RateBps       int64      `json:"rate_bps" gorm:"not null"`
+	Accrued       int64      `json:"accrued" gorm:"not null"`                       // In 1/InterestScale minor units
+	TransactionID *uuid.UUID `json:"transaction_id,omitempty" gorm:"type:char(36)"` // Payout the accrual went into
+	PaidAt        *time.Time `json:"paid_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// InterestScale is how many accrual units make one minor unit: a balance
+// times an annual rate in basis points, over the basis points in 100% and
+// the days in a year, is then an exact whole number of units
+const InterestScale = 10000 * 365
+
+// BeforeCreate hook for InterestRateRule model
+func (r *InterestRateRule) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// BeforeCreate hook for InterestAccrual model
+func (a *InterestAccrual) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
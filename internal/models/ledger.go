@@ -0,0 +1,84 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AccountType represents the kind of ledger account
+type AccountType string
+
+const (
+	AccountTypeUserWallet         AccountType = "user_wallet"
+	AccountTypeFundingSource      AccountType = "funding_source"
+	AccountTypeWithdrawalClearing AccountType = "withdrawal_clearing"
+	AccountTypeFeeIncome          AccountType = "fee_income"
+	AccountTypeFXPosition         AccountType = "fx_position"
+	AccountTypeEscrow             AccountType = "escrow"
+	AccountTypePromotions         AccountType = "promotions"
+	AccountTypeInterest           AccountType = "interest"
+	AccountTypeAdjustments        AccountType = "adjustments"
+	AccountTypeOpeningBalances    AccountType = "opening_balances"
+)
+
+// Codes of the system accounts that sit on the other side of wallet postings.
+// Each currency gets its own account, suffixed with the currency code.
+const (
+	AccountCodeFundingSource      = "system:funding_source"
+	AccountCodeWithdrawalClearing = "system:withdrawal_clearing"
+	AccountCodeFeeIncome          = "system:fee_income"
+	AccountCodeFXPosition         = "system:fx_position"
+	AccountCodeEscrow             = "system:escrow"
+	AccountCodePromotions         = "system:promotions"
+	AccountCodeInterest           = "system:interest"
+	AccountCodeAdjustments        = "system:adjustments"
+	AccountCodeOpeningBalances    = "system:opening_balances"
+)
+
+// WalletAccountCode returns the ledger account code for a wallet
+func WalletAccountCode(walletID uuid.UUID) string {
+	return "wallet:" + walletID.String()
+}
+
+// LedgerAccount represents an account in the double-entry ledger
+type LedgerAccount struct {
+	ID        uuid.UUID   `json:"id" gorm:"type:char(36);primary_key"`
+	Code      string      `json:"code" gorm:"type:varchar(100);unique;not null"`
+	Type      AccountType `json:"type" gorm:"type:varchar(32);not null"`
+	Currency  string      `json:"currency" gorm:"type:char(3);not null;default:'USD'"`
+	WalletID  *uuid.UUID  `json:"wallet_id,omitempty" gorm:"type:char(36);unique"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// LedgerEntry represents a single posting in the journal. Every entry debits
+// one account and credits another by the same positive amount, so each
+// journal sums to zero by construction and the check constraints keep it so.
+type LedgerEntry struct {
+	ID              uuid.UUID      `json:"id" gorm:"type:char(36);primary_key"`
+	TransactionID   uuid.UUID      `json:"transaction_id" gorm:"type:char(36);not null;index"`
+	DebitAccountID  uuid.UUID      `json:"debit_account_id" gorm:"type:char(36);not null;index"`
+	CreditAccountID uuid.UUID      `json:"credit_account_id" gorm:"type:char(36);not null;index;check:chk_ledger_entries_accounts,debit_account_id <> credit_account_id"`
+	Amount          int64          `json:"amount" gorm:"not null;check:chk_ledger_entries_amount,amount > 0"` // Store in smallest currency unit
+	Currency        string         `json:"currency" gorm:"type:char(3);not null;default:'USD'"`
+	CreatedAt       time.Time      `json:"created_at"`
+	DebitAccount    *LedgerAccount `json:"debit_account,omitempty" gorm:"foreignKey:DebitAccountID"`
+	CreditAccount   *LedgerAccount `json:"credit_account,omitempty" gorm:"foreignKey:CreditAccountID"`
+}
+
+// BeforeCreate hook for LedgerAccount model
+func (a *LedgerAccount) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// BeforeCreate hook for LedgerEntry model
+func (e *LedgerEntry) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
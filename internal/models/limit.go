@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// LimitPeriod represents the window a limit rule counts over
+type LimitPeriod string
+
+const (
+	LimitPeriodTransaction LimitPeriod = "transaction" // Caps a single transaction
+	LimitPeriodDay         LimitPeriod = "day"
+	LimitPeriodWeek        LimitPeriod = "week"
+	LimitPeriodMonth       LimitPeriod = "month"
+)
+
+// Window returns the length of a rolling period, or zero for per-transaction limits
+func (p LimitPeriod) Window() time.Duration {
+	switch p {
+	case LimitPeriodDay:
+		return 24 * time.Hour
+	case LimitPeriodWeek:
+		return 7 * 24 * time.Hour
+	case LimitPeriodMonth:
+		return 30 * 24 * time.Hour
+	}
+	return 0
+}
+
+// LimitRule caps the amount and number of transactions of a type over a
+// period. A rule applies to one user, one tier, or everyone when neither is
+// set; for each period only the most specific matching rule is enforced.
+type LimitRule struct {
+	ID              uuid.UUID       `json:"id" gorm:"type:char(36);primary_key"`
+	TransactionType TransactionType `json:"transaction_type" gorm:"type:varchar(20);not null;index"`
+	Currency        string          `json:"currency" gorm:"type:char(3);not null;default:''"` // Empty matches any currency
+	UserID          *uuid.UUID      `json:"user_id,omitempty" gorm:"type:char(36);index"`
+	Tier            string          `json:"tier" gorm:"type:varchar(32);not null;default:''"`
+	Period          LimitPeriod     `json:"period" gorm:"type:varchar(20);not null"`
+	MaxAmount       int64           `json:"max_amount" gorm:"default:0"` // Zero means no amount limit
+	MaxCount        int64           `json:"max_count" gorm:"default:0"`  // Zero means no count limit
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+}
+
+// BeforeCreate hook for LimitRule model
+func (r *LimitRule) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
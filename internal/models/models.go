@@ -3,37 +3,88 @@ package models
 import (
 	"time"
 
+	"github.com/Code-Linx/wallet-service/internal/currency"
+
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 // User represents a user in the system
 type User struct {
-	ID        uuid.UUID `json:"id" gorm:"type:char(36);primary_key"`
-	Name      string    `json:"name" gorm:"not null"`
-	Email     string    `json:"email" gorm:"unique;not null"`
-	Wallet    *Wallet   `json:"wallet" gorm:"foreignKey:UserID"` // 👈 Use pointer here
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID         uuid.UUID `json:"id" gorm:"type:char(36);primary_key"`
+	Name       string    `json:"name" gorm:"not null"`
+	Email      string    `json:"email" gorm:"unique;not null"`
+	Tier       string    `json:"tier" gorm:"type:varchar(32);not null;default:'standard'"` // Selects which fee rules apply
+	IsMerchant bool      `json:"is_merchant" gorm:"not null;default:false"`                // Can be paid with promotional credit
+	Wallet     *Wallet   `json:"wallet" gorm:"foreignKey:UserID"`                          // 👈 Use pointer here; wallet in the default currency
+	Wallets    []Wallet  `json:"wallets,omitempty" gorm:"foreignKey:UserID"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
 }
 
-// Wallet represents a user's wallet
+// UserTierStandard is the tier every user starts in
+const UserTierStandard = "standard"
+
+// Wallet represents a user's wallet in one currency. Each user has one
+// primary wallet per currency and any number of named pockets beside it.
 type Wallet struct {
-	ID        uuid.UUID `json:"id" gorm:"type:char(36);primary_key"`
-	UserID    uuid.UUID `json:"user_id" gorm:"type:char(36);not null;unique"`
-	Balance   int64     `json:"balance" gorm:"default:0"` // Store in smallest currency unit (cents)
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-	User      *User     `json:"user" gorm:"foreignKey:UserID"` // 👈 Use pointer here
+	ID               uuid.UUID    `json:"id" gorm:"type:char(36);primary_key"`
+	UserID           uuid.UUID    `json:"user_id" gorm:"type:char(36);not null;uniqueIndex:idx_wallets_user_currency_name"`
+	Currency         string       `json:"currency" gorm:"type:char(3);not null;default:'USD';uniqueIndex:idx_wallets_user_currency_name"`        // ISO 4217 code
+	Name             string       `json:"name,omitempty" gorm:"type:varchar(64);not null;default:'';uniqueIndex:idx_wallets_user_currency_name"` // Pocket name; empty for the primary wallet
+	IsPrimary        bool         `json:"is_primary" gorm:"not null;default:false"`                                                              // Spendable wallet that user-keyed operations act on
+	Exponent         int          `json:"exponent" gorm:"-"`                                                                                     // Minor-unit digits of the currency
+	Balance          int64        `json:"balance" gorm:"default:0"`                                                                              // Store in smallest currency unit
+	HeldBalance      int64        `json:"held_balance" gorm:"default:0"`                                                                         // Sum of active holds
+	PromoBalance     int64        `json:"promo_balance" gorm:"default:0"`                                                                        // Promotional credit left in active buckets
+	AvailableBalance int64        `json:"available_balance" gorm:"-"`                                                                            // Cash balance minus active holds
+	CreditLimit      int64        `json:"credit_limit" gorm:"default:0"`                                                                         // How far the cash balance may go below zero
+	OverdraftRateBps int64        `json:"overdraft_rate_bps" gorm:"default:0"`                                                                   // Annual interest on the overdrawn amount, in basis points
+	OverdraftUsed    int64        `json:"overdraft_used" gorm:"-"`                                                                               // How far the cash balance is below zero
+	InterestCarry    int64        `json:"-" gorm:"default:0"`                                                                                    // Interest owed short of a minor unit, in InterestScale units, carried to the next payout
+	Status           WalletStatus `json:"status" gorm:"type:varchar(20);not null;default:'active'"`
+	StatusReason     string       `json:"status_reason,omitempty"`
+	CreatedAt        time.Time    `json:"created_at"`
+	UpdatedAt        time.Time    `json:"updated_at"`
+	User             *User        `json:"user" gorm:"foreignKey:UserID"` // 👈 Use pointer here
+}
+
+// Available returns the cash that is not reserved by holds. Promotional
+// credit is part of the balance but only spent on transfers to merchants.
+func (w *Wallet) Available() int64 {
+	return w.Balance - w.HeldBalance - w.PromoBalance
+}
+
+// Spendable returns what withdrawals and transfers may take: the available
+// cash plus whatever is left of the credit line
+func (w *Wallet) Spendable() int64 {
+	return w.Available() + w.CreditLimit
+}
+
+// Overdrawn returns how far the cash balance is below zero
+func (w *Wallet) Overdrawn() int64 {
+	return max(0, w.PromoBalance-w.Balance)
 }
 
 // TransactionType represents the type of transaction
 type TransactionType string
 
 const (
-	TransactionTypeCredit   TransactionType = "credit"
-	TransactionTypeDebit    TransactionType = "debit"
-	TransactionTypeTransfer TransactionType = "transfer"
+	TransactionTypeCredit         TransactionType = "credit"
+	TransactionTypeDebit          TransactionType = "debit"
+	TransactionTypeTransfer       TransactionType = "transfer"
+	TransactionTypeRefund         TransactionType = "refund"
+	TransactionTypeConversion     TransactionType = "conversion"
+	TransactionTypeFee            TransactionType = "fee"
+	TransactionTypeMove           TransactionType = "move"            // Between two wallets of the same user
+	TransactionTypeEscrow         TransactionType = "escrow"          // Transfer held in escrow until released or returned
+	TransactionTypeSplit          TransactionType = "split"           // Parent of the transfer legs of a split payment
+	TransactionTypePromoCredit    TransactionType = "promo_credit"    // Promotional credit granted to a wallet
+	TransactionTypePromoExpiry    TransactionType = "promo_expiry"    // Unspent promotional credit taken back
+	TransactionTypeInterestCharge TransactionType = "interest_charge" // Daily interest on an overdrawn balance
+	TransactionTypeInterestPayout TransactionType = "interest_payout" // Monthly savings interest paid into a wallet
+	TransactionTypeAdjustment     TransactionType = "adjustment"      // Manual correction posted by finance
+	TransactionTypeOpeningBalance TransactionType = "opening_balance" // Brings a wallet from before the ledger onto it
 )
 
 // TransactionStatus represents the status of a transaction
@@ -43,24 +94,46 @@ const (
 	TransactionStatusPending   TransactionStatus = "pending"
 	TransactionStatusCompleted TransactionStatus = "completed"
 	TransactionStatusFailed    TransactionStatus = "failed"
+	TransactionStatusDisputed  TransactionStatus = "disputed"  // Escrow frozen until an admin resolves it
+	TransactionStatusCancelled TransactionStatus = "cancelled" // Escrow returned to the sender
 )
 
 // Transaction represents a transaction record
 type Transaction struct {
-	ID          uuid.UUID         `json:"id" gorm:"type:char(36);primary_key"`
-	UserID      uuid.UUID         `json:"user_id" gorm:"type:char(36);not null"`
-	Type        TransactionType   `json:"type" gorm:"not null"`
-	Amount      int64             `json:"amount" gorm:"not null"` // Store in smallest currency unit
-	Description string            `json:"description"`
-	Status      TransactionStatus `json:"status" gorm:"default:'pending'"`
-	Reference   string            `json:"reference" gorm:"unique;not null"` // For idempotency
-	FromUserID  *uuid.UUID        `json:"from_user_id,omitempty" gorm:"type:char(36)"`
-	ToUserID    *uuid.UUID        `json:"to_user_id,omitempty" gorm:"type:char(36)"`
-	CreatedAt   time.Time         `json:"created_at"`
-	UpdatedAt   time.Time         `json:"updated_at"`
-	User        User              `json:"user" gorm:"foreignKey:UserID"`
-	FromUser    *User             `json:"from_user,omitempty" gorm:"foreignKey:FromUserID"`
-	ToUser      *User             `json:"to_user,omitempty" gorm:"foreignKey:ToUserID"`
+	ID                  uuid.UUID         `json:"id" gorm:"type:char(36);primary_key"`
+	UserID              uuid.UUID         `json:"user_id" gorm:"type:char(36);not null"`
+	Type                TransactionType   `json:"type" gorm:"not null"`
+	Amount              int64             `json:"amount" gorm:"not null"` // Store in smallest currency unit
+	Currency            string            `json:"currency" gorm:"type:char(3);not null;default:'USD'"`
+	Description         string            `json:"description"`
+	Status              TransactionStatus `json:"status" gorm:"default:'pending'"`
+	Reference           string            `json:"reference" gorm:"unique;not null"` // For idempotency
+	FromUserID          *uuid.UUID        `json:"from_user_id,omitempty" gorm:"type:char(36)"`
+	ToUserID            *uuid.UUID        `json:"to_user_id,omitempty" gorm:"type:char(36)"`
+	ParentTransactionID *uuid.UUID        `json:"parent_transaction_id,omitempty" gorm:"type:char(36);index"` // Transaction this one compensates
+	RefundedAmount      int64             `json:"refunded_amount" gorm:"default:0"`
+	FeeAmount           int64             `json:"fee_amount" gorm:"default:0"`       // Charged separately as a linked fee transaction
+	PromoAmount         int64             `json:"promo_amount" gorm:"default:0"`     // Part of the amount paid from promotional credit
+	ExpiresAt           *time.Time        `json:"expires_at,omitempty" gorm:"index"` // Escrow only: when pending funds go back to the sender
+	FailureReason       string            `json:"failure_reason,omitempty"`
+	CompletedAt         *time.Time        `json:"completed_at,omitempty"`
+	FailedAt            *time.Time        `json:"failed_at,omitempty"`
+	DisputedAt          *time.Time        `json:"disputed_at,omitempty"`
+	CancelledAt         *time.Time        `json:"cancelled_at,omitempty"`
+	CreatedAt           time.Time         `json:"created_at"`
+	UpdatedAt           time.Time         `json:"updated_at"`
+	User                User              `json:"user" gorm:"foreignKey:UserID"`
+	FromUser            *User             `json:"from_user,omitempty" gorm:"foreignKey:FromUserID"`
+	ToUser              *User             `json:"to_user,omitempty" gorm:"foreignKey:ToUserID"`
+	Entries             []LedgerEntry     `json:"entries,omitempty" gorm:"foreignKey:TransactionID"`
+	Conversion          *FXConversion     `json:"conversion,omitempty" gorm:"foreignKey:TransactionID"`
+	EscrowEvents        []EscrowEvent     `json:"escrow_events,omitempty" gorm:"foreignKey:TransactionID"`
+	Legs                []Transaction     `json:"legs,omitempty" gorm:"foreignKey:ParentTransactionID;constraint:-"` // Split payments only
+}
+
+// RefundableAmount returns how much of the transaction has not been refunded yet
+func (t *Transaction) RefundableAmount() int64 {
+	return t.Amount - t.RefundedAmount
 }
 
 // BeforeCreate hook for User model
@@ -79,19 +152,44 @@ func (w *Wallet) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// AfterFind hook for Wallet model
+func (w *Wallet) AfterFind(tx *gorm.DB) error {
+	w.Exponent = currency.Exponent(w.Currency)
+	w.AvailableBalance = w.Available()
+	w.OverdraftUsed = w.Overdrawn()
+	return nil
+}
+
 // BeforeCreate hook for Transaction model
 func (t *Transaction) BeforeCreate(tx *gorm.DB) error {
 	if t.ID == uuid.Nil {
 		t.ID = uuid.New()
 	}
+	if t.Status == "" {
+		t.Status = TransactionStatusPending
+	}
+	// Later statuses are reached through Transition, never written directly
+	if !t.Status.IsInitial() {
+		return ErrInvalidTransition
+	}
+	if t.CompletedAt == nil && t.FailedAt == nil {
+		t.stamp(tx.Statement.DB.NowFunc())
+	}
 	return nil
 }
 
 // ReconciliationResult represents the result of a reconciliation process
 type ReconciliationResult struct {
 	UserID            uuid.UUID `json:"user_id"`
+	WalletID          uuid.UUID `json:"wallet_id"`
+	Currency          string    `json:"currency"`
 	StoredBalance     int64     `json:"stored_balance"`
+	PromoBalance      int64     `json:"promo_balance"`  // Promotional credit the wallet says it holds
+	BucketBalance     int64     `json:"bucket_balance"` // What its active promo buckets add up to
+	CreditLimit       int64     `json:"credit_limit"`
+	OverLimit         bool      `json:"over_limit"` // Overdrawn beyond the credit limit; a mismatch unless interest explains it
 	CalculatedBalance int64     `json:"calculated_balance"`
+	LedgerBalance     int64     `json:"ledger_balance"`
 	Difference        int64     `json:"difference"`
 	HasMismatch       bool      `json:"has_mismatch"`
 	CheckedAt         time.Time `json:"checked_at"`
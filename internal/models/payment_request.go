@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PaymentRequestStatus represents the status of a payment request
+type PaymentRequestStatus string
+
+const (
+	PaymentRequestStatusPending  PaymentRequestStatus = "pending"
+	PaymentRequestStatusAccepted PaymentRequestStatus = "accepted"
+	PaymentRequestStatusDeclined PaymentRequestStatus = "declined"
+	PaymentRequestStatusExpired  PaymentRequestStatus = "expired"
+)
+
+// PaymentRequest is one user asking another for money. Accepting it
+// transfers the amount from the payer to the requester.
+type PaymentRequest struct {
+	ID            uuid.UUID            `json:"id" gorm:"type:char(36);primary_key"`
+	RequesterID   uuid.UUID            `json:"requester_id" gorm:"type:char(36);not null;index"` // Receives the money
+	PayerID       uuid.UUID            `json:"payer_id" gorm:"type:char(36);not null;index"`
+	Amount        int64                `json:"amount" gorm:"not null"` // Store in smallest currency unit
+	Currency      string               `json:"currency" gorm:"type:char(3);not null;default:'USD'"`
+	Memo          string               `json:"memo" gorm:"type:varchar(255)"`
+	Status        PaymentRequestStatus `json:"status" gorm:"type:varchar(20);default:'pending';index"`
+	Reference     string               `json:"reference" gorm:"unique;not null"` // For idempotency
+	ExpiresAt     time.Time            `json:"expires_at" gorm:"index"`
+	RespondedAt   *time.Time           `json:"responded_at,omitempty"`                        // When the payer accepted or declined
+	TransactionID *uuid.UUID           `json:"transaction_id,omitempty" gorm:"type:char(36)"` // Set once accepted
+	CreatedAt     time.Time            `json:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at"`
+	Requester     *User                `json:"requester,omitempty" gorm:"foreignKey:RequesterID"`
+	Payer         *User                `json:"payer,omitempty" gorm:"foreignKey:PayerID"`
+	Transaction   *Transaction         `json:"transaction,omitempty" gorm:"foreignKey:TransactionID"`
+}
+
+// BeforeCreate hook for PaymentRequest model
+func (p *PaymentRequest) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
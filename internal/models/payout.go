@@ -0,0 +1,86 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PayoutMode decides what happens to a batch when one of its items fails
+type PayoutMode string
+
+const (
+	PayoutModeAllOrNothing PayoutMode = "all_or_nothing" // Any failure rolls back the whole batch
+	PayoutModeBestEffort   PayoutMode = "best_effort"    // Each item stands on its own
+)
+
+// PayoutBatchStatus represents the status of a payout batch
+type PayoutBatchStatus string
+
+const (
+	PayoutBatchStatusPending            PayoutBatchStatus = "pending"
+	PayoutBatchStatusProcessing         PayoutBatchStatus = "processing"
+	PayoutBatchStatusCompleted          PayoutBatchStatus = "completed"
+	PayoutBatchStatusPartiallyCompleted PayoutBatchStatus = "partially_completed"
+	PayoutBatchStatusFailed             PayoutBatchStatus = "failed"
+)
+
+// PayoutItemStatus represents the status of a single payout in a batch
+type PayoutItemStatus string
+
+const (
+	PayoutItemStatusPending    PayoutItemStatus = "pending"
+	PayoutItemStatusSucceeded  PayoutItemStatus = "succeeded"
+	PayoutItemStatusFailed     PayoutItemStatus = "failed"
+	PayoutItemStatusRolledBack PayoutItemStatus = "rolled_back" // Not applied because another item in an all-or-nothing batch failed
+)
+
+// PayoutBatch is a list of transfers from one sender submitted together
+type PayoutBatch struct {
+	ID             uuid.UUID         `json:"id" gorm:"type:char(36);primary_key"`
+	UserID         uuid.UUID         `json:"user_id" gorm:"type:char(36);not null;index"` // Sender
+	Currency       string            `json:"currency" gorm:"type:char(3);not null;default:'USD'"`
+	Mode           PayoutMode        `json:"mode" gorm:"type:varchar(20);not null"`
+	Reference      string            `json:"reference" gorm:"uniqueIndex;not null"`
+	Status         PayoutBatchStatus `json:"status" gorm:"type:varchar(20);default:'pending';index"`
+	ItemCount      int               `json:"item_count"`
+	TotalAmount    int64             `json:"total_amount"`
+	SucceededCount int               `json:"succeeded_count" gorm:"default:0"`
+	FailedCount    int               `json:"failed_count" gorm:"default:0"`
+	Items          []PayoutItem      `json:"items,omitempty" gorm:"foreignKey:BatchID"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+	CompletedAt    *time.Time        `json:"completed_at,omitempty"`
+}
+
+// PayoutItem is one transfer within a payout batch
+type PayoutItem struct {
+	ID            uuid.UUID        `json:"id" gorm:"type:char(36);primary_key"`
+	BatchID       uuid.UUID        `json:"batch_id" gorm:"type:char(36);not null;index"`
+	Position      int              `json:"position"` // Order within the batch, starting at 1
+	ToUserID      uuid.UUID        `json:"to_user_id" gorm:"type:char(36);not null"`
+	Amount        int64            `json:"amount" gorm:"not null"`
+	Reference     string           `json:"reference" gorm:"not null"` // Reference of the resulting transfer
+	Status        PayoutItemStatus `json:"status" gorm:"type:varchar(20);default:'pending'"`
+	TransactionID *uuid.UUID       `json:"transaction_id,omitempty" gorm:"type:char(36)"`
+	Error         string           `json:"error,omitempty"`
+	CreatedAt     time.Time        `json:"created_at"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+}
+
+// BeforeCreate hook for PayoutBatch model
+func (b *PayoutBatch) BeforeCreate(tx *gorm.DB) error {
+	if b.ID == uuid.Nil {
+		b.ID = uuid.New()
+	}
+	return nil
+}
+
+// BeforeCreate hook for PayoutItem model
+func (i *PayoutItem) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PromoBucketStatus represents the status of a promotional credit bucket
+type PromoBucketStatus string
+
+const (
+	PromoBucketStatusActive  PromoBucketStatus = "active"
+	PromoBucketStatusSpent   PromoBucketStatus = "spent"
+	PromoBucketStatusExpired PromoBucketStatus = "expired"
+)
+
+// PromoBucket is promotional credit granted to a wallet. It is part of the
+// wallet balance but can only be spent on transfers to merchants, and
+// whatever is left when it expires goes back to the promotions account.
+type PromoBucket struct {
+	ID            uuid.UUID         `json:"id" gorm:"type:char(36);primary_key"`
+	WalletID      uuid.UUID         `json:"wallet_id" gorm:"type:char(36);not null;index"`
+	UserID        uuid.UUID         `json:"user_id" gorm:"type:char(36);not null;index"`
+	Amount        int64             `json:"amount" gorm:"not null"` // Granted, in the smallest currency unit
+	Remaining     int64             `json:"remaining" gorm:"not null"`
+	Currency      string            `json:"currency" gorm:"type:char(3);not null;default:'USD'"`
+	Description   string            `json:"description"`
+	Status        PromoBucketStatus `json:"status" gorm:"type:varchar(20);default:'active';index"`
+	ExpiresAt     time.Time         `json:"expires_at" gorm:"index"`
+	TransactionID uuid.UUID         `json:"transaction_id" gorm:"type:char(36);not null"` // The grant
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+}
+
+// PromoSpend records how much of a bucket a transfer used, so a refund of
+// the transfer can put it back as promotional credit rather than cash
+type PromoSpend struct {
+	ID            uuid.UUID `json:"id" gorm:"type:char(36);primary_key"`
+	BucketID      uuid.UUID `json:"bucket_id" gorm:"type:char(36);not null;index"`
+	TransactionID uuid.UUID `json:"transaction_id" gorm:"type:char(36);not null;index"`
+	Amount        int64     `json:"amount" gorm:"not null"`
+	Restored      int64     `json:"restored" gorm:"default:0"` // Put back by refunds
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// BeforeCreate hook for PromoBucket model
+func (b *PromoBucket) BeforeCreate(tx *gorm.DB) error {
+	if b.ID == uuid.Nil {
+		b.ID = uuid.New()
+	}
+	return nil
+}
+
+// BeforeCreate hook for PromoSpend model
+func (s *PromoSpend) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
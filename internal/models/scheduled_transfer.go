@@ -0,0 +1,76 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ScheduledTransferStatus represents the status of a scheduled transfer
+type ScheduledTransferStatus string
+
+const (
+	ScheduledTransferStatusActive    ScheduledTransferStatus = "active"
+	ScheduledTransferStatusPaused    ScheduledTransferStatus = "paused"
+	ScheduledTransferStatusCompleted ScheduledTransferStatus = "completed"
+	ScheduledTransferStatusFailed    ScheduledTransferStatus = "failed"
+	ScheduledTransferStatusCancelled ScheduledTransferStatus = "cancelled"
+)
+
+// ScheduledTransfer is a standing order to transfer funds once at a given
+// time, or repeatedly following a cron expression
+type ScheduledTransfer struct {
+	ID           uuid.UUID               `json:"id" gorm:"type:char(36);primary_key"`
+	UserID       uuid.UUID               `json:"user_id" gorm:"type:char(36);not null;index"` // Sender
+	ToUserID     uuid.UUID               `json:"to_user_id" gorm:"type:char(36);not null"`
+	Amount       int64                   `json:"amount" gorm:"not null"` // Store in smallest currency unit
+	Currency     string                  `json:"currency" gorm:"type:char(3);not null;default:'USD'"`
+	Description  string                  `json:"description"`
+	Schedule     string                  `json:"schedule" gorm:"type:varchar(100)"` // Cron expression; empty for a one-off transfer
+	OccurrenceAt time.Time               `json:"occurrence_at"`                     // Scheduled time of the occurrence currently due
+	NextRunAt    time.Time               `json:"next_run_at" gorm:"index"`          // When the scheduler next attempts it, later than OccurrenceAt while retrying
+	EndsAt       *time.Time              `json:"ends_at,omitempty"`
+	Status       ScheduledTransferStatus `json:"status" gorm:"type:varchar(20);default:'active';index"`
+	RunCount     int                     `json:"run_count" gorm:"default:0"`
+	FailureCount int                     `json:"failure_count" gorm:"default:0"` // Failed attempts at the current occurrence
+	LastError    string                  `json:"last_error,omitempty"`
+	CreatedAt    time.Time               `json:"created_at"`
+	UpdatedAt    time.Time               `json:"updated_at"`
+}
+
+// ScheduledTransferRunStatus represents the outcome of one scheduler attempt
+type ScheduledTransferRunStatus string
+
+const (
+	ScheduledTransferRunSucceeded ScheduledTransferRunStatus = "succeeded"
+	ScheduledTransferRunFailed    ScheduledTransferRunStatus = "failed"
+)
+
+// ScheduledTransferRun records one attempt at an occurrence of a scheduled transfer
+type ScheduledTransferRun struct {
+	ID                  uuid.UUID                  `json:"id" gorm:"type:char(36);primary_key"`
+	ScheduledTransferID uuid.UUID                  `json:"scheduled_transfer_id" gorm:"type:char(36);not null;index"`
+	OccurrenceAt        time.Time                  `json:"occurrence_at"`
+	Attempt             int                        `json:"attempt"`
+	Status              ScheduledTransferRunStatus `json:"status" gorm:"type:varchar(20);not null"`
+	TransactionID       *uuid.UUID                 `json:"transaction_id,omitempty" gorm:"type:char(36)"`
+	Error               string                     `json:"error,omitempty"`
+	CreatedAt           time.Time                  `json:"created_at"`
+}
+
+// BeforeCreate hook for ScheduledTransfer model
+func (s *ScheduledTransfer) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// BeforeCreate hook for ScheduledTransferRun model
+func (r *ScheduledTransferRun) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidTransition is returned when a transaction cannot move from its
+// current status to the requested one
+var ErrInvalidTransition = errors.New("invalid transaction status transition")
+
+// transactionTransitions lists the statuses each status may move to.
+// Completed, failed and cancelled are final.
+var transactionTransitions = map[TransactionStatus][]TransactionStatus{
+	TransactionStatusPending:  {TransactionStatusCompleted, TransactionStatusFailed, TransactionStatusDisputed, TransactionStatusCancelled},
+	TransactionStatusDisputed: {TransactionStatusCompleted, TransactionStatusCancelled},
+}
+
+// CanTransitionTo reports whether a transaction may move from s to next
+func (s TransactionStatus) CanTransitionTo(next TransactionStatus) bool {
+	for _, allowed := range transactionTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsInitial reports whether a transaction may be created in status s.
+// Anything else is only reachable through a transition.
+func (s TransactionStatus) IsInitial() bool {
+	return s == TransactionStatusPending || s == TransactionStatusCompleted || s == TransactionStatusFailed
+}
+
+// Transition moves the transaction to a new status at the given time.
+// The reason is kept only for failures.
+func (t *Transaction) Transition(to TransactionStatus, reason string, at time.Time) error {
+	if !t.Status.CanTransitionTo(to) {
+		return ErrInvalidTransition
+	}
+	t.Status = to
+	if to == TransactionStatusFailed {
+		t.FailureReason = reason
+	}
+	t.stamp(at)
+	return nil
+}
+
+// stamp records when the transaction reached its current status
+func (t *Transaction) stamp(at time.Time) {
+	switch t.Status {
+	case TransactionStatusCompleted:
+		t.CompletedAt = &at
+	case TransactionStatusFailed:
+		t.FailedAt = &at
+	case TransactionStatusDisputed:
+		t.DisputedAt = &at
+	case TransactionStatusCancelled:
+		t.CancelledAt = &at
+	}
+}
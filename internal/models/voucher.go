@@ -0,0 +1,82 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// VoucherStatus represents the status of a voucher code
+type VoucherStatus string
+
+const (
+	VoucherStatusActive    VoucherStatus = "active"
+	VoucherStatusExhausted VoucherStatus = "exhausted" // Redeemed as many times as allowed
+)
+
+// VoucherBatch is a set of voucher codes generated together with the same
+// value, currency, expiry and redemption limit
+type VoucherBatch struct {
+	ID             uuid.UUID `json:"id" gorm:"type:char(36);primary_key"`
+	Description    string    `json:"description"`
+	Amount         int64     `json:"amount" gorm:"not null"` // Credited per redemption, in the smallest currency unit
+	Currency       string    `json:"currency" gorm:"type:char(3);not null;default:'USD'"`
+	MaxRedemptions int       `json:"max_redemptions" gorm:"not null;default:1"` // 1 for single-use codes
+	Count          int       `json:"count" gorm:"not null"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	CreatedAt      time.Time `json:"created_at"`
+	Vouchers       []Voucher `json:"vouchers,omitempty" gorm:"foreignKey:BatchID"`
+}
+
+// Voucher is one code that credits a wallet when redeemed. Only a hash of
+// the code is stored; the code itself is returned once, when generated.
+type Voucher struct {
+	ID             uuid.UUID     `json:"id" gorm:"type:char(36);primary_key"`
+	BatchID        uuid.UUID     `json:"batch_id" gorm:"type:char(36);not null;index"`
+	Code           string        `json:"code,omitempty" gorm:"-"`                     // Only set in the response that generated it
+	CodeHash       string        `json:"-" gorm:"type:char(64);not null;uniqueIndex"` // Hex SHA-256 of the normalized code
+	CodeHint       string        `json:"code_hint" gorm:"type:varchar(8)"`            // Last characters of the code, for support
+	Amount         int64         `json:"amount" gorm:"not null"`                      // Credited per redemption
+	Currency       string        `json:"currency" gorm:"type:char(3);not null;default:'USD'"`
+	MaxRedemptions int           `json:"max_redemptions" gorm:"not null;default:1"`
+	Redemptions    int           `json:"redemptions" gorm:"not null;default:0"`
+	Status         VoucherStatus `json:"status" gorm:"type:varchar(20);default:'active'"`
+	ExpiresAt      time.Time     `json:"expires_at"`
+	CreatedAt      time.Time     `json:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+}
+
+// VoucherRedemption records one user redeeming a voucher. A user redeems a
+// given voucher at most once.
+type VoucherRedemption struct {
+	ID            uuid.UUID `json:"id" gorm:"type:char(36);primary_key"`
+	VoucherID     uuid.UUID `json:"voucher_id" gorm:"type:char(36);not null;uniqueIndex:idx_voucher_redemptions_voucher_user"`
+	UserID        uuid.UUID `json:"user_id" gorm:"type:char(36);not null;uniqueIndex:idx_voucher_redemptions_voucher_user"`
+	TransactionID uuid.UUID `json:"transaction_id" gorm:"type:char(36);not null"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// BeforeCreate hook for VoucherBatch model
+func (b *VoucherBatch) BeforeCreate(tx *gorm.DB) error {
+	if b.ID == uuid.Nil {
+		b.ID = uuid.New()
+	}
+	return nil
+}
+
+// BeforeCreate hook for Voucher model
+func (v *Voucher) BeforeCreate(tx *gorm.DB) error {
+	if v.ID == uuid.Nil {
+		v.ID = uuid.New()
+	}
+	return nil
+}
+
+// BeforeCreate hook for VoucherRedemption model
+func (r *VoucherRedemption) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WalletStatus represents what a wallet may be used for
+type WalletStatus string
+
+const (
+	WalletStatusActive    WalletStatus = "active"
+	WalletStatusFrozen    WalletStatus = "frozen"    // Money can come in but not go out
+	WalletStatusSuspended WalletStatus = "suspended" // No money moves either way
+	WalletStatusClosed    WalletStatus = "closed"    // Permanently out of use
+)
+
+// WalletStatusChange records who changed a wallet's status, and why
+type WalletStatusChange struct {
+	ID                 uuid.UUID    `json:"id" gorm:"type:char(36);primary_key"`
+	WalletID           uuid.UUID    `json:"wallet_id" gorm:"type:char(36);not null;index"`
+	FromStatus         WalletStatus `json:"from_status" gorm:"type:varchar(20);not null"`
+	ToStatus           WalletStatus `json:"to_status" gorm:"type:varchar(20);not null"`
+	Reason             string       `json:"reason" gorm:"not null"`
+	Actor              string       `json:"actor" gorm:"not null"`                               // Who made the change
+	SweepTransactionID *uuid.UUID   `json:"sweep_transaction_id,omitempty" gorm:"type:char(36)"` // Set when closing moved the balance elsewhere
+	CreatedAt          time.Time    `json:"created_at"`
+}
+
+// BeforeCreate hook for WalletStatusChange model
+func (c *WalletStatusChange) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
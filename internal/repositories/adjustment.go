@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"github.com/Code-Linx/wallet-service/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AdjustmentRepository interface defines balance adjustment repository methods
+type AdjustmentRepository interface {
+	Create(adjustment *models.BalanceAdjustment) error
+	GetByID(id uuid.UUID) (*models.BalanceAdjustment, error)
+	GetByIDForUpdate(id uuid.UUID) (*models.BalanceAdjustment, error)
+	GetByReference(reference string) (*models.BalanceAdjustment, error)
+	List(status models.AdjustmentStatus) ([]models.BalanceAdjustment, error)
+	Update(adjustment *models.BalanceAdjustment) error
+	CreateDecision(decision *models.AdjustmentDecision) error
+}
+
+// adjustmentRepository implements AdjustmentRepository
+type adjustmentRepository struct {
+	db *gorm.DB
+}
+
+// Adjustment Repository Implementation
+
+func (r *adjustmentRepository) Create(adjustment *models.BalanceAdjustment) error {
+	return r.db.Create(adjustment).Error
+}
+
+func (r *adjustmentRepository) GetByID(id uuid.UUID) (*models.BalanceAdjustment, error) {
+	var adjustment models.BalanceAdjustment
+	err := r.preloadDecisions(r.db).First(&adjustment, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &adjustment, nil
+}
+
+// GetByIDForUpdate locks the adjustment so two reviewers deciding at once
+// are handled one after the other
+func (r *adjustmentRepository) GetByIDForUpdate(id uuid.UUID) (*models.BalanceAdjustment, error) {
+	var adjustment models.BalanceAdjustment
+	err := r.preloadDecisions(r.db.Clauses(clause.Locking{Strength: "UPDATE"})).First(&adjustment, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &adjustment, nil
+}
+
+func (r *adjustmentRepository) GetByReference(reference string) (*models.BalanceAdjustment, error) {
+	var adjustment models.BalanceAdjustment
+	err := r.preloadDecisions(r.db).First(&adjustment, "reference = ?", reference).Error
+	if err != nil {
+		return nil, err
+	}
+	return &adjustment, nil
+}
+
+// List returns adjustments in the given status, or all of them when status
+// is empty, newest first
+func (r *adjustmentRepository) List(status models.AdjustmentStatus) ([]models.BalanceAdjustment, error) {
+	var adjustments []models.BalanceAdjustment
+	query := r.preloadDecisions(r.db)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Order("created_at DESC").Find(&adjustments).Error
+	return adjustments, err
+}
+
+func (r *adjustmentRepository) Update(adjustment *models.BalanceAdjustment) error {
+	return r.db.Omit("Decisions").Save(adjustment).Error
+}
+
+func (r *adjustmentRepository) CreateDecision(decision *models.AdjustmentDecision) error {
+	return r.db.Create(decision).Error
+}
+
+// preloadDecisions loads an adjustment's decisions in the order they were made
+func (r *adjustmentRepository) preloadDecisions(db *gorm.DB) *gorm.DB {
+	return db.Preload("Decisions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at ASC, id ASC")
+	})
+}
@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// APIKeyRepository interface defines API key repository methods
+type APIKeyRepository interface {
+	Create(key *models.APIKey) error
+	GetByID(id uuid.UUID) (*models.APIKey, error)
+	GetByHash(keyHash string) (*models.APIKey, error)
+	List() ([]models.APIKey, error)
+	Revoke(id uuid.UUID, at time.Time) error
+	RecordUse(id uuid.UUID, at time.Time, ip string) error
+}
+
+// apiKeyRepository implements APIKeyRepository
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+// API Key Repository Implementation
+
+func (r *apiKeyRepository) Create(key *models.APIKey) error {
+	return r.db.Create(key).Error
+}
+
+func (r *apiKeyRepository) GetByID(id uuid.UUID) (*models.APIKey, error) {
+	var key models.APIKey
+	err := r.db.First(&key, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) GetByHash(keyHash string) (*models.APIKey, error) {
+	var key models.APIKey
+	err := r.db.First(&key, "key_hash = ?", keyHash).Error
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) List() ([]models.APIKey, error) {
+	var keys []models.APIKey
+	err := r.db.Order("created_at DESC").Find(&keys).Error
+	return keys, err
+}
+
+// Revoke stamps the key revoked unless it already is
+func (r *apiKeyRepository) Revoke(id uuid.UUID, at time.Time) error {
+	return r.db.Model(&models.APIKey{}).Where("id = ? AND revoked_at IS NULL", id).Update("revoked_at", at).Error
+}
+
+func (r *apiKeyRepository) RecordUse(id uuid.UUID, at time.Time, ip string) error {
+	return r.db.Model(&models.APIKey{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"last_used_at": at,
+		"last_used_ip": ip,
+	}).Error
+}
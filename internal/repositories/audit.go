@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// AuditLogFilter narrows an audit log listing; empty fields match everything
+type AuditLogFilter struct {
+	Actor string
+	Route string
+	Since *time.Time
+	Until *time.Time
+}
+
+// AuditLogRepository interface defines audit log repository methods
+type AuditLogRepository interface {
+	Create(entry *models.AuditLogEntry) error
+	List(filter AuditLogFilter, limit, offset int) ([]models.AuditLogEntry, int64, error)
+}
+
+// auditLogRepository implements AuditLogRepository
+type auditLogRepository struct {
+	db *gorm.DB
+}
+
+// Audit Log Repository Implementation
+
+func (r *auditLogRepository) Create(entry *models.AuditLogEntry) error {
+	return r.db.Create(entry).Error
+}
+
+// List returns matching entries, newest first
+func (r *auditLogRepository) List(filter AuditLogFilter, limit, offset int) ([]models.AuditLogEntry, int64, error) {
+	var entries []models.AuditLogEntry
+	var total int64
+
+	if err := r.db.Model(&models.AuditLogEntry{}).Scopes(filter.scope).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := r.db.Scopes(filter.scope).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&entries).Error
+	return entries, total, err
+}
+
+// scope adds the filter's conditions to a query
+func (f AuditLogFilter) scope(db *gorm.DB) *gorm.DB {
+	if f.Actor != "" {
+		db = db.Where("actor = ?", f.Actor)
+	}
+	if f.Route != "" {
+		db = db.Where("route = ?", f.Route)
+	}
+	if f.Since != nil {
+		db = db.Where("created_at >= ?", *f.Since)
+	}
+	if f.Until != nil {
+		db = db.Where("created_at < ?", *f.Until)
+	}
+	return db
+}
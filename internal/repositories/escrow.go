@@ -0,0 +1,45 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EscrowRepository interface defines escrow repository methods
+type EscrowRepository interface {
+	GetExpired(now time.Time, limit int) ([]models.Transaction, error)
+	CreateEvent(event *models.EscrowEvent) error
+	ListEvents(transactionID uuid.UUID) ([]models.EscrowEvent, error)
+}
+
+// escrowRepository implements EscrowRepository
+type escrowRepository struct {
+	db *gorm.DB
+}
+
+// Escrow Repository Implementation
+
+// GetExpired returns pending escrows whose timeout has passed, oldest first.
+// Disputed escrows never expire.
+func (r *escrowRepository) GetExpired(now time.Time, limit int) ([]models.Transaction, error) {
+	var transactions []models.Transaction
+	err := r.db.Where("type = ? AND status = ? AND expires_at <= ?", models.TransactionTypeEscrow, models.TransactionStatusPending, now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&transactions).Error
+	return transactions, err
+}
+
+func (r *escrowRepository) CreateEvent(event *models.EscrowEvent) error {
+	return r.db.Create(event).Error
+}
+
+func (r *escrowRepository) ListEvents(transactionID uuid.UUID) ([]models.EscrowEvent, error) {
+	var events []models.EscrowEvent
+	err := r.db.Where("transaction_id = ?", transactionID).Order("created_at ASC").Find(&events).Error
+	return events, err
+}
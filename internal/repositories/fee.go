@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"github.com/Code-Linx/wallet-service/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// FeeRuleRepository interface defines fee rule repository methods
+type FeeRuleRepository interface {
+	Create(rule *models.FeeRule) error
+	GetByID(id uuid.UUID) (*models.FeeRule, error)
+	List() ([]models.FeeRule, error)
+	ListMatching(transactionType models.TransactionType, currency, tier string) ([]models.FeeRule, error)
+	Delete(id uuid.UUID) error
+}
+
+// feeRuleRepository implements FeeRuleRepository
+type feeRuleRepository struct {
+	db *gorm.DB
+}
+
+// Fee Rule Repository Implementation
+
+func (r *feeRuleRepository) Create(rule *models.FeeRule) error {
+	return r.db.Create(rule).Error
+}
+
+func (r *feeRuleRepository) GetByID(id uuid.UUID) (*models.FeeRule, error) {
+	var rule models.FeeRule
+	err := r.db.First(&rule, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *feeRuleRepository) List() ([]models.FeeRule, error) {
+	var rules []models.FeeRule
+	err := r.db.Order("transaction_type ASC, created_at ASC").Find(&rules).Error
+	return rules, err
+}
+
+func (r *feeRuleRepository) ListMatching(transactionType models.TransactionType, currency, tier string) ([]models.FeeRule, error) {
+	var rules []models.FeeRule
+	err := r.db.Where("transaction_type = ?", transactionType).
+		Where("currency = ? OR currency = ''", currency).
+		Where("tier = ? OR tier = ''", tier).
+		Order("created_at DESC").
+		Find(&rules).Error
+	return rules, err
+}
+
+func (r *feeRuleRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.FeeRule{}, "id = ?", id).Error
+}
@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"github.com/Code-Linx/wallet-service/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FXRepository interface defines FX quote and conversion repository methods
+type FXRepository interface {
+	CreateQuote(quote *models.FXQuote) error
+	GetQuoteByID(id uuid.UUID) (*models.FXQuote, error)
+	GetQuoteByIDForUpdate(id uuid.UUID) (*models.FXQuote, error)
+	UpdateQuote(quote *models.FXQuote) error
+	CreateConversion(conversion *models.FXConversion) error
+}
+
+// fxRepository implements FXRepository
+type fxRepository struct {
+	db *gorm.DB
+}
+
+// FX Repository Implementation
+
+func (r *fxRepository) CreateQuote(quote *models.FXQuote) error {
+	return r.db.Create(quote).Error
+}
+
+func (r *fxRepository) GetQuoteByID(id uuid.UUID) (*models.FXQuote, error) {
+	var quote models.FXQuote
+	err := r.db.First(&quote, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &quote, nil
+}
+
+func (r *fxRepository) GetQuoteByIDForUpdate(id uuid.UUID) (*models.FXQuote, error) {
+	var quote models.FXQuote
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&quote, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &quote, nil
+}
+
+func (r *fxRepository) UpdateQuote(quote *models.FXQuote) error {
+	return r.db.Save(quote).Error
+}
+
+func (r *fxRepository) CreateConversion(conversion *models.FXConversion) error {
+	return r.db.Create(conversion).Error
+}
@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// HoldRepository interface defines hold repository methods
+type HoldRepository interface {
+	Create(hold *models.Hold) error
+	GetByID(id uuid.UUID) (*models.Hold, error)
+	GetByIDForUpdate(id uuid.UUID) (*models.Hold, error)
+	GetByReference(reference string) (*models.Hold, error)
+	GetExpired(now time.Time, limit int) ([]models.Hold, error)
+	Update(hold *models.Hold) error
+}
+
+// holdRepository implements HoldRepository
+type holdRepository struct {
+	db *gorm.DB
+}
+
+// Hold Repository Implementation
+
+func (r *holdRepository) Create(hold *models.Hold) error {
+	return r.db.Create(hold).Error
+}
+
+func (r *holdRepository) GetByID(id uuid.UUID) (*models.Hold, error) {
+	var hold models.Hold
+	err := r.db.Preload("CaptureTransaction").First(&hold, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &hold, nil
+}
+
+func (r *holdRepository) GetByIDForUpdate(id uuid.UUID) (*models.Hold, error) {
+	var hold models.Hold
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&hold, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &hold, nil
+}
+
+func (r *holdRepository) GetByReference(reference string) (*models.Hold, error) {
+	var hold models.Hold
+	err := r.db.First(&hold, "reference = ?", reference).Error
+	if err != nil {
+		return nil, err
+	}
+	return &hold, nil
+}
+
+func (r *holdRepository) GetExpired(now time.Time, limit int) ([]models.Hold, error) {
+	var holds []models.Hold
+	err := r.db.Where("status = ? AND expires_at <= ?", models.HoldStatusActive, now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&holds).Error
+	return holds, err
+}
+
+func (r *holdRepository) Update(hold *models.Hold) error {
+	return r.db.Save(hold).Error
+}
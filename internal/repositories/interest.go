@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// InterestDue names a wallet and a month it has unpaid interest for
+type InterestDue struct {
+	WalletID uuid.UUID
+	Month    string
+}
+
+// InterestRepository interface defines interest repository methods
+type InterestRepository interface {
+	CreateRule(rule *models.InterestRateRule) error
+	GetRuleByID(id uuid.UUID) (*models.InterestRateRule, error)
+	ListRules() ([]models.InterestRateRule, error)
+	ListRuleHistory(product models.InterestProduct, currency, tier string) ([]models.InterestRateRule, error)
+	DeleteRule(id uuid.UUID) error
+	CreateAccrual(accrual *models.InterestAccrual) error
+	GetLastAccrual(walletID uuid.UUID) (*models.InterestAccrual, error)
+	ListAccruals(walletID uuid.UUID, limit int) ([]models.InterestAccrual, error)
+	ListDue(beforeMonth string) ([]InterestDue, error)
+	ListUnpaid(walletID uuid.UUID, month string) ([]models.InterestAccrual, error)
+	MarkPaid(walletID uuid.UUID, month string, transactionID *uuid.UUID, paidAt time.Time) error
+}
+
+// interestRepository implements InterestRepository
+type interestRepository struct {
+	db *gorm.DB
+}
+
+// Interest Repository Implementation
+
+func (r *interestRepository) CreateRule(rule *models.InterestRateRule) error {
+	return r.db.Create(rule).Error
+}
+
+func (r *interestRepository) GetRuleByID(id uuid.UUID) (*models.InterestRateRule, error) {
+	var rule models.InterestRateRule
+	err := r.db.First(&rule, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *interestRepository) ListRules() ([]models.InterestRateRule, error) {
+	var rules []models.InterestRateRule
+	err := r.db.Order("product ASC, created_at ASC").Find(&rules).Error
+	return rules, err
+}
+
+// ListRuleHistory returns every rule that matches, deleted ones included,
+// newest first
+func (r *interestRepository) ListRuleHistory(product models.InterestProduct, currency, tier string) ([]models.InterestRateRule, error) {
+	var rules []models.InterestRateRule
+	err := r.db.Unscoped().Where("product = ? OR product = ''", product).
+		Where("currency = ? OR currency = ''", currency).
+		Where("tier = ? OR tier = ''", tier).
+		Order("created_at DESC").
+		Find(&rules).Error
+	return rules, err
+}
+
+func (r *interestRepository) DeleteRule(id uuid.UUID) error {
+	return r.db.Delete(&models.InterestRateRule{}, "id = ?", id).Error
+}
+
+func (r *interestRepository) CreateAccrual(accrual *models.InterestAccrual) error {
+	return r.db.Create(accrual).Error
+}
+
+// GetLastAccrual returns the wallet's most recent day of accrual
+func (r *interestRepository) GetLastAccrual(walletID uuid.UUID) (*models.InterestAccrual, error) {
+	var accrual models.InterestAccrual
+	err := r.db.Where("wallet_id = ?", walletID).Order("date DESC").First(&accrual).Error
+	if err != nil {
+		return nil, err
+	}
+	return &accrual, nil
+}
+
+func (r *interestRepository) ListAccruals(walletID uuid.UUID, limit int) ([]models.InterestAccrual, error) {
+	var accruals []models.InterestAccrual
+	err := r.db.Where("wallet_id = ?", walletID).Order("date DESC").Limit(limit).Find(&accruals).Error
+	return accruals, err
+}
+
+// ListDue returns each wallet and month before beforeMonth that still has
+// unpaid accruals, oldest month first
+func (r *interestRepository) ListDue(beforeMonth string) ([]InterestDue, error) {
+	var due []InterestDue
+	err := r.db.Model(&models.InterestAccrual{}).
+		Select("wallet_id, month").
+		Where("paid_at IS NULL AND month < ?", beforeMonth).
+		Group("wallet_id, month").
+		Order("month ASC, wallet_id ASC").
+		Scan(&due).Error
+	return due, err
+}
+
+func (r *interestRepository) ListUnpaid(walletID uuid.UUID, month string) ([]models.InterestAccrual, error) {
+	var accruals []models.InterestAccrual
+	err := r.db.Where("wallet_id = ? AND month = ? AND paid_at IS NULL", walletID, month).
+		Order("date ASC").
+		Find(&accruals).Error
+	return accruals, err
+}
+
+func (r *interestRepository) MarkPaid(walletID uuid.UUID, month string, transactionID *uuid.UUID, paidAt time.Time) error {
+	return r.db.Model(&models.InterestAccrual{}).
+		Where("wallet_id = ? AND month = ? AND paid_at IS NULL", walletID, month).
+		Updates(map[string]interface{}{
+			"transaction_id": transactionID,
+			"paid_at":        paidAt,
+		}).Error
+}
@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// WalletMovement is what one ledger entry did to a wallet: Amount is the
+// change to its balance and PromoAmount the promotional credit of the
+// transaction behind it, when that transaction belongs to the wallet's owner
+type WalletMovement struct {
+	TransactionID uuid.UUID
+	Amount        int64
+	PromoAmount   int64
+	CreatedAt     time.Time
+}
+
+// LedgerRepository interface defines ledger repository methods
+type LedgerRepository interface {
+	GetOrCreateAccount(account *models.LedgerAccount) (*models.LedgerAccount, error)
+	GetAccountByCode(code string) (*models.LedgerAccount, error)
+	CreateEntries(entries []models.LedgerEntry) error
+	GetEntriesByTransactionID(transactionID uuid.UUID) ([]models.LedgerEntry, error)
+	GetAccountBalance(accountID uuid.UUID) (int64, error)
+	GetAccountEntries(accountID uuid.UUID) ([]models.LedgerEntry, error)
+	ListWalletMovements(wallet *models.Wallet, since time.Time) ([]WalletMovement, error)
+}
+
+// ledgerRepository implements LedgerRepository
+type ledgerRepository struct {
+	db *gorm.DB
+}
+
+// Ledger Repository Implementation
+
+func (r *ledgerRepository) GetOrCreateAccount(account *models.LedgerAccount) (*models.LedgerAccount, error) {
+	// Accounts almost always exist already; reading first avoids taking an
+	// insert lock on hot system accounts
+	existing, err := r.GetAccountByCode(account.Code)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	// Concurrent callers may race to create the same account, so let the
+	// unique code decide and read back whichever row won
+	if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(account).Error; err != nil {
+		return nil, err
+	}
+	return r.GetAccountByCode(account.Code)
+}
+
+func (r *ledgerRepository) GetAccountByCode(code string) (*models.LedgerAccount, error) {
+	var account models.LedgerAccount
+	err := r.db.First(&account, "code = ?", code).Error
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (r *ledgerRepository) CreateEntries(entries []models.LedgerEntry) error {
+	return r.db.Create(&entries).Error
+}
+
+func (r *ledgerRepository) GetEntriesByTransactionID(transactionID uuid.UUID) ([]models.LedgerEntry, error) {
+	var entries []models.LedgerEntry
+	err := r.db.Where("transaction_id = ?", transactionID).
+		Preload("DebitAccount").
+		Preload("CreditAccount").
+		Order("created_at ASC").
+		Find(&entries).Error
+	return entries, err
+}
+
+func (r *ledgerRepository) GetAccountBalance(accountID uuid.UUID) (int64, error) {
+	var result struct {
+		Sum int64
+	}
+
+	// Net credit balance: wallet accounts are liabilities, so credits add to them
+	query := `
+		SELECT COALESCE(SUM(
+			CASE
+				WHEN credit_account_id = ? THEN amount
+				WHEN debit_account_id = ? THEN -amount
+				ELSE 0
+			END
+		), 0) as sum
+		FROM ledger_entries
+		WHERE credit_account_id = ? OR debit_account_id = ?
+	`
+
+	err := r.db.Raw(query, accountID, accountID, accountID, accountID).Scan(&result).Error
+	return result.Sum, err
+}
+
+// GetAccountEntries returns the entries posted to an account, newest first
+func (r *ledgerRepository) GetAccountEntries(accountID uuid.UUID) ([]models.LedgerEntry, error) {
+	var entries []models.LedgerEntry
+	err := r.db.Where("debit_account_id = ? OR credit_account_id = ?", accountID, accountID).
+		Order("created_at DESC").
+		Find(&entries).Error
+	return entries, err
+}
+
+// ListWalletMovements returns the entries posted to a wallet's ledger account
+// since the given time, oldest first. Opening balances are left out: they
+// bring an existing balance onto the ledger rather than change it.
+func (r *ledgerRepository) ListWalletMovements(wallet *models.Wallet, since time.Time) ([]WalletMovement, error) {
+	var movements []WalletMovement
+	err := r.db.Table("ledger_entries").
+		Select(`ledger_entries.transaction_id,
+			CASE WHEN ledger_accounts.id = ledger_entries.credit_account_id THEN ledger_entries.amount ELSE -ledger_entries.amount END AS amount,
+			CASE WHEN transactions.user_id = ? THEN transactions.promo_amount ELSE 0 END AS promo_amount,
+			ledger_entries.created_at`, wallet.UserID).
+		Joins("JOIN ledger_accounts ON ledger_accounts.id IN (ledger_entries.debit_account_id, ledger_entries.credit_account_id)").
+		Joins("JOIN transactions ON transactions.id = ledger_entries.transaction_id").
+		Where("ledger_accounts.wallet_id = ? AND ledger_entries.created_at >= ? AND transactions.type <> ?", wallet.ID, since, models.TransactionTypeOpeningBalance).
+		Order("ledger_entries.created_at ASC, ledger_entries.transaction_id ASC").
+		Scan(&movements).Error
+	return movements, err
+}
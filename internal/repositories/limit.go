@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// LimitUsage summarizes a user's completed transactions within a window
+type LimitUsage struct {
+	Count  int64
+	Amount int64
+	Oldest *time.Time // Creation time of the oldest transaction counted
+}
+
+// LimitRuleRepository interface defines limit rule repository methods
+type LimitRuleRepository interface {
+	Create(rule *models.LimitRule) error
+	GetByID(id uuid.UUID) (*models.LimitRule, error)
+	List() ([]models.LimitRule, error)
+	ListMatching(transactionType models.TransactionType, currency string, userID uuid.UUID, tier string) ([]models.LimitRule, error)
+	Delete(id uuid.UUID) error
+	GetUsage(userID uuid.UUID, transactionType models.TransactionType, currency string, since time.Time) (*LimitUsage, error)
+}
+
+// limitRuleRepository implements LimitRuleRepository
+type limitRuleRepository struct {
+	db *gorm.DB
+}
+
+// Limit Rule Repository Implementation
+
+func (r *limitRuleRepository) Create(rule *models.LimitRule) error {
+	return r.db.Create(rule).Error
+}
+
+func (r *limitRuleRepository) GetByID(id uuid.UUID) (*models.LimitRule, error) {
+	var rule models.LimitRule
+	err := r.db.First(&rule, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *limitRuleRepository) List() ([]models.LimitRule, error) {
+	var rules []models.LimitRule
+	err := r.db.Order("transaction_type ASC, created_at ASC").Find(&rules).Error
+	return rules, err
+}
+
+func (r *limitRuleRepository) ListMatching(transactionType models.TransactionType, currency string, userID uuid.UUID, tier string) ([]models.LimitRule, error) {
+	var rules []models.LimitRule
+	err := r.db.Where("transaction_type = ?", transactionType).
+		Where("currency = ? OR currency = ''", currency).
+		Where("user_id = ? OR (user_id IS NULL AND (tier = ? OR tier = ''))", userID, tier).
+		Order("created_at DESC").
+		Find(&rules).Error
+	return rules, err
+}
+
+func (r *limitRuleRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.LimitRule{}, "id = ?", id).Error
+}
+
+func (r *limitRuleRepository) GetUsage(userID uuid.UUID, transactionType models.TransactionType, currency string, since time.Time) (*LimitUsage, error) {
+	var usage LimitUsage
+	err := r.usage(userID, transactionType, currency, since).
+		Select("COUNT(*) as count, COALESCE(SUM(amount), 0) as amount").
+		Scan(&usage).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if usage.Count > 0 {
+		var oldest models.Transaction
+		err := r.usage(userID, transactionType, currency, since).
+			Select("created_at").
+			Order("created_at ASC").
+			First(&oldest).Error
+		if err != nil {
+			return nil, err
+		}
+		usage.Oldest = &oldest.CreatedAt
+	}
+
+	return &usage, nil
+}
+
+// usage selects the transactions that count towards a user's limits. Escrows
+// are transfers whose funds left the sender when they were opened, so they
+// count as transfers unless they were returned or never went through.
+func (r *limitRuleRepository) usage(userID uuid.UUID, transactionType models.TransactionType, currency string, since time.Time) *gorm.DB {
+	query := r.db.Model(&models.Transaction{}).
+		Where("user_id = ? AND currency = ? AND created_at >= ?", userID, currency, since)
+
+	if transactionType == models.TransactionTypeTransfer {
+		return query.Where("((type = ? AND status = ?) OR (type = ? AND status NOT IN ?))",
+			transactionType, models.TransactionStatusCompleted, models.TransactionTypeEscrow,
+			[]models.TransactionStatus{models.TransactionStatusCancelled, models.TransactionStatusFailed})
+	}
+	return query.Where("type = ? AND status = ?", transactionType, models.TransactionStatusCompleted)
+}
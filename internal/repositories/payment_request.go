@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PaymentRequestRepository interface defines payment request repository methods
+type PaymentRequestRepository interface {
+	Create(request *models.PaymentRequest) error
+	GetByID(id uuid.UUID) (*models.PaymentRequest, error)
+	GetByIDForUpdate(id uuid.UUID) (*models.PaymentRequest, error)
+	GetByReference(reference string) (*models.PaymentRequest, error)
+	ListByRequesterID(requesterID uuid.UUID, status models.PaymentRequestStatus) ([]models.PaymentRequest, error)
+	ListByPayerID(payerID uuid.UUID, status models.PaymentRequestStatus) ([]models.PaymentRequest, error)
+	Update(request *models.PaymentRequest) error
+	ExpirePending(now time.Time) (int64, error)
+}
+
+// paymentRequestRepository implements PaymentRequestRepository
+type paymentRequestRepository struct {
+	db *gorm.DB
+}
+
+// Payment Request Repository Implementation
+
+func (r *paymentRequestRepository) Create(request *models.PaymentRequest) error {
+	return r.db.Create(request).Error
+}
+
+func (r *paymentRequestRepository) GetByID(id uuid.UUID) (*models.PaymentRequest, error) {
+	var request models.PaymentRequest
+	err := r.db.Preload("Requester").Preload("Payer").Preload("Transaction").First(&request, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (r *paymentRequestRepository) GetByIDForUpdate(id uuid.UUID) (*models.PaymentRequest, error) {
+	var request models.PaymentRequest
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&request, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (r *paymentRequestRepository) GetByReference(reference string) (*models.PaymentRequest, error) {
+	var request models.PaymentRequest
+	err := r.db.First(&request, "reference = ?", reference).Error
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (r *paymentRequestRepository) ListByRequesterID(requesterID uuid.UUID, status models.PaymentRequestStatus) ([]models.PaymentRequest, error) {
+	return r.list("requester_id = ?", requesterID, status)
+}
+
+func (r *paymentRequestRepository) ListByPayerID(payerID uuid.UUID, status models.PaymentRequestStatus) ([]models.PaymentRequest, error) {
+	return r.list("payer_id = ?", payerID, status)
+}
+
+// list returns the requests matching the condition, optionally narrowed to one status
+func (r *paymentRequestRepository) list(condition string, userID uuid.UUID, status models.PaymentRequestStatus) ([]models.PaymentRequest, error) {
+	var requests []models.PaymentRequest
+	query := r.db.Where(condition, userID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Preload("Requester").Preload("Payer").Order("created_at DESC").Find(&requests).Error
+	return requests, err
+}
+
+func (r *paymentRequestRepository) Update(request *models.PaymentRequest) error {
+	return r.db.Save(request).Error
+}
+
+// ExpirePending marks pending requests past their expiry as expired
+func (r *paymentRequestRepository) ExpirePending(now time.Time) (int64, error) {
+	result := r.db.Model(&models.PaymentRequest{}).
+		Where("status = ? AND expires_at <= ?", models.PaymentRequestStatusPending, now).
+		Update("status", models.PaymentRequestStatusExpired)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"github.com/Code-Linx/wallet-service/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// payoutItemInsertBatchSize keeps item inserts under the database's placeholder limit
+const payoutItemInsertBatchSize = 500
+
+// PayoutRepository interface defines payout batch repository methods
+type PayoutRepository interface {
+	CreateBatch(batch *models.PayoutBatch) error
+	GetBatchByID(id uuid.UUID) (*models.PayoutBatch, error)
+	GetBatchByReference(reference string) (*models.PayoutBatch, error)
+	ListBatchesByUserID(userID uuid.UUID) ([]models.PayoutBatch, error)
+	GetUnfinishedBatches(limit int) ([]models.PayoutBatch, error)
+	UpdateBatch(batch *models.PayoutBatch) error
+	UpdateItem(item *models.PayoutItem) error
+}
+
+// payoutRepository implements PayoutRepository
+type payoutRepository struct {
+	db *gorm.DB
+}
+
+// Payout Repository Implementation
+
+// CreateBatch saves the batch and its items
+func (r *payoutRepository) CreateBatch(batch *models.PayoutBatch) error {
+	if err := r.db.Omit("Items").Create(batch).Error; err != nil {
+		return err
+	}
+	for i := range batch.Items {
+		batch.Items[i].BatchID = batch.ID
+	}
+	return r.db.CreateInBatches(batch.Items, payoutItemInsertBatchSize).Error
+}
+
+func (r *payoutRepository) GetBatchByID(id uuid.UUID) (*models.PayoutBatch, error) {
+	var batch models.PayoutBatch
+	err := r.preloadItems().First(&batch, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &batch, nil
+}
+
+func (r *payoutRepository) GetBatchByReference(reference string) (*models.PayoutBatch, error) {
+	var batch models.PayoutBatch
+	err := r.preloadItems().First(&batch, "reference = ?", reference).Error
+	if err != nil {
+		return nil, err
+	}
+	return &batch, nil
+}
+
+// ListBatchesByUserID returns a user's batches without their items
+func (r *payoutRepository) ListBatchesByUserID(userID uuid.UUID) ([]models.PayoutBatch, error) {
+	var batches []models.PayoutBatch
+	err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&batches).Error
+	return batches, err
+}
+
+// GetUnfinishedBatches returns batches still waiting to run, oldest first.
+// Batches left processing by a crash are included so they can be resumed.
+func (r *payoutRepository) GetUnfinishedBatches(limit int) ([]models.PayoutBatch, error) {
+	var batches []models.PayoutBatch
+	err := r.db.Where("status IN ?", []models.PayoutBatchStatus{models.PayoutBatchStatusPending, models.PayoutBatchStatusProcessing}).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&batches).Error
+	return batches, err
+}
+
+func (r *payoutRepository) UpdateBatch(batch *models.PayoutBatch) error {
+	return r.db.Omit("Items").Save(batch).Error
+}
+
+func (r *payoutRepository) UpdateItem(item *models.PayoutItem) error {
+	return r.db.Save(item).Error
+}
+
+func (r *payoutRepository) preloadItems() *gorm.DB {
+	return r.db.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position ASC")
+	})
+}
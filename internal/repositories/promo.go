@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PromoRepository interface defines promotional credit repository methods
+type PromoRepository interface {
+	CreateBucket(bucket *models.PromoBucket) error
+	GetBucketByTransactionID(transactionID uuid.UUID) (*models.PromoBucket, error)
+	GetBucketForUpdate(id uuid.UUID) (*models.PromoBucket, error)
+	ListByWalletID(walletID uuid.UUID) ([]models.PromoBucket, error)
+	ListActiveForUpdate(walletID uuid.UUID) ([]models.PromoBucket, error)
+	ListSpendableForUpdate(walletID uuid.UUID, now time.Time) ([]models.PromoBucket, error)
+	GetExpired(now time.Time, limit int) ([]models.PromoBucket, error)
+	SumActive(walletID uuid.UUID) (int64, error)
+	UpdateBucket(bucket *models.PromoBucket) error
+	CreateSpend(spend *models.PromoSpend) error
+	ListRestorableSpends(transactionID uuid.UUID) ([]models.PromoSpend, error)
+	UpdateSpend(spend *models.PromoSpend) error
+}
+
+// promoRepository implements PromoRepository
+type promoRepository struct {
+	db *gorm.DB
+}
+
+// Promo Repository Implementation
+
+func (r *promoRepository) CreateBucket(bucket *models.PromoBucket) error {
+	return r.db.Create(bucket).Error
+}
+
+func (r *promoRepository) GetBucketByTransactionID(transactionID uuid.UUID) (*models.PromoBucket, error) {
+	var bucket models.PromoBucket
+	err := r.db.First(&bucket, "transaction_id = ?", transactionID).Error
+	if err != nil {
+		return nil, err
+	}
+	return &bucket, nil
+}
+
+func (r *promoRepository) GetBucketForUpdate(id uuid.UUID) (*models.PromoBucket, error) {
+	var bucket models.PromoBucket
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&bucket, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &bucket, nil
+}
+
+func (r *promoRepository) ListByWalletID(walletID uuid.UUID) ([]models.PromoBucket, error) {
+	var buckets []models.PromoBucket
+	err := r.db.Where("wallet_id = ?", walletID).Order("created_at DESC").Find(&buckets).Error
+	return buckets, err
+}
+
+func (r *promoRepository) ListActiveForUpdate(walletID uuid.UUID) ([]models.PromoBucket, error) {
+	var buckets []models.PromoBucket
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("wallet_id = ? AND status = ?", walletID, models.PromoBucketStatusActive).
+		Order("expires_at ASC, created_at ASC").
+		Find(&buckets).Error
+	return buckets, err
+}
+
+// ListSpendableForUpdate returns the wallet's unexpired buckets with credit
+// left, in the order they are spent: soonest expiry first, then oldest grant
+func (r *promoRepository) ListSpendableForUpdate(walletID uuid.UUID, now time.Time) ([]models.PromoBucket, error) {
+	var buckets []models.PromoBucket
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("wallet_id = ? AND status = ? AND remaining > 0 AND expires_at > ?", walletID, models.PromoBucketStatusActive, now).
+		Order("expires_at ASC, created_at ASC").
+		Find(&buckets).Error
+	return buckets, err
+}
+
+func (r *promoRepository) GetExpired(now time.Time, limit int) ([]models.PromoBucket, error) {
+	var buckets []models.PromoBucket
+	err := r.db.Where("status = ? AND expires_at <= ?", models.PromoBucketStatusActive, now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&buckets).Error
+	return buckets, err
+}
+
+// SumActive adds up the credit left in the wallet's active buckets
+func (r *promoRepository) SumActive(walletID uuid.UUID) (int64, error) {
+	var result struct {
+		Sum int64
+	}
+	err := r.db.Model(&models.PromoBucket{}).
+		Select("COALESCE(SUM(remaining), 0) AS sum").
+		Where("wallet_id = ? AND status = ?", walletID, models.PromoBucketStatusActive).
+		Scan(&result).Error
+	return result.Sum, err
+}
+
+func (r *promoRepository) UpdateBucket(bucket *models.PromoBucket) error {
+	return r.db.Save(bucket).Error
+}
+
+func (r *promoRepository) CreateSpend(spend *models.PromoSpend) error {
+	return r.db.Create(spend).Error
+}
+
+// ListRestorableSpends returns what a transaction took from promo buckets and
+// has not been given back yet, in the reverse of the order it was spent
+func (r *promoRepository) ListRestorableSpends(transactionID uuid.UUID) ([]models.PromoSpend, error) {
+	var spends []models.PromoSpend
+	err := r.db.Model(&models.PromoSpend{}).
+		Select("promo_spends.*").
+		Joins("JOIN promo_buckets ON promo_buckets.id = promo_spends.bucket_id").
+		Where("promo_spends.transaction_id = ? AND promo_spends.restored < promo_spends.amount", transactionID).
+		Order("promo_buckets.expires_at DESC, promo_buckets.created_at DESC").
+		Find(&spends).Error
+	return spends, err
+}
+
+func (r *promoRepository) UpdateSpend(spend *models.PromoSpend) error {
+	return r.db.Save(spend).Error
+}
@@ -1,10 +1,15 @@
 package repositories
 
 import (
+	"strings"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/currency"
 	"github.com/Code-Linx/wallet-service/internal/models"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // UserRepository interface defines user repository methods
@@ -13,24 +18,42 @@ type UserRepository interface {
 	GetByID(id uuid.UUID) (*models.User, error)
 	GetByEmail(email string) (*models.User, error)
 	GetAll() ([]models.User, error)
+	Search(query string, limit, offset int) ([]models.User, int64, error)
+	UpdateMerchant(id uuid.UUID, isMerchant bool) error
 }
 
 // WalletRepository interface defines wallet repository methods
 type WalletRepository interface {
 	Create(wallet *models.Wallet) error
-	GetByUserID(userID uuid.UUID) (*models.Wallet, error)
-	UpdateBalance(userID uuid.UUID, newBalance int64) error
+	GetByUserIDAndCurrency(userID uuid.UUID, currency string) (*models.Wallet, error)
+	GetByUserIDAndCurrencyForUpdate(userID uuid.UUID, currency string) (*models.Wallet, error)
+	GetPocket(userID uuid.UUID, currency, name string) (*models.Wallet, error)
+	ListByUserID(userID uuid.UUID) ([]models.Wallet, error)
+	AdjustBalance(walletID uuid.UUID, delta int64) error
+	AdjustHeldBalance(walletID uuid.UUID, delta int64) error
+	AdjustPromoBalance(walletID uuid.UUID, delta int64) error
+	UpdateCreditLine(walletID uuid.UUID, creditLimit, overdraftRateBps int64) error
+	ListOverdrawn() ([]models.Wallet, error)
+	UpdateInterestCarry(walletID uuid.UUID, carry int64) error
+	UpdateStatus(walletID uuid.UUID, status models.WalletStatus, reason string) error
+	CreateStatusChange(change *models.WalletStatusChange) error
+	ListStatusChanges(walletID uuid.UUID) ([]models.WalletStatusChange, error)
 	GetByID(id uuid.UUID) (*models.Wallet, error)
+	GetByIDForUpdate(id uuid.UUID) (*models.Wallet, error)
 	GetAllWallets() ([]models.Wallet, error)
 }
 
 // TransactionRepository interface defines transaction repository methods
 type TransactionRepository interface {
 	Create(transaction *models.Transaction) error
+	GetByID(id uuid.UUID) (*models.Transaction, error)
+	GetByIDForUpdate(id uuid.UUID) (*models.Transaction, error)
 	GetByReference(reference string) (*models.Transaction, error)
 	GetByUserID(userID uuid.UUID, limit, offset int) ([]models.Transaction, int64, error)
-	GetUserTransactionSum(userID uuid.UUID) (int64, error)
-	Update(transaction *models.Transaction) error
+	GetByWalletID(walletID uuid.UUID, limit, offset int) ([]models.Transaction, int64, error)
+	GetUserTransactionSum(userID uuid.UUID, currency string) (int64, error)
+	UpdateRefundedAmount(id uuid.UUID, refundedAmount int64) error
+	Transition(transaction *models.Transaction, to models.TransactionStatus, reason string, at time.Time) error
 }
 
 // userRepository implements UserRepository
@@ -50,19 +73,49 @@ type transactionRepository struct {
 
 // Repositories holds all repository instances
 type Repositories struct {
-	User        UserRepository
-	Wallet      WalletRepository
-	Transaction TransactionRepository
-	DB          *gorm.DB
+	User              UserRepository
+	Wallet            WalletRepository
+	Transaction       TransactionRepository
+	Ledger            LedgerRepository
+	Hold              HoldRepository
+	FX                FXRepository
+	FeeRule           FeeRuleRepository
+	LimitRule         LimitRuleRepository
+	ScheduledTransfer ScheduledTransferRepository
+	Payout            PayoutRepository
+	Escrow            EscrowRepository
+	PaymentRequest    PaymentRequestRepository
+	Promo             PromoRepository
+	Voucher           VoucherRepository
+	Interest          InterestRepository
+	APIKey            APIKeyRepository
+	AuditLog          AuditLogRepository
+	Adjustment        AdjustmentRepository
+	DB                *gorm.DB
 }
 
 // NewRepositories creates new repository instances
 func NewRepositories(db *gorm.DB) *Repositories {
 	return &Repositories{
-		User:        &userRepository{db: db},
-		Wallet:      &walletRepository{db: db},
-		Transaction: &transactionRepository{db: db},
-		DB:          db,
+		User:              &userRepository{db: db},
+		Wallet:            &walletRepository{db: db},
+		Transaction:       &transactionRepository{db: db},
+		Ledger:            &ledgerRepository{db: db},
+		Hold:              &holdRepository{db: db},
+		FX:                &fxRepository{db: db},
+		FeeRule:           &feeRuleRepository{db: db},
+		LimitRule:         &limitRuleRepository{db: db},
+		ScheduledTransfer: &scheduledTransferRepository{db: db},
+		Payout:            &payoutRepository{db: db},
+		Escrow:            &escrowRepository{db: db},
+		PaymentRequest:    &paymentRequestRepository{db: db},
+		Promo:             &promoRepository{db: db},
+		Voucher:           &voucherRepository{db: db},
+		Interest:          &interestRepository{db: db},
+		APIKey:            &apiKeyRepository{db: db},
+		AuditLog:          &auditLogRepository{db: db},
+		Adjustment:        &adjustmentRepository{db: db},
+		DB:                db,
 	}
 }
 
@@ -74,7 +127,7 @@ func (r *userRepository) Create(user *models.User) error {
 
 func (r *userRepository) GetByID(id uuid.UUID) (*models.User, error) {
 	var user models.User
-	err := r.db.Preload("Wallet").First(&user, "id = ?", id).Error
+	err := r.preloadWallets().First(&user, "id = ?", id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -83,7 +136,7 @@ func (r *userRepository) GetByID(id uuid.UUID) (*models.User, error) {
 
 func (r *userRepository) GetByEmail(email string) (*models.User, error) {
 	var user models.User
-	err := r.db.Preload("Wallet").First(&user, "email = ?", email).Error
+	err := r.preloadWallets().First(&user, "email = ?", email).Error
 	if err != nil {
 		return nil, err
 	}
@@ -92,27 +145,134 @@ func (r *userRepository) GetByEmail(email string) (*models.User, error) {
 
 func (r *userRepository) GetAll() ([]models.User, error) {
 	var users []models.User
-	err := r.db.Preload("Wallet").Find(&users).Error
+	err := r.preloadWallets().Find(&users).Error
 	return users, err
 }
 
+// Search finds users whose ID matches the query exactly or whose name or
+// email contains it
+func (r *userRepository) Search(query string, limit, offset int) ([]models.User, int64, error) {
+	var users []models.User
+	var total int64
+
+	pattern := "%" + escapeLike(query) + "%"
+	match := r.db.Where("id = ? OR name LIKE ? ESCAPE '!' OR email LIKE ? ESCAPE '!'", query, pattern, pattern)
+
+	if err := r.db.Model(&models.User{}).Where(match).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := r.preloadWallets().Where(match).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&users).Error
+	return users, total, err
+}
+
+// escapeLike makes the LIKE wildcards in s match themselves, for use with
+// ESCAPE '!', which reads the same in MySQL and SQLite
+func escapeLike(s string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(s)
+}
+
+func (r *userRepository) UpdateMerchant(id uuid.UUID, isMerchant bool) error {
+	return r.db.Model(&models.User{}).Where("id = ?", id).Update("is_merchant", isMerchant).Error
+}
+
+// preloadWallets loads the primary default-currency wallet as Wallet alongside all of the user's wallets
+func (r *userRepository) preloadWallets() *gorm.DB {
+	return r.db.Preload("Wallet", "currency = ? AND is_primary = ?", currency.Default, true).Preload("Wallets", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at ASC")
+	})
+}
+
 // Wallet Repository Implementation
 
 func (r *walletRepository) Create(wallet *models.Wallet) error {
 	return r.db.Create(wallet).Error
 }
 
-func (r *walletRepository) GetByUserID(userID uuid.UUID) (*models.Wallet, error) {
+// GetByUserIDAndCurrency returns the user's primary wallet in a currency
+func (r *walletRepository) GetByUserIDAndCurrency(userID uuid.UUID, currency string) (*models.Wallet, error) {
+	var wallet models.Wallet
+	err := r.db.First(&wallet, "user_id = ? AND currency = ? AND is_primary = ?", userID, currency, true).Error
+	if err != nil {
+		return nil, err
+	}
+	return &wallet, nil
+}
+
+func (r *walletRepository) GetByUserIDAndCurrencyForUpdate(userID uuid.UUID, currency string) (*models.Wallet, error) {
 	var wallet models.Wallet
-	err := r.db.First(&wallet, "user_id = ?", userID).Error
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&wallet, "user_id = ? AND currency = ? AND is_primary = ?", userID, currency, true).Error
 	if err != nil {
 		return nil, err
 	}
 	return &wallet, nil
 }
 
-func (r *walletRepository) UpdateBalance(userID uuid.UUID, newBalance int64) error {
-	return r.db.Model(&models.Wallet{}).Where("user_id = ?", userID).Update("balance", newBalance).Error
+func (r *walletRepository) GetPocket(userID uuid.UUID, currency, name string) (*models.Wallet, error) {
+	var wallet models.Wallet
+	err := r.db.First(&wallet, "user_id = ? AND currency = ? AND name = ? AND is_primary = ?", userID, currency, name, false).Error
+	if err != nil {
+		return nil, err
+	}
+	return &wallet, nil
+}
+
+func (r *walletRepository) ListByUserID(userID uuid.UUID) ([]models.Wallet, error) {
+	var wallets []models.Wallet
+	err := r.db.Where("user_id = ?", userID).Order("created_at ASC").Find(&wallets).Error
+	return wallets, err
+}
+
+func (r *walletRepository) AdjustBalance(walletID uuid.UUID, delta int64) error {
+	return r.db.Model(&models.Wallet{}).Where("id = ?", walletID).Update("balance", gorm.Expr("balance + ?", delta)).Error
+}
+
+func (r *walletRepository) AdjustHeldBalance(walletID uuid.UUID, delta int64) error {
+	return r.db.Model(&models.Wallet{}).Where("id = ?", walletID).Update("held_balance", gorm.Expr("held_balance + ?", delta)).Error
+}
+
+func (r *walletRepository) AdjustPromoBalance(walletID uuid.UUID, delta int64) error {
+	return r.db.Model(&models.Wallet{}).Where("id = ?", walletID).Update("promo_balance", gorm.Expr("promo_balance + ?", delta)).Error
+}
+
+func (r *walletRepository) UpdateCreditLine(walletID uuid.UUID, creditLimit, overdraftRateBps int64) error {
+	return r.db.Model(&models.Wallet{}).Where("id = ?", walletID).Updates(map[string]interface{}{
+		"credit_limit":       creditLimit,
+		"overdraft_rate_bps": overdraftRateBps,
+	}).Error
+}
+
+// ListOverdrawn returns the wallets whose cash balance is below zero and
+// that pay interest on it
+func (r *walletRepository) ListOverdrawn() ([]models.Wallet, error) {
+	var wallets []models.Wallet
+	err := r.db.Where("balance < promo_balance AND overdraft_rate_bps > 0").Order("id ASC").Find(&wallets).Error
+	return wallets, err
+}
+
+func (r *walletRepository) UpdateInterestCarry(walletID uuid.UUID, carry int64) error {
+	return r.db.Model(&models.Wallet{}).Where("id = ?", walletID).Update("interest_carry", carry).Error
+}
+
+func (r *walletRepository) UpdateStatus(walletID uuid.UUID, status models.WalletStatus, reason string) error {
+	return r.db.Model(&models.Wallet{}).Where("id = ?", walletID).Updates(map[string]interface{}{
+		"status":        status,
+		"status_reason": reason,
+	}).Error
+}
+
+func (r *walletRepository) CreateStatusChange(change *models.WalletStatusChange) error {
+	return r.db.Create(change).Error
+}
+
+func (r *walletRepository) ListStatusChanges(walletID uuid.UUID) ([]models.WalletStatusChange, error) {
+	var changes []models.WalletStatusChange
+	err := r.db.Where("wallet_id = ?", walletID).Order("created_at ASC").Find(&changes).Error
+	return changes, err
 }
 
 func (r *walletRepository) GetByID(id uuid.UUID) (*models.Wallet, error) {
@@ -124,6 +284,15 @@ func (r *walletRepository) GetByID(id uuid.UUID) (*models.Wallet, error) {
 	return &wallet, nil
 }
 
+func (r *walletRepository) GetByIDForUpdate(id uuid.UUID) (*models.Wallet, error) {
+	var wallet models.Wallet
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&wallet, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &wallet, nil
+}
+
 func (r *walletRepository) GetAllWallets() ([]models.Wallet, error) {
 	var wallets []models.Wallet
 	err := r.db.Preload("User").Find(&wallets).Error
@@ -136,9 +305,27 @@ func (r *transactionRepository) Create(transaction *models.Transaction) error {
 	return r.db.Create(transaction).Error
 }
 
+func (r *transactionRepository) GetByID(id uuid.UUID) (*models.Transaction, error) {
+	var transaction models.Transaction
+	err := r.db.Preload("Legs", "type = ?", models.TransactionTypeTransfer).First(&transaction, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &transaction, nil
+}
+
+func (r *transactionRepository) GetByIDForUpdate(id uuid.UUID) (*models.Transaction, error) {
+	var transaction models.Transaction
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&transaction, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &transaction, nil
+}
+
 func (r *transactionRepository) GetByReference(reference string) (*models.Transaction, error) {
 	var transaction models.Transaction
-	err := r.db.First(&transaction, "reference = ?", reference).Error
+	err := r.db.Preload("Conversion").First(&transaction, "reference = ?", reference).Error
 	if err != nil {
 		return nil, err
 	}
@@ -149,16 +336,56 @@ func (r *transactionRepository) GetByUserID(userID uuid.UUID, limit, offset int)
 	var transactions []models.Transaction
 	var total int64
 
+	// Recipients of a split payment also see the parent, so every
+	// participant sees the whole split
+	splitsReceived := r.db.Model(&models.Transaction{}).
+		Select("parent_transaction_id").
+		Where("type = ? AND to_user_id = ? AND parent_transaction_id IS NOT NULL", models.TransactionTypeTransfer, userID)
+	participant := r.db.Where("user_id = ? OR from_user_id = ? OR to_user_id = ? OR id IN (?)", userID, userID, userID, splitsReceived)
+
+	// Get total count
+	if err := r.db.Model(&models.Transaction{}).Where(participant).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	err := r.db.Where(participant).
+		Preload("User").
+		Preload("FromUser").
+		Preload("ToUser").
+		Preload("Entries").
+		Preload("Conversion").
+		Preload("Legs", "type = ?", models.TransactionTypeTransfer).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&transactions).Error
+
+	return transactions, total, err
+}
+
+// GetByWalletID returns the transactions that posted to a wallet's ledger account
+func (r *transactionRepository) GetByWalletID(walletID uuid.UUID, limit, offset int) ([]models.Transaction, int64, error) {
+	var transactions []models.Transaction
+	var total int64
+
+	posted := r.db.Model(&models.LedgerEntry{}).
+		Select("ledger_entries.transaction_id").
+		Joins("JOIN ledger_accounts ON ledger_accounts.id IN (ledger_entries.debit_account_id, ledger_entries.credit_account_id)").
+		Where("ledger_accounts.wallet_id = ?", walletID)
+
 	// Get total count
-	if err := r.db.Model(&models.Transaction{}).Where("user_id = ? OR from_user_id = ? OR to_user_id = ?", userID, userID, userID).Count(&total).Error; err != nil {
+	if err := r.db.Model(&models.Transaction{}).Where("id IN (?)", posted).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Get paginated results
-	err := r.db.Where("user_id = ? OR from_user_id = ? OR to_user_id = ?", userID, userID, userID).
+	err := r.db.Where("id IN (?)", posted).
 		Preload("User").
 		Preload("FromUser").
 		Preload("ToUser").
+		Preload("Entries").
+		Preload("Conversion").
 		Order("created_at DESC").
 		Limit(limit).
 		Offset(offset).
@@ -167,33 +394,93 @@ func (r *transactionRepository) GetByUserID(userID uuid.UUID, limit, offset int)
 	return transactions, total, err
 }
 
-func (r *transactionRepository) GetUserTransactionSum(userID uuid.UUID) (int64, error) {
+func (r *transactionRepository) GetUserTransactionSum(userID uuid.UUID, currency string) (int64, error) {
 	var result struct {
 		Sum int64
 	}
 
-	// Calculate sum: credits minus debits for the user
+	// Calculate sum: credits minus debits for the user. Conversions leave
+	// the source currency as the transaction amount and arrive in the target
+	// currency as the converted amount. Escrows leave the sender as soon as
+	// they are opened but only reach the recipient once released.
+	// Promotional credit counts towards the balance until it expires, and
+	// interest payouts count like any other credit.
+	// Adjustments name the user as the recipient of a credit or the sender
+	// of a debit. Opening balances only carry existing wallets onto the
+	// ledger, so they are not counted.
 	query := `
-		SELECT COALESCE(SUM(
-			CASE 
-				WHEN type = 'credit' THEN amount
-				WHEN type = 'debit' AND user_id = ? THEN -amount
-				WHEN type = 'transfer' AND from_user_id = ? THEN -amount
-				WHEN type = 'transfer' AND to_user_id = ? THEN amount
-				ELSE 0
-			END
-		), 0) as sum
-		FROM transactions 
-		WHERE (user_id = ? OR from_user_id = ? OR to_user_id = ?) 
-		AND status = 'completed'
+		SELECT (
+			SELECT COALESCE(SUM(
+				CASE 
+					WHEN type = 'credit' THEN amount
+					WHEN type = 'promo_credit' THEN amount
+					WHEN type = 'promo_expiry' THEN -amount
+					WHEN type = 'debit' AND user_id = ? THEN -amount
+					WHEN type = 'transfer' AND from_user_id = ? THEN -amount
+					WHEN type = 'transfer' AND to_user_id = ? THEN amount
+					WHEN type = 'refund' AND from_user_id = ? THEN -amount
+					WHEN type = 'refund' AND to_user_id = ? THEN amount
+					WHEN type = 'conversion' AND user_id = ? THEN -amount
+					WHEN type = 'fee' AND user_id = ? THEN -amount
+					WHEN type = 'interest_charge' AND user_id = ? THEN -amount
+					WHEN type = 'interest_payout' THEN amount
+					WHEN type = 'escrow' AND from_user_id = ? THEN -amount
+					WHEN type = 'escrow' AND to_user_id = ? AND status = 'completed' THEN amount
+					WHEN type = 'adjustment' AND from_user_id = ? THEN -amount
+					WHEN type = 'adjustment' AND to_user_id = ? THEN amount
+					ELSE 0
+				END
+			), 0)
+			FROM transactions 
+			WHERE (user_id = ? OR from_user_id = ? OR to_user_id = ?) 
+			AND currency = ?
+			AND (status = 'completed' OR (type = 'escrow' AND status IN ('pending', 'disputed')))
+		) + (
+			SELECT COALESCE(SUM(c.target_amount), 0)
+			FROM fx_conversions c
+			JOIN transactions t ON t.id = c.transaction_id
+			WHERE t.user_id = ?
+			AND c.target_currency = ?
+			AND t.status = 'completed'
+		) as sum
 	`
 
-	err := r.db.Raw(query, userID, userID, userID, userID, userID, userID).Scan(&result).Error
+	err := r.db.Raw(query, userID, userID, userID, userID, userID, userID, userID, userID, userID, userID, userID, userID, userID, userID, userID, currency, userID, currency).Scan(&result).Error
 	return result.Sum, err
 }
 
-func (r *transactionRepository) Update(transaction *models.Transaction) error {
-	return r.db.Save(transaction).Error
+func (r *transactionRepository) UpdateRefundedAmount(id uuid.UUID, refundedAmount int64) error {
+	return r.db.Model(&models.Transaction{}).Where("id = ?", id).Update("refunded_amount", refundedAmount).Error
+}
+
+// Transition moves a transaction to a new status. The update only applies
+// while the stored status still matches, so a concurrent change makes it
+// fail with ErrInvalidTransition instead of overwriting the other writer.
+func (r *transactionRepository) Transition(transaction *models.Transaction, to models.TransactionStatus, reason string, at time.Time) error {
+	next := *transaction
+	if err := next.Transition(to, reason, at); err != nil {
+		return err
+	}
+
+	result := r.db.Model(&models.Transaction{}).
+		Where("id = ? AND status = ?", transaction.ID, transaction.Status).
+		Updates(map[string]interface{}{
+			"status":         next.Status,
+			"failure_reason": next.FailureReason,
+			"completed_at":   next.CompletedAt,
+			"failed_at":      next.FailedAt,
+			"disputed_at":    next.DisputedAt,
+			"cancelled_at":   next.CancelledAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return models.ErrInvalidTransition
+	}
+
+	*transaction = next
+	return nil
 }
 
 // BeginTransaction starts a new database transaction
@@ -204,9 +491,24 @@ func (repos *Repositories) BeginTransaction() *gorm.DB {
 // WithTransaction creates repositories with transaction context
 func (repos *Repositories) WithTransaction(tx *gorm.DB) *Repositories {
 	return &Repositories{
-		User:        &userRepository{db: tx},
-		Wallet:      &walletRepository{db: tx},
-		Transaction: &transactionRepository{db: tx},
-		DB:          tx,
+		User:              &userRepository{db: tx},
+		Wallet:            &walletRepository{db: tx},
+		Transaction:       &transactionRepository{db: tx},
+		Ledger:            &ledgerRepository{db: tx},
+		Hold:              &holdRepository{db: tx},
+		FX:                &fxRepository{db: tx},
+		FeeRule:           &feeRuleRepository{db: tx},
+		LimitRule:         &limitRuleRepository{db: tx},
+		ScheduledTransfer: &scheduledTransferRepository{db: tx},
+		Payout:            &payoutRepository{db: tx},
+		Escrow:            &escrowRepository{db: tx},
+		PaymentRequest:    &paymentRequestRepository{db: tx},
+		Promo:             &promoRepository{db: tx},
+		Voucher:           &voucherRepository{db: tx},
+		Interest:          &interestRepository{db: tx},
+		APIKey:            &apiKeyRepository{db: tx},
+		AuditLog:          &auditLogRepository{db: tx},
+		Adjustment:        &adjustmentRepository{db: tx},
+		DB:                tx,
 	}
 }
@@ -0,0 +1,70 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ScheduledTransferRepository interface defines scheduled transfer repository methods
+type ScheduledTransferRepository interface {
+	Create(transfer *models.ScheduledTransfer) error
+	GetByID(id uuid.UUID) (*models.ScheduledTransfer, error)
+	ListByUserID(userID uuid.UUID) ([]models.ScheduledTransfer, error)
+	GetDue(now time.Time, limit int) ([]models.ScheduledTransfer, error)
+	Update(transfer *models.ScheduledTransfer) error
+	CreateRun(run *models.ScheduledTransferRun) error
+	ListRuns(scheduledTransferID uuid.UUID) ([]models.ScheduledTransferRun, error)
+}
+
+// scheduledTransferRepository implements ScheduledTransferRepository
+type scheduledTransferRepository struct {
+	db *gorm.DB
+}
+
+// Scheduled Transfer Repository Implementation
+
+func (r *scheduledTransferRepository) Create(transfer *models.ScheduledTransfer) error {
+	return r.db.Create(transfer).Error
+}
+
+func (r *scheduledTransferRepository) GetByID(id uuid.UUID) (*models.ScheduledTransfer, error) {
+	var transfer models.ScheduledTransfer
+	err := r.db.First(&transfer, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+func (r *scheduledTransferRepository) ListByUserID(userID uuid.UUID) ([]models.ScheduledTransfer, error) {
+	var transfers []models.ScheduledTransfer
+	err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&transfers).Error
+	return transfers, err
+}
+
+func (r *scheduledTransferRepository) GetDue(now time.Time, limit int) ([]models.ScheduledTransfer, error) {
+	var transfers []models.ScheduledTransfer
+	err := r.db.Where("status = ? AND next_run_at <= ?", models.ScheduledTransferStatusActive, now).
+		Order("next_run_at ASC").
+		Limit(limit).
+		Find(&transfers).Error
+	return transfers, err
+}
+
+func (r *scheduledTransferRepository) Update(transfer *models.ScheduledTransfer) error {
+	return r.db.Save(transfer).Error
+}
+
+func (r *scheduledTransferRepository) CreateRun(run *models.ScheduledTransferRun) error {
+	return r.db.Create(run).Error
+}
+
+func (r *scheduledTransferRepository) ListRuns(scheduledTransferID uuid.UUID) ([]models.ScheduledTransferRun, error) {
+	var runs []models.ScheduledTransferRun
+	err := r.db.Where("scheduled_transfer_id = ?", scheduledTransferID).Order("created_at DESC").Find(&runs).Error
+	return runs, err
+}
@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"github.com/Code-Linx/wallet-service/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// VoucherRepository interface defines voucher repository methods
+type VoucherRepository interface {
+	CreateBatch(batch *models.VoucherBatch) error
+	GetBatchByID(id uuid.UUID) (*models.VoucherBatch, error)
+	ListBatches() ([]models.VoucherBatch, error)
+	GetByCodeHash(codeHash string) (*models.Voucher, error)
+	GetByIDForUpdate(id uuid.UUID) (*models.Voucher, error)
+	Update(voucher *models.Voucher) error
+	CreateRedemption(redemption *models.VoucherRedemption) error
+}
+
+// voucherRepository implements VoucherRepository
+type voucherRepository struct {
+	db *gorm.DB
+}
+
+// Voucher Repository Implementation
+
+// CreateBatch inserts the batch together with its vouchers
+func (r *voucherRepository) CreateBatch(batch *models.VoucherBatch) error {
+	return r.db.Create(batch).Error
+}
+
+func (r *voucherRepository) GetBatchByID(id uuid.UUID) (*models.VoucherBatch, error) {
+	var batch models.VoucherBatch
+	err := r.db.Preload("Vouchers", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at ASC, id ASC")
+	}).First(&batch, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &batch, nil
+}
+
+func (r *voucherRepository) ListBatches() ([]models.VoucherBatch, error) {
+	var batches []models.VoucherBatch
+	err := r.db.Order("created_at DESC").Find(&batches).Error
+	return batches, err
+}
+
+func (r *voucherRepository) GetByCodeHash(codeHash string) (*models.Voucher, error) {
+	var voucher models.Voucher
+	err := r.db.First(&voucher, "code_hash = ?", codeHash).Error
+	if err != nil {
+		return nil, err
+	}
+	return &voucher, nil
+}
+
+func (r *voucherRepository) GetByIDForUpdate(id uuid.UUID) (*models.Voucher, error) {
+	var voucher models.Voucher
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&voucher, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &voucher, nil
+}
+
+func (r *voucherRepository) Update(voucher *models.Voucher) error {
+	return r.db.Save(voucher).Error
+}
+
+func (r *voucherRepository) CreateRedemption(redemption *models.VoucherRedemption) error {
+	return r.db.Create(redemption).Error
+}
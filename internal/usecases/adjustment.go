@@ -0,0 +1,250 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Adjustment Use Case Implementation
+
+// Propose records a manual balance adjustment for review. Nothing is posted
+// until enough other admins approve it.
+func (uc *adjustmentUseCase) Propose(adjustment *models.BalanceAdjustment) (*models.BalanceAdjustment, error) {
+	adjustment.Reason = strings.TrimSpace(adjustment.Reason)
+	adjustment.Evidence = strings.TrimSpace(adjustment.Evidence)
+	adjustment.Reference = strings.TrimSpace(adjustment.Reference)
+	adjustment.ProposedBy = strings.TrimSpace(adjustment.ProposedBy)
+
+	if adjustment.Amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+	if adjustment.Direction != models.AdjustmentDirectionCredit && adjustment.Direction != models.AdjustmentDirectionDebit {
+		return nil, ErrInvalidAdjustment
+	}
+	if adjustment.Reason == "" || adjustment.Evidence == "" || adjustment.Reference == "" {
+		return nil, ErrInvalidAdjustment
+	}
+	if adjustment.ProposedBy == "" {
+		return nil, ErrAdjustmentActorRequired
+	}
+
+	// Check if adjustment already exists (idempotency)
+	existing, err := uc.repos.Adjustment.GetByReference(adjustment.Reference)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check existing adjustment: %w", err)
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	// Approval posts a transaction under the same reference, so it must be free
+	existingTxn, err := uc.repos.Transaction.GetByReference(adjustment.Reference)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check existing transaction: %w", err)
+	}
+	if existingTxn != nil {
+		return nil, ErrTransactionExists
+	}
+
+	wallet, err := uc.repos.Wallet.GetByID(adjustment.WalletID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWalletNotFound
+		}
+		return nil, fmt.Errorf("failed to get wallet: %w", err)
+	}
+
+	adjustment.ID = uuid.Nil
+	adjustment.UserID = wallet.UserID
+	adjustment.Currency = wallet.Currency
+	adjustment.Status = models.AdjustmentStatusPending
+	adjustment.RequiredApprovals = uc.approval.requiredApprovals(adjustment.Amount, wallet.Currency)
+	adjustment.TransactionID = nil
+	adjustment.DecidedAt = nil
+	adjustment.Decisions = nil
+	adjustment.CreatedAt = uc.clock.Now()
+
+	if err := uc.repos.Adjustment.Create(adjustment); err != nil {
+		return nil, fmt.Errorf("failed to create adjustment: %w", err)
+	}
+
+	return adjustment, nil
+}
+
+// Approve records an approval and, once the adjustment has as many as it
+// requires, posts it to the ledger in the same database transaction
+func (uc *adjustmentUseCase) Approve(id uuid.UUID, actor, comment string) (*models.BalanceAdjustment, error) {
+	return uc.decide(id, actor, comment, models.AdjustmentDecisionApprove)
+}
+
+// Reject closes the adjustment without posting anything
+func (uc *adjustmentUseCase) Reject(id uuid.UUID, actor, comment string) (*models.BalanceAdjustment, error) {
+	return uc.decide(id, actor, comment, models.AdjustmentDecisionReject)
+}
+
+func (uc *adjustmentUseCase) Get(id uuid.UUID) (*models.BalanceAdjustment, error) {
+	adjustment, err := uc.repos.Adjustment.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAdjustmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get adjustment: %w", err)
+	}
+	return adjustment, nil
+}
+
+func (uc *adjustmentUseCase) List(status models.AdjustmentStatus) ([]models.BalanceAdjustment, error) {
+	return uc.repos.Adjustment.List(status)
+}
+
+// decide records one reviewer's decision on a pending adjustment
+func (uc *adjustmentUseCase) decide(id uuid.UUID, actor, comment string, decision models.AdjustmentDecisionType) (*models.BalanceAdjustment, error) {
+	actor = strings.TrimSpace(actor)
+	if actor == "" {
+		return nil, ErrAdjustmentActorRequired
+	}
+
+	// Start transaction
+	tx := uc.repos.BeginTransaction()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	txRepos := uc.repos.WithTransaction(tx)
+
+	// Lock the adjustment so concurrent reviews see each other's decisions
+	adjustment, err := txRepos.Adjustment.GetByIDForUpdate(id)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAdjustmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get adjustment: %w", err)
+	}
+
+	if adjustment.Status != models.AdjustmentStatusPending {
+		tx.Rollback()
+		return nil, ErrAdjustmentNotPending
+	}
+	if actor == adjustment.ProposedBy {
+		tx.Rollback()
+		return nil, ErrSelfApproval
+	}
+	if adjustment.ApprovedBy(actor) {
+		tx.Rollback()
+		return nil, ErrAdjustmentReviewed
+	}
+
+	now := uc.clock.Now()
+	record := &models.AdjustmentDecision{
+		AdjustmentID: adjustment.ID,
+		Actor:        actor,
+		Decision:     decision,
+		Comment:      strings.TrimSpace(comment),
+		CreatedAt:    now,
+	}
+	if err := txRepos.Adjustment.CreateDecision(record); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to record decision: %w", err)
+	}
+	adjustment.Decisions = append(adjustment.Decisions, *record)
+
+	switch {
+	case decision == models.AdjustmentDecisionReject:
+		adjustment.Status = models.AdjustmentStatusRejected
+		adjustment.DecidedAt = &now
+	case adjustment.Approvals() >= adjustment.RequiredApprovals:
+		transaction, err := postAdjustment(txRepos, adjustment.WalletID, adjustment.Direction, adjustment.Amount, adjustment.Reason, adjustment.Reference)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		adjustment.Status = models.AdjustmentStatusApproved
+		adjustment.TransactionID = &transaction.ID
+		adjustment.DecidedAt = &now
+	}
+
+	if err := txRepos.Adjustment.Update(adjustment); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to update adjustment: %w", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return adjustment, nil
+}
+
+// postAdjustment locks the wallet and posts an adjustment to it inside the
+// caller's database transaction; the caller rolls back on error
+func postAdjustment(txRepos *repositories.Repositories, walletID uuid.UUID, direction models.AdjustmentDirection, amount int64, reason, reference string) (*models.Transaction, error) {
+	wallet, err := txRepos.Wallet.GetByIDForUpdate(walletID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWalletNotFound
+		}
+		return nil, fmt.Errorf("failed to get wallet: %w", err)
+	}
+
+	if direction == models.AdjustmentDirectionDebit {
+		if err := checkCanDebit(wallet); err != nil {
+			return nil, err
+		}
+		if wallet.Spendable() < amount {
+			return nil, ErrInsufficientFunds
+		}
+	} else if err := checkCanCredit(wallet); err != nil {
+		return nil, err
+	}
+
+	transaction := &models.Transaction{
+		UserID:      wallet.UserID,
+		Type:        models.TransactionTypeAdjustment,
+		Amount:      amount,
+		Currency:    wallet.Currency,
+		Description: fmt.Sprintf("Manual %s: %s", direction, reason),
+		Status:      models.TransactionStatusCompleted,
+		Reference:   reference,
+	}
+	if direction == models.AdjustmentDirectionDebit {
+		transaction.FromUserID = &wallet.UserID
+	} else {
+		transaction.ToUserID = &wallet.UserID
+	}
+
+	if err := txRepos.Transaction.Create(transaction); err != nil {
+		return nil, fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	// Post the journal: credits come out of the adjustments account and
+	// debits go back into it
+	adjustments, err := systemAccount(txRepos, models.AccountCodeAdjustments, wallet.Currency)
+	if err != nil {
+		return nil, err
+	}
+	walletAcc, err := walletAccount(txRepos, wallet)
+	if err != nil {
+		return nil, err
+	}
+
+	p := posting{debit: adjustments, credit: walletAcc, amount: amount}
+	if direction == models.AdjustmentDirectionDebit {
+		p = posting{debit: walletAcc, credit: adjustments, amount: amount}
+	}
+	if err := postJournal(txRepos, transaction, p); err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/repositories"
+)
+
+// Admin Use Case Implementation
+
+// SearchUsers finds users by exact ID or by part of their name or email
+func (uc *adminUseCase) SearchUsers(query string, page, pageSize int) ([]models.User, int64, error) {
+	limit, offset := pageBounds(page, pageSize)
+	return uc.repos.User.Search(strings.TrimSpace(query), limit, offset)
+}
+
+// RecordAction writes an entry to the admin audit log
+func (uc *adminUseCase) RecordAction(entry *models.AuditLogEntry) error {
+	entry.CreatedAt = uc.clock.Now()
+	if err := uc.repos.AuditLog.Create(entry); err != nil {
+		return fmt.Errorf("failed to record admin action: %w", err)
+	}
+	return nil
+}
+
+func (uc *adminUseCase) ListAuditLog(filter repositories.AuditLogFilter, page, pageSize int) ([]models.AuditLogEntry, int64, error) {
+	limit, offset := pageBounds(page, pageSize)
+	return uc.repos.AuditLog.List(filter, limit, offset)
+}
@@ -0,0 +1,188 @@
+package usecases
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/Code-Linx/wallet-service/internal/auth"
+	"github.com/Code-Linx/wallet-service/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	apiKeyPrefix       = "wsk_" // Marks a string as a wallet service key, e.g. in secret scanners
+	apiKeyRandomBytes  = 24
+	apiKeyPrefixLength = 12 // Characters of the key kept in the clear
+	maxAPIKeyNameLen   = 64
+)
+
+// API Key Use Case Implementation
+
+// Create generates a key with the given name, scopes, IP allowlist and
+// expiry. The returned key carries the secret, which is not stored.
+func (uc *apiKeyUseCase) Create(key *models.APIKey) (*models.APIKey, error) {
+	if err := uc.validateAPIKey(key); err != nil {
+		return nil, err
+	}
+
+	random := make([]byte, apiKeyRandomBytes)
+	if _, err := rand.Read(random); err != nil {
+		return nil, fmt.Errorf("failed to generate API key: %w", err)
+	}
+	secret := apiKeyPrefix + hex.EncodeToString(random)
+
+	key.Key = secret
+	key.KeyHash = hashAPIKey(secret)
+	key.Prefix = secret[:apiKeyPrefixLength]
+
+	if err := uc.repos.APIKey.Create(key); err != nil {
+		return nil, fmt.Errorf("failed to create API key: %w", err)
+	}
+	return key, nil
+}
+
+func (uc *apiKeyUseCase) List() ([]models.APIKey, error) {
+	return uc.repos.APIKey.List()
+}
+
+// Revoke stops a key working at once; revoking it again changes nothing
+func (uc *apiKeyUseCase) Revoke(id uuid.UUID) (*models.APIKey, error) {
+	if _, err := uc.get(id); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repos.APIKey.Revoke(id, uc.clock.Now()); err != nil {
+		return nil, fmt.Errorf("failed to revoke API key: %w", err)
+	}
+	return uc.get(id)
+}
+
+// Authenticate finds the key a client presented and checks it may be used
+// from clientIP, recording the use
+func (uc *apiKeyUseCase) Authenticate(rawKey, clientIP string) (*models.APIKey, error) {
+	key, err := uc.repos.APIKey.GetByHash(hashAPIKey(rawKey))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	now := uc.clock.Now()
+	if key.RevokedAt != nil {
+		return nil, ErrAPIKeyRevoked
+	}
+	if key.ExpiresAt != nil && !now.Before(*key.ExpiresAt) {
+		return nil, ErrAPIKeyExpired
+	}
+	if !ipAllowed(key.AllowedIPs, clientIP) {
+		return nil, ErrAPIKeyIPNotAllowed
+	}
+
+	if err := uc.repos.APIKey.RecordUse(key.ID, now, clientIP); err != nil {
+		return nil, fmt.Errorf("failed to record API key use: %w", err)
+	}
+	key.LastUsedAt = &now
+	key.LastUsedIP = clientIP
+	return key, nil
+}
+
+func (uc *apiKeyUseCase) get(id uuid.UUID) (*models.APIKey, error) {
+	key, err := uc.repos.APIKey.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+	return key, nil
+}
+
+// validateAPIKey checks a new key's settings and normalizes its scopes and
+// allowlist
+func (uc *apiKeyUseCase) validateAPIKey(key *models.APIKey) error {
+	key.Name = strings.TrimSpace(key.Name)
+	if key.Name == "" || len(key.Name) > maxAPIKeyNameLen {
+		return ErrInvalidAPIKey
+	}
+	if key.ExpiresAt != nil && !key.ExpiresAt.After(uc.clock.Now()) {
+		return ErrInvalidAPIKey
+	}
+
+	if len(key.Scopes) == 0 {
+		return ErrInvalidAPIKey
+	}
+	seen := make(map[string]bool)
+	scopes := make([]string, 0, len(key.Scopes))
+	for _, scope := range key.Scopes {
+		if !auth.Scopes[auth.Scope(scope)] {
+			return ErrInvalidAPIKey
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	key.Scopes = scopes
+
+	for i, entry := range key.AllowedIPs {
+		prefix, err := parseIPOrPrefix(entry)
+		if err != nil {
+			return ErrInvalidAPIKey
+		}
+		key.AllowedIPs[i] = prefix.String()
+	}
+	return nil
+}
+
+// ipAllowed reports whether ip falls in the allowlist; an empty list allows any
+func ipAllowed(allowlist []string, ip string) bool {
+	if len(allowlist) == 0 {
+		return true
+	}
+
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, entry := range allowlist {
+		prefix, err := parseIPOrPrefix(entry)
+		if err == nil && prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIPOrPrefix reads a single IP as a range holding only that address
+func parseIPOrPrefix(entry string) (netip.Prefix, error) {
+	if strings.Contains(entry, "/") {
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		return prefix.Masked(), nil
+	}
+
+	addr, err := netip.ParseAddr(entry)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// hashAPIKey returns the hex SHA-256 of a key. Keys carry 192 random bits,
+// so an unsalted hash is enough to find one without storing it.
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,380 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DefaultEscrowTimeout is how long an escrow waits for release when no
+// timeout is given
+const DefaultEscrowTimeout = 14 * 24 * time.Hour
+
+// escrowExpiryBatchSize caps how many escrows a single ExpireEscrows run returns
+const escrowExpiryBatchSize = 100
+
+// escrowSystemActor is recorded as the actor when an escrow times out
+const escrowSystemActor = "system"
+
+// Escrow Use Case Implementation
+
+// CreateEscrow takes funds from the sender like a transfer, but parks them
+// in escrow until the sender releases them or they go back after timeout
+func (uc *walletUseCase) CreateEscrow(fromUserID, toUserID uuid.UUID, amount int64, currencyCode string, timeout time.Duration, reference string) (*models.Transaction, error) {
+	if amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+	if timeout <= 0 {
+		timeout = DefaultEscrowTimeout
+	}
+
+	currencyCode, err := resolveCurrency(currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if fromUserID == toUserID {
+		return nil, ErrSameUser
+	}
+
+	// Check if transaction already exists (idempotency)
+	existingTxn, err := uc.repos.Transaction.GetByReference(reference)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check existing transaction: %w", err)
+	}
+	if existingTxn != nil {
+		return existingTxn, nil // Return existing transaction
+	}
+
+	// Check if both users exist
+	fromUser, err := uc.repos.User.GetByID(fromUserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get sender: %w", err)
+	}
+
+	attempt := &models.Transaction{
+		UserID:      fromUserID,
+		Type:        models.TransactionTypeEscrow,
+		Amount:      amount,
+		Currency:    currencyCode,
+		Description: fmt.Sprintf("Escrow for %s", toUserID),
+		Reference:   reference,
+		FromUserID:  &fromUserID,
+	}
+
+	toUser, err := uc.repos.User.GetByID(toUserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			uc.recordFailure(attempt, ErrRecipientNotFound)
+			return nil, ErrRecipientNotFound
+		}
+		return nil, fmt.Errorf("failed to get recipient: %w", err)
+	}
+	attempt.Description = fmt.Sprintf("Escrow for %s", toUser.Name)
+	attempt.ToUserID = &toUserID
+
+	// Start transaction
+	tx := uc.repos.BeginTransaction()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	txRepos := uc.repos.WithTransaction(tx)
+
+	expiresAt := uc.clock.Now().Add(timeout)
+	transaction, err := uc.transfer(txRepos, fromUser, toUser, amount, currencyCode, reference, &expiresAt, nil)
+	if err != nil {
+		tx.Rollback()
+		uc.recordFailure(attempt, err)
+		return nil, err
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	// Load transaction with user data
+	transaction.User = *fromUser
+	transaction.FromUser = fromUser
+	transaction.ToUser = toUser
+	return transaction, nil
+}
+
+// GetEscrow returns an escrow and its history to either party
+func (uc *walletUseCase) GetEscrow(userID, transactionID uuid.UUID) (*models.Transaction, error) {
+	escrow, err := uc.getEscrow(transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if *escrow.FromUserID != userID && *escrow.ToUserID != userID {
+		return nil, ErrEscrowNotFound
+	}
+
+	events, err := uc.repos.Escrow.ListEvents(escrow.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list escrow events: %w", err)
+	}
+	escrow.EscrowEvents = events
+	return escrow, nil
+}
+
+// ReleaseEscrow pays a pending escrow to the recipient. Only the sender can
+// release, once they are satisfied with the delivery.
+func (uc *walletUseCase) ReleaseEscrow(userID, transactionID uuid.UUID) (*models.Transaction, error) {
+	escrow, err := uc.getEscrow(transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if *escrow.FromUserID != userID {
+		return nil, ErrEscrowForbidden
+	}
+
+	return uc.settleEscrow(escrow, models.TransactionStatusPending, models.EscrowResolutionRelease, userID.String(), "")
+}
+
+// CancelEscrow returns a pending escrow to the sender. Only the recipient can
+// cancel; a sender who is unhappy raises a dispute instead.
+func (uc *walletUseCase) CancelEscrow(userID, transactionID uuid.UUID) (*models.Transaction, error) {
+	escrow, err := uc.getEscrow(transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if *escrow.ToUserID != userID {
+		return nil, ErrEscrowForbidden
+	}
+
+	return uc.settleEscrow(escrow, models.TransactionStatusPending, models.EscrowResolutionRefund, userID.String(), "")
+}
+
+// DisputeEscrow freezes a pending escrow until an admin resolves it. A
+// disputed escrow no longer times out.
+func (uc *walletUseCase) DisputeEscrow(userID, transactionID uuid.UUID, reason string) (*models.Transaction, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, ErrEscrowReasonRequired
+	}
+
+	escrow, err := uc.getEscrow(transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if *escrow.FromUserID != userID && *escrow.ToUserID != userID {
+		return nil, ErrEscrowForbidden
+	}
+
+	// Start transaction
+	tx := uc.repos.BeginTransaction()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	txRepos := uc.repos.WithTransaction(tx)
+
+	escrow, err = txRepos.Transaction.GetByIDForUpdate(escrow.ID)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to get escrow: %w", err)
+	}
+	if escrow.Status != models.TransactionStatusPending {
+		tx.Rollback()
+		return nil, ErrEscrowNotPending
+	}
+
+	from := escrow.Status
+	if err := txRepos.Transaction.Transition(escrow, models.TransactionStatusDisputed, "", uc.clock.Now()); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to update escrow: %w", err)
+	}
+
+	if err := txRepos.Escrow.CreateEvent(&models.EscrowEvent{
+		TransactionID: escrow.ID,
+		FromStatus:    from,
+		ToStatus:      models.TransactionStatusDisputed,
+		Actor:         userID.String(),
+		Reason:        reason,
+	}); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to record escrow event: %w", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return escrow, nil
+}
+
+// ResolveEscrow settles a disputed escrow on behalf of an admin, either
+// paying the recipient or returning the funds to the sender
+func (uc *walletUseCase) ResolveEscrow(transactionID uuid.UUID, resolution models.EscrowResolution, reason, actor string) (*models.Transaction, error) {
+	if resolution != models.EscrowResolutionRelease && resolution != models.EscrowResolutionRefund {
+		return nil, ErrInvalidResolution
+	}
+	reason, actor = strings.TrimSpace(reason), strings.TrimSpace(actor)
+	if reason == "" || actor == "" {
+		return nil, ErrEscrowReasonRequired
+	}
+
+	escrow, err := uc.getEscrow(transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.settleEscrow(escrow, models.TransactionStatusDisputed, resolution, actor, reason)
+}
+
+// ExpireEscrows returns pending escrows that were not released in time to
+// their senders
+func (uc *walletUseCase) ExpireEscrows() (int, error) {
+	escrows, err := uc.repos.Escrow.GetExpired(uc.clock.Now(), escrowExpiryBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get expired escrows: %w", err)
+	}
+
+	expired := 0
+	for i := range escrows {
+		_, err := uc.settleEscrow(&escrows[i], models.TransactionStatusPending, models.EscrowResolutionRefund, escrowSystemActor, "escrow timed out")
+		if err != nil {
+			// Released or disputed since it was listed
+			if errors.Is(err, ErrEscrowNotPending) {
+				continue
+			}
+			log.Printf("Failed to expire escrow %s: %v", escrows[i].ID, err)
+			continue
+		}
+		expired++
+	}
+
+	return expired, nil
+}
+
+// getEscrow loads an escrow transaction by ID
+func (uc *walletUseCase) getEscrow(transactionID uuid.UUID) (*models.Transaction, error) {
+	escrow, err := uc.repos.Transaction.GetByID(transactionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEscrowNotFound
+		}
+		return nil, fmt.Errorf("failed to get escrow: %w", err)
+	}
+	if escrow.Type != models.TransactionTypeEscrow {
+		return nil, ErrEscrowNotFound
+	}
+	return escrow, nil
+}
+
+// settleEscrow moves the escrowed funds to the recipient or back to the
+// sender, provided the escrow is still in the expected status
+func (uc *walletUseCase) settleEscrow(escrow *models.Transaction, expected models.TransactionStatus, resolution models.EscrowResolution, actor, reason string) (*models.Transaction, error) {
+	payeeID, status := *escrow.ToUserID, models.TransactionStatusCompleted
+	if resolution == models.EscrowResolutionRefund {
+		payeeID, status = *escrow.FromUserID, models.TransactionStatusCancelled
+	}
+
+	// Start transaction
+	tx := uc.repos.BeginTransaction()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	txRepos := uc.repos.WithTransaction(tx)
+
+	wallet, err := txRepos.Wallet.GetByUserIDAndCurrency(payeeID, escrow.Currency)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWalletNotFound
+		}
+		return nil, fmt.Errorf("failed to get wallet: %w", err)
+	}
+
+	// Lock the wallet, then the escrow so concurrent settlements serialize
+	wallets, err := lockWallets(txRepos, wallet)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to get wallet: %w", err)
+	}
+	wallet = wallets[wallet.ID]
+
+	escrow, err = txRepos.Transaction.GetByIDForUpdate(escrow.ID)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to get escrow: %w", err)
+	}
+	if escrow.Status != expected {
+		tx.Rollback()
+		if expected == models.TransactionStatusDisputed {
+			return nil, ErrEscrowNotDisputed
+		}
+		return nil, ErrEscrowNotPending
+	}
+
+	// Past its timeout an escrow can only go back to the sender
+	if resolution == models.EscrowResolutionRelease && expected == models.TransactionStatusPending && !uc.clock.Now().Before(*escrow.ExpiresAt) {
+		tx.Rollback()
+		return nil, ErrEscrowExpired
+	}
+
+	if err := checkCanCredit(wallet); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	escrowAccount, err := systemAccount(txRepos, models.AccountCodeEscrow, escrow.Currency)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	toAccount, err := walletAccount(txRepos, wallet)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := postJournal(txRepos, escrow, posting{debit: escrowAccount, credit: toAccount, amount: escrow.Amount}); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	from := escrow.Status
+	if err := txRepos.Transaction.Transition(escrow, status, "", uc.clock.Now()); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to update escrow: %w", err)
+	}
+
+	if err := txRepos.Escrow.CreateEvent(&models.EscrowEvent{
+		TransactionID: escrow.ID,
+		FromStatus:    from,
+		ToStatus:      status,
+		Actor:         actor,
+		Reason:        reason,
+	}); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to record escrow event: %w", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return escrow, nil
+}
@@ -0,0 +1,240 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// basisPointsPerUnit is the number of basis points in 100%
+const basisPointsPerUnit = 10000
+
+// feeTransactionTypes lists the transaction types fees can be charged on
+var feeTransactionTypes = map[models.TransactionType]bool{
+	models.TransactionTypeCredit:   true,
+	models.TransactionTypeDebit:    true,
+	models.TransactionTypeTransfer: true,
+}
+
+// Fee Use Case Implementation
+
+func (uc *feeUseCase) CreateRule(rule *models.FeeRule) (*models.FeeRule, error) {
+	if err := validateFeeRule(rule); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repos.FeeRule.Create(rule); err != nil {
+		return nil, fmt.Errorf("failed to create fee rule: %w", err)
+	}
+	return rule, nil
+}
+
+func (uc *feeUseCase) ListRules() ([]models.FeeRule, error) {
+	return uc.repos.FeeRule.List()
+}
+
+func (uc *feeUseCase) DeleteRule(id uuid.UUID) error {
+	if _, err := uc.repos.FeeRule.GetByID(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrFeeRuleNotFound
+		}
+		return fmt.Errorf("failed to get fee rule: %w", err)
+	}
+	return uc.repos.FeeRule.Delete(id)
+}
+
+func (uc *feeUseCase) QuoteFee(userID uuid.UUID, transactionType models.TransactionType, amount int64, currencyCode string) (*models.FeeQuote, error) {
+	if amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+	if !feeTransactionTypes[transactionType] {
+		return nil, ErrNoFeesForType
+	}
+
+	currencyCode, err := resolveCurrency(currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if user exists
+	user, err := uc.repos.User.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	fee, rule, err := feeFor(uc.repos, user, transactionType, amount, currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	quote := &models.FeeQuote{
+		TransactionType: transactionType,
+		Currency:        currencyCode,
+		Amount:          amount,
+		Fee:             fee,
+		WalletChange:    -(amount + fee),
+	}
+	if transactionType == models.TransactionTypeCredit {
+		quote.WalletChange = amount - fee
+	}
+	if rule != nil {
+		quote.RuleID = &rule.ID
+	}
+	return quote, nil
+}
+
+// feeFor returns the fee a user pays on a transaction and the rule it came from, if any
+func feeFor(repos *repositories.Repositories, user *models.User, transactionType models.TransactionType, amount int64, currencyCode string) (int64, *models.FeeRule, error) {
+	rules, err := repos.FeeRule.ListMatching(transactionType, currencyCode, user.Tier)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to get fee rules: %w", err)
+	}
+
+	rule := mostSpecificFeeRule(rules)
+	if rule == nil {
+		return 0, nil, nil
+	}
+	return calculateFee(rule, amount), rule, nil
+}
+
+// mostSpecificFeeRule picks the rule that names the tier, then the currency;
+// rules arrive newest first, so the newest wins a tie
+func mostSpecificFeeRule(rules []models.FeeRule) *models.FeeRule {
+	var best *models.FeeRule
+	bestScore := -1
+	for i := range rules {
+		score := 0
+		if rules[i].Tier != "" {
+			score += 2
+		}
+		if rules[i].Currency != "" {
+			score++
+		}
+		if score > bestScore {
+			best, bestScore = &rules[i], score
+		}
+	}
+	return best
+}
+
+// calculateFee applies a rule to an amount. Percentages round up to the
+// next minor unit; the min and max caps apply to every kind of rule.
+func calculateFee(rule *models.FeeRule, amount int64) int64 {
+	var fee int64
+	switch rule.Kind {
+	case models.FeeKindFlat:
+		fee = rule.FlatAmount
+	case models.FeeKindPercentage:
+		fee = percentOf(amount, rule.BasisPoints)
+	case models.FeeKindTiered:
+		for _, tier := range rule.Tiers {
+			if tier.UpTo == 0 || amount <= tier.UpTo {
+				fee = tier.FlatAmount + percentOf(amount, tier.BasisPoints)
+				break
+			}
+		}
+	}
+
+	if fee < rule.MinFee {
+		fee = rule.MinFee
+	}
+	if rule.MaxFee > 0 && fee > rule.MaxFee {
+		fee = rule.MaxFee
+	}
+	return fee
+}
+
+func percentOf(amount, basisPoints int64) int64 {
+	return (amount*basisPoints + basisPointsPerUnit - 1) / basisPointsPerUnit
+}
+
+// validateFeeRule checks a rule is well formed and normalizes its currency
+func validateFeeRule(rule *models.FeeRule) error {
+	if !feeTransactionTypes[rule.TransactionType] {
+		return ErrInvalidFeeRule
+	}
+	if rule.Currency != "" {
+		code, err := resolveCurrency(rule.Currency)
+		if err != nil {
+			return err
+		}
+		rule.Currency = code
+	}
+	if rule.FlatAmount < 0 || rule.MinFee < 0 || rule.MaxFee < 0 {
+		return ErrInvalidFeeRule
+	}
+	if rule.BasisPoints < 0 || rule.BasisPoints > basisPointsPerUnit {
+		return ErrInvalidFeeRule
+	}
+	if rule.MaxFee > 0 && rule.MinFee > rule.MaxFee {
+		return ErrInvalidFeeRule
+	}
+
+	switch rule.Kind {
+	case models.FeeKindFlat, models.FeeKindPercentage:
+		if len(rule.Tiers) > 0 {
+			return ErrInvalidFeeRule
+		}
+	case models.FeeKindTiered:
+		if len(rule.Tiers) == 0 {
+			return ErrInvalidFeeRule
+		}
+		// Bands must ascend, with only the last one left unbounded
+		var previous int64
+		for i, tier := range rule.Tiers {
+			if tier.FlatAmount < 0 || tier.BasisPoints < 0 || tier.BasisPoints > basisPointsPerUnit {
+				return ErrInvalidFeeRule
+			}
+			if tier.UpTo == 0 && i != len(rule.Tiers)-1 {
+				return ErrInvalidFeeRule
+			}
+			if tier.UpTo != 0 && tier.UpTo <= previous {
+				return ErrInvalidFeeRule
+			}
+			previous = tier.UpTo
+		}
+	default:
+		return ErrInvalidFeeRule
+	}
+	return nil
+}
+
+// chargeFee records the fee on a transaction as a linked fee transaction
+// debiting the payer's wallet into the fee income account. The wallet must
+// already be locked by the caller.
+func chargeFee(repos *repositories.Repositories, parent *models.Transaction, wallet *models.Wallet, fee int64) error {
+	feeTxn := &models.Transaction{
+		UserID:              wallet.UserID,
+		Type:                models.TransactionTypeFee,
+		Amount:              fee,
+		Currency:            parent.Currency,
+		Description:         fmt.Sprintf("Fee for %s", parent.Type),
+		Status:              models.TransactionStatusCompleted,
+		Reference:           parent.Reference + ":fee",
+		ParentTransactionID: &parent.ID,
+	}
+
+	if err := repos.Transaction.Create(feeTxn); err != nil {
+		return fmt.Errorf("failed to create fee transaction: %w", err)
+	}
+
+	account, err := walletAccount(repos, wallet)
+	if err != nil {
+		return err
+	}
+
+	income, err := systemAccount(repos, models.AccountCodeFeeIncome, parent.Currency)
+	if err != nil {
+		return err
+	}
+
+	return postJournal(repos, feeTxn, posting{debit: account, credit: income, amount: fee})
+}
@@ -0,0 +1,316 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/currency"
+	"github.com/Code-Linx/wallet-service/internal/fx"
+	"github.com/Code-Linx/wallet-service/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// QuoteTTL is how long a quoted rate stays valid
+const QuoteTTL = 30 * time.Second
+
+// FX Use Case Implementation
+
+func (uc *walletUseCase) QuoteConversion(userID uuid.UUID, amount int64, fromCurrency, toCurrency string) (*models.FXQuote, error) {
+	if amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	fromCurrency, toCurrency, err := resolveCurrencyPair(fromCurrency, toCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if user exists
+	if _, err := uc.repos.User.GetByID(userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	rate, err := uc.liveRate(fromCurrency, toCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	conversion, err := convert(amount, rate, fromCurrency, toCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	quote := &models.FXQuote{
+		UserID:         userID,
+		SourceCurrency: fromCurrency,
+		TargetCurrency: toCurrency,
+		SourceAmount:   amount,
+		TargetAmount:   conversion.TargetAmount,
+		Rate:           fx.FormatRate(rate),
+		ExpiresAt:      uc.clock.Now().Add(QuoteTTL),
+	}
+
+	if err := uc.repos.FX.CreateQuote(quote); err != nil {
+		return nil, fmt.Errorf("failed to create quote: %w", err)
+	}
+
+	return quote, nil
+}
+
+func (uc *walletUseCase) ConvertFunds(userID uuid.UUID, amount int64, fromCurrency, toCurrency string, quoteID *uuid.UUID, reference string) (*models.Transaction, error) {
+	if amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	fromCurrency, toCurrency, err := resolveCurrencyPair(fromCurrency, toCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if transaction already exists (idempotency)
+	existingTxn, err := uc.repos.Transaction.GetByReference(reference)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check existing transaction: %w", err)
+	}
+	if existingTxn != nil {
+		return existingTxn, nil // Return existing transaction
+	}
+
+	// Check if user exists
+	user, err := uc.repos.User.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// Without a quote the conversion takes the rate at the time of the request
+	var rate *big.Rat
+	if quoteID == nil {
+		if rate, err = uc.liveRate(fromCurrency, toCurrency); err != nil {
+			return nil, err
+		}
+	}
+
+	// Start transaction
+	tx := uc.repos.BeginTransaction()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	txRepos := uc.repos.WithTransaction(tx)
+
+	// Both wallets must exist; conversions never open wallets implicitly
+	var toLock []*models.Wallet
+	for _, code := range []string{fromCurrency, toCurrency} {
+		wallet, err := txRepos.Wallet.GetByUserIDAndCurrency(userID, code)
+		if err != nil {
+			tx.Rollback()
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrWalletNotFound
+			}
+			return nil, fmt.Errorf("failed to get wallet: %w", err)
+		}
+		toLock = append(toLock, wallet)
+	}
+
+	wallets, err := lockWallets(txRepos, toLock...)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to get wallets: %w", err)
+	}
+	fromWallet, toWallet := wallets[toLock[0].ID], wallets[toLock[1].ID]
+
+	if err := checkCanDebit(fromWallet); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := checkCanCredit(toWallet); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Lock the quote after the wallets so it can only be used once
+	var quote *models.FXQuote
+	if quoteID != nil {
+		quote, err = txRepos.FX.GetQuoteByIDForUpdate(*quoteID)
+		if err != nil {
+			tx.Rollback()
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrQuoteNotFound
+			}
+			return nil, fmt.Errorf("failed to get quote: %w", err)
+		}
+		if err := checkQuote(quote, userID, amount, fromCurrency, toCurrency, uc.clock.Now()); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if rate, err = fx.ParseRate(quote.Rate); err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to parse quoted rate: %w", err)
+		}
+	}
+
+	if err := checkLimits(txRepos, user, models.TransactionTypeConversion, amount, fromCurrency, uc.clock.Now()); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Check sufficient funds
+	if fromWallet.Available() < amount {
+		tx.Rollback()
+		return nil, ErrInsufficientFunds
+	}
+
+	conversion, err := convert(amount, rate, fromCurrency, toCurrency)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Create transaction record
+	transaction := &models.Transaction{
+		UserID:      userID,
+		Type:        models.TransactionTypeConversion,
+		Amount:      amount,
+		Currency:    fromCurrency,
+		Description: fmt.Sprintf("Conversion %s to %s", fromCurrency, toCurrency),
+		Status:      models.TransactionStatusCompleted,
+		Reference:   reference,
+	}
+
+	if err := txRepos.Transaction.Create(transaction); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	record := &models.FXConversion{
+		TransactionID:  transaction.ID,
+		QuoteID:        quoteID,
+		SourceCurrency: fromCurrency,
+		TargetCurrency: toCurrency,
+		SourceAmount:   amount,
+		TargetAmount:   conversion.TargetAmount,
+		Rate:           fx.FormatRate(rate),
+		Remainder:      conversion.RemainderString(),
+	}
+
+	if err := txRepos.FX.CreateConversion(record); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to record conversion: %w", err)
+	}
+
+	// Each leg stays within its own currency, with the FX position accounts
+	// taking the other side
+	fromAccount, err := walletAccount(txRepos, fromWallet)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	toAccount, err := walletAccount(txRepos, toWallet)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	sourcePosition, err := systemAccount(txRepos, models.AccountCodeFXPosition, fromCurrency)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	targetPosition, err := systemAccount(txRepos, models.AccountCodeFXPosition, toCurrency)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := postJournal(txRepos, transaction,
+		posting{debit: fromAccount, credit: sourcePosition, amount: amount},
+		posting{debit: targetPosition, credit: toAccount, amount: conversion.TargetAmount},
+	); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if quote != nil {
+		quote.TransactionID = &transaction.ID
+		if err := txRepos.FX.UpdateQuote(quote); err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to update quote: %w", err)
+		}
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	transaction.Conversion = record
+	return transaction, nil
+}
+
+// liveRate asks the rate provider for the current rate, rounded to the precision it is stored at
+func (uc *walletUseCase) liveRate(fromCurrency, toCurrency string) (*big.Rat, error) {
+	rate, err := uc.rates.Rate(fromCurrency, toCurrency)
+	if err != nil {
+		if errors.Is(err, fx.ErrRateUnavailable) {
+			return nil, ErrRateUnavailable
+		}
+		return nil, fmt.Errorf("failed to get exchange rate: %w", err)
+	}
+	return fx.RoundRate(rate), nil
+}
+
+// checkQuote verifies a locked quote can be used for the requested conversion
+func checkQuote(quote *models.FXQuote, userID uuid.UUID, amount int64, fromCurrency, toCurrency string, now time.Time) error {
+	if quote.UserID != userID {
+		return ErrQuoteNotFound
+	}
+	if quote.TransactionID != nil {
+		return ErrQuoteUsed
+	}
+	if now.After(quote.ExpiresAt) {
+		return ErrQuoteExpired
+	}
+	if quote.SourceAmount != amount || quote.SourceCurrency != fromCurrency || quote.TargetCurrency != toCurrency {
+		return ErrQuoteMismatch
+	}
+	return nil
+}
+
+// convert applies a rate between two currencies, refusing conversions that round to nothing
+func convert(amount int64, rate *big.Rat, fromCurrency, toCurrency string) (fx.Conversion, error) {
+	conversion, err := fx.Convert(amount, rate, currency.Exponent(fromCurrency), currency.Exponent(toCurrency))
+	if err != nil {
+		return fx.Conversion{}, fmt.Errorf("failed to convert amount: %w", err)
+	}
+	if conversion.TargetAmount <= 0 {
+		return fx.Conversion{}, ErrInvalidAmount
+	}
+	return conversion, nil
+}
+
+// resolveCurrencyPair normalizes both sides of a conversion
+func resolveCurrencyPair(fromCurrency, toCurrency string) (string, string, error) {
+	fromCurrency, err := resolveCurrency(fromCurrency)
+	if err != nil {
+		return "", "", err
+	}
+	toCurrency, err = resolveCurrency(toCurrency)
+	if err != nil {
+		return "", "", err
+	}
+	if fromCurrency == toCurrency {
+		return "", "", ErrSameCurrency
+	}
+	return fromCurrency, toCurrency, nil
+}
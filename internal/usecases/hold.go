@@ -0,0 +1,374 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DefaultHoldTTL is how long a hold stays active when no TTL is given
+const DefaultHoldTTL = 7 * 24 * time.Hour
+
+// holdExpiryBatchSize caps how many holds a single ExpireHolds run releases
+const holdExpiryBatchSize = 100
+
+// Hold Use Case Implementation
+
+func (uc *walletUseCase) PlaceHold(userID uuid.UUID, amount int64, currencyCode string, ttl time.Duration, reference string) (*models.Hold, error) {
+	if amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+	if ttl <= 0 {
+		ttl = DefaultHoldTTL
+	}
+
+	currencyCode, err := resolveCurrency(currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if hold already exists (idempotency)
+	existingHold, err := uc.repos.Hold.GetByReference(reference)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check existing hold: %w", err)
+	}
+	if existingHold != nil {
+		return existingHold, nil // Return existing hold
+	}
+
+	// Check if user exists
+	if _, err := uc.repos.User.GetByID(userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// Start transaction
+	tx := uc.repos.BeginTransaction()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	txRepos := uc.repos.WithTransaction(tx)
+
+	// Get current wallet, locked until commit
+	wallet, err := txRepos.Wallet.GetByUserIDAndCurrencyForUpdate(userID, currencyCode)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWalletNotFound
+		}
+		return nil, fmt.Errorf("failed to get wallet: %w", err)
+	}
+
+	// A hold reserves money to be debited, so it needs a wallet money can leave
+	if err := checkCanDebit(wallet); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Holds can only reserve money that is not already reserved
+	if wallet.Available() < amount {
+		tx.Rollback()
+		return nil, ErrInsufficientFunds
+	}
+
+	hold := &models.Hold{
+		WalletID:  wallet.ID,
+		UserID:    userID,
+		Amount:    amount,
+		Currency:  currencyCode,
+		Status:    models.HoldStatusActive,
+		Reference: reference,
+		ExpiresAt: uc.clock.Now().Add(ttl),
+	}
+
+	if err := txRepos.Hold.Create(hold); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to create hold: %w", err)
+	}
+
+	if err := txRepos.Wallet.AdjustHeldBalance(wallet.ID, amount); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to reserve funds: %w", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return hold, nil
+}
+
+// CaptureHold debits the wallet for amount out of an active hold and releases
+// whatever is left of the reservation. An amount of zero captures the hold in full.
+func (uc *walletUseCase) CaptureHold(userID, holdID uuid.UUID, amount int64) (*models.Transaction, error) {
+	if amount < 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	hold, err := uc.GetHold(userID, holdID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Capturing twice returns the original capture (idempotency)
+	if hold.Status == models.HoldStatusCaptured && hold.CaptureTransaction != nil {
+		return hold.CaptureTransaction, nil
+	}
+
+	// Start transaction
+	tx := uc.repos.BeginTransaction()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	txRepos := uc.repos.WithTransaction(tx)
+
+	// Lock the wallet before the hold, matching the order used everywhere else
+	wallet, err := txRepos.Wallet.GetByIDForUpdate(hold.WalletID)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to get wallet: %w", err)
+	}
+
+	hold, err = txRepos.Hold.GetByIDForUpdate(holdID)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to get hold: %w", err)
+	}
+
+	if hold.Status != models.HoldStatusActive {
+		tx.Rollback()
+		return nil, ErrHoldNotActive
+	}
+
+	// A hold past its TTL is released instead of captured
+	if !uc.clock.Now().Before(hold.ExpiresAt) {
+		if err := releaseHold(txRepos, hold, models.HoldStatusExpired); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if err := tx.Commit().Error; err != nil {
+			return nil, fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		return nil, ErrHoldExpired
+	}
+
+	if err := checkCanDebit(wallet); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if amount == 0 {
+		amount = hold.Amount
+	}
+	if amount > hold.Amount {
+		tx.Rollback()
+		return nil, ErrInvalidAmount
+	}
+
+	// Create transaction record
+	transaction := &models.Transaction{
+		UserID:      userID,
+		Type:        models.TransactionTypeDebit,
+		Amount:      amount,
+		Currency:    hold.Currency,
+		Description: "Hold capture",
+		Status:      models.TransactionStatusCompleted,
+		Reference:   hold.Reference + ":capture",
+	}
+
+	if err := txRepos.Transaction.Create(transaction); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	// Post the journal: captured money leaves through the withdrawal clearing account
+	account, err := walletAccount(txRepos, wallet)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	clearing, err := systemAccount(txRepos, models.AccountCodeWithdrawalClearing, hold.Currency)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := postJournal(txRepos, transaction, posting{debit: account, credit: clearing, amount: amount}); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Release the whole reservation, including any uncaptured remainder
+	hold.CapturedAmount = amount
+	hold.CaptureTransactionID = &transaction.ID
+	if err := releaseHold(txRepos, hold, models.HoldStatusCaptured); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return transaction, nil
+}
+
+func (uc *walletUseCase) VoidHold(userID, holdID uuid.UUID) (*models.Hold, error) {
+	hold, err := uc.GetHold(userID, holdID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start transaction
+	tx := uc.repos.BeginTransaction()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	txRepos := uc.repos.WithTransaction(tx)
+
+	// Lock the wallet before the hold, matching the order used everywhere else
+	wallet, err := txRepos.Wallet.GetByIDForUpdate(hold.WalletID)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to get wallet: %w", err)
+	}
+
+	hold, err = txRepos.Hold.GetByIDForUpdate(holdID)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to get hold: %w", err)
+	}
+
+	// Voiding twice is a no-op
+	if hold.Status == models.HoldStatusVoided {
+		tx.Rollback()
+		return hold, nil
+	}
+	if hold.Status != models.HoldStatusActive {
+		tx.Rollback()
+		return nil, ErrHoldNotActive
+	}
+
+	// Voiding frees money for the owner, so it is blocked like a credit
+	if err := checkCanCredit(wallet); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := releaseHold(txRepos, hold, models.HoldStatusVoided); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return hold, nil
+}
+
+func (uc *walletUseCase) GetHold(userID, holdID uuid.UUID) (*models.Hold, error) {
+	hold, err := uc.repos.Hold.GetByID(holdID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrHoldNotFound
+		}
+		return nil, fmt.Errorf("failed to get hold: %w", err)
+	}
+
+	// Holds are only visible to the owner of the wallet
+	if hold.UserID != userID {
+		return nil, ErrHoldNotFound
+	}
+	return hold, nil
+}
+
+// ExpireHolds releases active holds whose TTL has passed and returns how many were expired
+func (uc *walletUseCase) ExpireHolds() (int, error) {
+	holds, err := uc.repos.Hold.GetExpired(uc.clock.Now(), holdExpiryBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get expired holds: %w", err)
+	}
+
+	expired := 0
+	for _, hold := range holds {
+		if err := uc.expireHold(hold.WalletID, hold.ID); err != nil {
+			log.Printf("Failed to expire hold %s: %v", hold.ID, err)
+			continue
+		}
+		expired++
+	}
+
+	return expired, nil
+}
+
+func (uc *walletUseCase) expireHold(walletID, holdID uuid.UUID) error {
+	// Start transaction
+	tx := uc.repos.BeginTransaction()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	txRepos := uc.repos.WithTransaction(tx)
+
+	if _, err := txRepos.Wallet.GetByIDForUpdate(walletID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to get wallet: %w", err)
+	}
+
+	hold, err := txRepos.Hold.GetByIDForUpdate(holdID)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to get hold: %w", err)
+	}
+
+	// The hold may have been captured or voided since it was listed
+	if hold.Status != models.HoldStatusActive {
+		tx.Rollback()
+		return nil
+	}
+
+	if err := releaseHold(txRepos, hold, models.HoldStatusExpired); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// releaseHold moves a hold out of the active state and frees its reservation on the wallet
+func releaseHold(repos *repositories.Repositories, hold *models.Hold, status models.HoldStatus) error {
+	hold.Status = status
+	if err := repos.Hold.Update(hold); err != nil {
+		return fmt.Errorf("failed to update hold: %w", err)
+	}
+
+	if err := repos.Wallet.AdjustHeldBalance(hold.WalletID, -hold.Amount); err != nil {
+		return fmt.Errorf("failed to release reserved funds: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,475 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// interestAccrualHistory is how many days of accruals are listed for a wallet
+const interestAccrualHistory = 100
+
+// interestProducts lists the products an interest rule can name
+var interestProducts = map[models.InterestProduct]bool{
+	"":                            true,
+	models.InterestProductPrimary: true,
+	models.InterestProductPocket:  true,
+}
+
+// Interest Use Case Implementation
+
+func (uc *interestUseCase) CreateRule(rule *models.InterestRateRule) (*models.InterestRateRule, error) {
+	if !interestProducts[rule.Product] {
+		return nil, ErrInvalidInterestRule
+	}
+	if rule.AnnualRateBps < 0 || rule.AnnualRateBps > basisPointsPerUnit {
+		return nil, ErrInvalidInterestRule
+	}
+	if rule.Currency != "" {
+		code, err := resolveCurrency(rule.Currency)
+		if err != nil {
+			return nil, err
+		}
+		rule.Currency = code
+	}
+
+	if err := uc.repos.Interest.CreateRule(rule); err != nil {
+		return nil, fmt.Errorf("failed to create interest rule: %w", err)
+	}
+	return rule, nil
+}
+
+func (uc *interestUseCase) ListRules() ([]models.InterestRateRule, error) {
+	return uc.repos.Interest.ListRules()
+}
+
+func (uc *interestUseCase) DeleteRule(id uuid.UUID) error {
+	if _, err := uc.repos.Interest.GetRuleByID(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInterestRuleNotFound
+		}
+		return fmt.Errorf("failed to get interest rule: %w", err)
+	}
+	return uc.repos.Interest.DeleteRule(id)
+}
+
+// ListAccruals returns the most recent days of interest a user's wallet has
+// earned, newest first
+func (uc *interestUseCase) ListAccruals(userID, walletID uuid.UUID) ([]models.InterestAccrual, error) {
+	wallet, err := uc.repos.Wallet.GetByID(walletID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWalletNotFound
+		}
+		return nil, fmt.Errorf("failed to get wallet: %w", err)
+	}
+	if wallet.UserID != userID {
+		return nil, ErrWalletNotFound
+	}
+
+	return uc.repos.Interest.ListAccruals(walletID, interestAccrualHistory)
+}
+
+// AccrueDaily records each wallet's interest for every completed UTC day
+// since its last accrual, up to and including the previous day, and returns
+// how many days it recorded. A day earns on the lowest cash balance the
+// wallet held during it, rebuilt from the ledger, at the lowest rate in force
+// during it. Both come from history rather than from when the job happens to
+// run, so a run after a gap catches up on the same figures and money parked
+// across midnight earns nothing. Days without a rate, or in a month that has
+// already been paid, are not recorded.
+func (uc *interestUseCase) AccrueDaily() (int, error) {
+	through := uc.clock.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -1)
+
+	wallets, err := uc.repos.Wallet.GetAllWallets()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get wallets: %w", err)
+	}
+
+	accrued := 0
+	for i := range wallets {
+		wallet := &wallets[i]
+		if wallet.Status == models.WalletStatusClosed || wallet.User == nil {
+			continue
+		}
+
+		days, err := uc.accrue(wallet.ID, wallet.User.Tier, through)
+		if err != nil {
+			log.Printf("Failed to accrue interest on wallet %s: %v", wallet.ID, err)
+			continue
+		}
+		accrued += days
+	}
+
+	return accrued, nil
+}
+
+// accrue records one wallet's interest for each day after its last accrual
+// up to through, returning how many days it recorded
+func (uc *interestUseCase) accrue(walletID uuid.UUID, tier string, through time.Time) (int, error) {
+	// Start transaction
+	tx := uc.repos.BeginTransaction()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	txRepos := uc.repos.WithTransaction(tx)
+
+	// Locking the wallet keeps two runs from recording the same day and
+	// keeps its balance still while its history is read
+	wallet, err := txRepos.Wallet.GetByIDForUpdate(walletID)
+	if err != nil {
+		tx.Rollback()
+		return 0, fmt.Errorf("failed to get wallet: %w", err)
+	}
+
+	rules, err := interestRuleHistory(txRepos, wallet, tier)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	if len(rules) == 0 {
+		tx.Rollback()
+		return 0, nil
+	}
+
+	// A wallet carries on from its last recorded day; one that has never
+	// accrued starts on the later of the day it was opened and the day the
+	// first rule that could apply to it was created
+	earliest := rules[0].CreatedAt
+	for _, rule := range rules {
+		if rule.CreatedAt.Before(earliest) {
+			earliest = rule.CreatedAt
+		}
+	}
+	from := startOfDay(earliest)
+	if wallet.CreatedAt.After(earliest) {
+		from = startOfDay(wallet.CreatedAt)
+	}
+	last, err := txRepos.Interest.GetLastAccrual(walletID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		tx.Rollback()
+		return 0, fmt.Errorf("failed to get last accrual: %w", err)
+	}
+	if last != nil {
+		lastDay, err := time.Parse(time.DateOnly, last.Date)
+		if err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("failed to parse accrual date: %w", err)
+		}
+		from = lastDay.AddDate(0, 0, 1)
+	}
+	if from.After(through) {
+		tx.Rollback()
+		return 0, nil
+	}
+
+	movements, err := txRepos.Ledger.ListWalletMovements(wallet, from)
+	if err != nil {
+		tx.Rollback()
+		return 0, fmt.Errorf("failed to get ledger movements: %w", err)
+	}
+	changes := cashChanges(movements)
+
+	// Only the user's own cash earns interest, not promotional credit. Undo
+	// everything since from to find what the wallet held then.
+	cash := wallet.Balance - wallet.PromoBalance
+	for _, change := range changes {
+		cash -= change.amount
+	}
+
+	paid := make(map[string]bool)
+	days := 0
+	for day := from; !day.After(through); day = day.AddDate(0, 0, 1) {
+		end := day.AddDate(0, 0, 1)
+
+		lowest := cash
+		for len(changes) > 0 && changes[0].at.Before(end) {
+			cash += changes[0].amount
+			lowest = min(lowest, cash)
+			changes = changes[1:]
+		}
+
+		rateBps := lowestInterestRate(rules, day, end)
+		if rateBps == 0 {
+			continue
+		}
+
+		// A month that has been paid takes no more accruals; they would
+		// never be paid
+		month := day.Format("2006-01")
+		if _, ok := paid[month]; !ok {
+			payout, err := txRepos.Transaction.GetByReference(fmt.Sprintf("interest:%s:%s", walletID, month))
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				tx.Rollback()
+				return 0, fmt.Errorf("failed to check existing transaction: %w", err)
+			}
+			paid[month] = payout != nil
+		}
+		if paid[month] {
+			continue
+		}
+
+		// Nothing is earned while overdrawn
+		balance := max(0, lowest)
+		accrual := &models.InterestAccrual{
+			WalletID: walletID,
+			Date:     day.Format(time.DateOnly),
+			Month:    month,
+			Balance:  balance,
+			RateBps:  rateBps,
+			Accrued:  balance * rateBps,
+		}
+		if err := txRepos.Interest.CreateAccrual(accrual); err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("failed to create accrual: %w", err)
+		}
+		days++
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return days, nil
+}
+
+// cashChange is one transaction's effect on a wallet's cash balance
+type cashChange struct {
+	at     time.Time
+	amount int64
+}
+
+// cashChanges folds a wallet's ledger movements into one change per
+// transaction, leaving out the promotional credit each one added or spent
+func cashChanges(movements []repositories.WalletMovement) []cashChange {
+	var changes []cashChange
+	for i := 0; i < len(movements); {
+		first := movements[i]
+		var amount int64
+		for ; i < len(movements) && movements[i].TransactionID == first.TransactionID; i++ {
+			amount += movements[i].Amount
+		}
+
+		// Promotional credit moves the same way as the transaction
+		switch {
+		case amount > 0:
+			amount -= first.PromoAmount
+		case amount < 0:
+			amount += first.PromoAmount
+		}
+		changes = append(changes, cashChange{at: first.CreatedAt, amount: amount})
+	}
+	return changes
+}
+
+// lowestInterestRate returns the lowest rate in force at any moment from
+// start up to end. Rates only change when a rule is created or deleted, so
+// it is enough to look at start and at each of those moments.
+func lowestInterestRate(rules []models.InterestRateRule, start, end time.Time) int64 {
+	moments := []time.Time{start}
+	for _, rule := range rules {
+		if rule.CreatedAt.After(start) && rule.CreatedAt.Before(end) {
+			moments = append(moments, rule.CreatedAt)
+		}
+		if rule.DeletedAt.Valid && rule.DeletedAt.Time.After(start) && rule.DeletedAt.Time.Before(end) {
+			moments = append(moments, rule.DeletedAt.Time)
+		}
+	}
+
+	lowest := int64(-1)
+	for _, at := range moments {
+		var active []models.InterestRateRule
+		for _, rule := range rules {
+			if rule.ActiveAt(at) {
+				active = append(active, rule)
+			}
+		}
+
+		var rateBps int64
+		if rule := mostSpecificInterestRule(active); rule != nil {
+			rateBps = rule.AnnualRateBps
+		}
+		if lowest < 0 || rateBps < lowest {
+			lowest = rateBps
+		}
+	}
+	return lowest
+}
+
+// startOfDay returns the start of the UTC day t falls on
+func startOfDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// PayMonthly pays each wallet the interest it accrued in every month before
+// the current UTC month and returns how many payouts it made. Each payout is
+// an interest_payout with the reference interest:{wallet_id}:{YYYY-MM}; what
+// is owed short of a minor unit is carried into the next month rather than
+// rounded.
+func (uc *interestUseCase) PayMonthly() (int, error) {
+	due, err := uc.repos.Interest.ListDue(uc.clock.Now().UTC().Format("2006-01"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get interest due: %w", err)
+	}
+
+	paid := 0
+	for _, d := range due {
+		ok, err := uc.pay(d.WalletID, d.Month)
+		if err != nil {
+			log.Printf("Failed to pay %s interest on wallet %s: %v", d.Month, d.WalletID, err)
+			continue
+		}
+		if ok {
+			paid++
+		}
+	}
+
+	return paid, nil
+}
+
+// pay settles one wallet's accruals for a month, reporting whether it
+// credited anything
+func (uc *interestUseCase) pay(walletID uuid.UUID, month string) (bool, error) {
+	reference := fmt.Sprintf("interest:%s:%s", walletID, month)
+
+	// Start transaction
+	tx := uc.repos.BeginTransaction()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	txRepos := uc.repos.WithTransaction(tx)
+
+	wallet, err := txRepos.Wallet.GetByIDForUpdate(walletID)
+	if err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to get wallet: %w", err)
+	}
+
+	// Check if transaction already exists (idempotency)
+	existingTxn, err := txRepos.Transaction.GetByReference(reference)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to check existing transaction: %w", err)
+	}
+	if existingTxn != nil {
+		tx.Rollback()
+		return false, nil
+	}
+
+	accruals, err := txRepos.Interest.ListUnpaid(walletID, month)
+	if err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to get accruals: %w", err)
+	}
+
+	total := wallet.InterestCarry
+	for _, accrual := range accruals {
+		total += accrual.Accrued
+	}
+	amount, carry := total/models.InterestScale, total%models.InterestScale
+
+	// A closed wallet can take no more money, so what it was owed lapses
+	var transactionID *uuid.UUID
+	if amount > 0 && wallet.Status != models.WalletStatusClosed {
+		transaction := &models.Transaction{
+			UserID:      wallet.UserID,
+			Type:        models.TransactionTypeInterestPayout,
+			Amount:      amount,
+			Currency:    wallet.Currency,
+			Description: fmt.Sprintf("Interest for %s", month),
+			Status:      models.TransactionStatusCompleted,
+			Reference:   reference,
+		}
+
+		if err := txRepos.Transaction.Create(transaction); err != nil {
+			tx.Rollback()
+			return false, fmt.Errorf("failed to create transaction: %w", err)
+		}
+
+		// Post the journal: interest is paid out of the interest account
+		expense, err := systemAccount(txRepos, models.AccountCodeInterest, wallet.Currency)
+		if err != nil {
+			tx.Rollback()
+			return false, err
+		}
+		account, err := walletAccount(txRepos, wallet)
+		if err != nil {
+			tx.Rollback()
+			return false, err
+		}
+
+		if err := postJournal(txRepos, transaction, posting{debit: expense, credit: account, amount: amount}); err != nil {
+			tx.Rollback()
+			return false, err
+		}
+		transactionID = &transaction.ID
+	}
+
+	if err := txRepos.Interest.MarkPaid(walletID, month, transactionID, uc.clock.Now()); err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to mark accruals paid: %w", err)
+	}
+	if err := txRepos.Wallet.UpdateInterestCarry(walletID, carry); err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to update interest carry: %w", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return transactionID != nil, nil
+}
+
+// interestRuleHistory returns every rule that could have applied to a
+// wallet, including deleted ones, newest first
+func interestRuleHistory(repos *repositories.Repositories, wallet *models.Wallet, tier string) ([]models.InterestRateRule, error) {
+	product := models.InterestProductPrimary
+	if !wallet.IsPrimary {
+		product = models.InterestProductPocket
+	}
+
+	rules, err := repos.Interest.ListRuleHistory(product, wallet.Currency, tier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get interest rules: %w", err)
+	}
+	return rules, nil
+}
+
+// mostSpecificInterestRule picks the rule that names the product, then the
+// tier, then the currency; rules arrive newest first, so the newest wins a tie
+func mostSpecificInterestRule(rules []models.InterestRateRule) *models.InterestRateRule {
+	var best *models.InterestRateRule
+	bestScore := -1
+	for i := range rules {
+		score := 0
+		if rules[i].Product != "" {
+			score += 4
+		}
+		if rules[i].Tier != "" {
+			score += 2
+		}
+		if rules[i].Currency != "" {
+			score++
+		}
+		if score > bestScore {
+			best, bestScore = &rules[i], score
+		}
+	}
+	return best
+}
@@ -0,0 +1,121 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// systemAccountTypes maps each system account code to its account type
+var systemAccountTypes = map[string]models.AccountType{
+	models.AccountCodeFundingSource:      models.AccountTypeFundingSource,
+	models.AccountCodeWithdrawalClearing: models.AccountTypeWithdrawalClearing,
+	models.AccountCodeFeeIncome:          models.AccountTypeFeeIncome,
+	models.AccountCodeFXPosition:         models.AccountTypeFXPosition,
+	models.AccountCodeEscrow:             models.AccountTypeEscrow,
+	models.AccountCodePromotions:         models.AccountTypePromotions,
+	models.AccountCodeInterest:           models.AccountTypeInterest,
+	models.AccountCodeAdjustments:        models.AccountTypeAdjustments,
+	models.AccountCodeOpeningBalances:    models.AccountTypeOpeningBalances,
+}
+
+// posting describes one debit/credit pair to be written to the journal
+type posting struct {
+	debit  *models.LedgerAccount
+	credit *models.LedgerAccount
+	amount int64
+}
+
+// walletAccount returns the ledger account backing a wallet, creating it on first use
+func walletAccount(repos *repositories.Repositories, wallet *models.Wallet) (*models.LedgerAccount, error) {
+	account, err := repos.Ledger.GetOrCreateAccount(&models.LedgerAccount{
+		Code:     models.WalletAccountCode(wallet.ID),
+		Type:     models.AccountTypeUserWallet,
+		Currency: wallet.Currency,
+		WalletID: &wallet.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet ledger account: %w", err)
+	}
+	return account, nil
+}
+
+// systemAccount returns one of the well-known system accounts in the given
+// currency, creating it on first use
+func systemAccount(repos *repositories.Repositories, code, currencyCode string) (*models.LedgerAccount, error) {
+	accountType, ok := systemAccountTypes[code]
+	if !ok {
+		return nil, fmt.Errorf("unknown system account %q", code)
+	}
+
+	account, err := repos.Ledger.GetOrCreateAccount(&models.LedgerAccount{
+		Code:     fmt.Sprintf("%s:%s", code, currencyCode),
+		Type:     accountType,
+		Currency: currencyCode,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get system ledger account: %w", err)
+	}
+	return account, nil
+}
+
+// postJournal writes the ledger entries for a transaction and moves the
+// balances of any wallets involved by the same amounts
+func postJournal(repos *repositories.Repositories, transaction *models.Transaction, postings ...posting) error {
+	entries := make([]models.LedgerEntry, 0, len(postings))
+	for _, p := range postings {
+		if p.amount <= 0 {
+			return ErrInvalidAmount
+		}
+		// An entry can only move money within a single currency
+		if p.debit.Currency != p.credit.Currency {
+			return ErrCurrencyMismatch
+		}
+		entries = append(entries, models.LedgerEntry{
+			TransactionID:   transaction.ID,
+			DebitAccountID:  p.debit.ID,
+			CreditAccountID: p.credit.ID,
+			Amount:          p.amount,
+			Currency:        p.debit.Currency,
+		})
+	}
+
+	if err := repos.Ledger.CreateEntries(entries); err != nil {
+		return fmt.Errorf("failed to post ledger entries: %w", err)
+	}
+
+	// Wallet balances are a projection of the wallet accounts; debits reduce
+	// them and credits increase them
+	for _, p := range postings {
+		if p.debit.WalletID != nil {
+			if err := repos.Wallet.AdjustBalance(*p.debit.WalletID, -p.amount); err != nil {
+				return fmt.Errorf("failed to update wallet balance: %w", err)
+			}
+		}
+		if p.credit.WalletID != nil {
+			if err := repos.Wallet.AdjustBalance(*p.credit.WalletID, p.amount); err != nil {
+				return fmt.Errorf("failed to update wallet balance: %w", err)
+			}
+		}
+	}
+
+	transaction.Entries = entries
+	return nil
+}
+
+// walletLedgerBalance derives a wallet's balance from its ledger entries
+func walletLedgerBalance(repos *repositories.Repositories, walletID uuid.UUID) (int64, error) {
+	account, err := repos.Ledger.GetAccountByCode(models.WalletAccountCode(walletID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return repos.Ledger.GetAccountBalance(account.ID)
+}
@@ -0,0 +1,204 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Kinds of limit a LimitExceededError can report
+const (
+	LimitKindAmount = "amount"
+	LimitKindCount  = "count"
+)
+
+// limitPeriods lists the periods in the order they are checked
+var limitPeriods = []models.LimitPeriod{
+	models.LimitPeriodTransaction,
+	models.LimitPeriodDay,
+	models.LimitPeriodWeek,
+	models.LimitPeriodMonth,
+}
+
+// limitTransactionTypes lists the transaction types limits can be set on
+var limitTransactionTypes = map[models.TransactionType]bool{
+	models.TransactionTypeCredit:     true,
+	models.TransactionTypeDebit:      true,
+	models.TransactionTypeTransfer:   true,
+	models.TransactionTypeConversion: true,
+}
+
+// LimitExceededError reports which limit a transaction would break and when
+// it frees up. It matches ErrLimitExceeded with errors.Is.
+type LimitExceededError struct {
+	TransactionType models.TransactionType `json:"transaction_type"`
+	Period          models.LimitPeriod     `json:"period"`
+	Kind            string                 `json:"kind"`
+	Limit           int64                  `json:"limit"`
+	Used            int64                  `json:"used"`
+	ResetsAt        *time.Time             `json:"resets_at,omitempty"` // When the oldest counted transaction leaves the window
+}
+
+func (e *LimitExceededError) Error() string {
+	msg := fmt.Sprintf("%s %s limit of %d per %s exceeded", e.TransactionType, e.Kind, e.Limit, e.Period)
+	if e.ResetsAt != nil {
+		msg += fmt.Sprintf("; resets at %s", e.ResetsAt.UTC().Format(time.RFC3339))
+	}
+	return msg
+}
+
+func (e *LimitExceededError) Is(target error) bool {
+	return target == ErrLimitExceeded
+}
+
+// Limit Use Case Implementation
+
+func (uc *limitUseCase) CreateRule(rule *models.LimitRule) (*models.LimitRule, error) {
+	if err := validateLimitRule(rule); err != nil {
+		return nil, err
+	}
+
+	if rule.UserID != nil {
+		if _, err := uc.repos.User.GetByID(*rule.UserID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrUserNotFound
+			}
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+	}
+
+	if err := uc.repos.LimitRule.Create(rule); err != nil {
+		return nil, fmt.Errorf("failed to create limit rule: %w", err)
+	}
+	return rule, nil
+}
+
+func (uc *limitUseCase) ListRules() ([]models.LimitRule, error) {
+	return uc.repos.LimitRule.List()
+}
+
+func (uc *limitUseCase) DeleteRule(id uuid.UUID) error {
+	if _, err := uc.repos.LimitRule.GetByID(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrLimitRuleNotFound
+		}
+		return fmt.Errorf("failed to get limit rule: %w", err)
+	}
+	return uc.repos.LimitRule.Delete(id)
+}
+
+// checkLimits returns a *LimitExceededError if the transaction would break
+// any limit that applies to the user. Callers hold the wallet lock, so
+// concurrent transactions on the wallet see each other's usage.
+func checkLimits(repos *repositories.Repositories, user *models.User, transactionType models.TransactionType, amount int64, currencyCode string, now time.Time) error {
+	rules, err := repos.LimitRule.ListMatching(transactionType, currencyCode, user.ID, user.Tier)
+	if err != nil {
+		return fmt.Errorf("failed to get limit rules: %w", err)
+	}
+
+	selected := mostSpecificLimitRules(rules)
+	for _, period := range limitPeriods {
+		rule, ok := selected[period]
+		if !ok {
+			continue
+		}
+
+		exceeded := &LimitExceededError{
+			TransactionType: transactionType,
+			Period:          period,
+			Kind:            LimitKindAmount,
+			Limit:           rule.MaxAmount,
+		}
+
+		if period == models.LimitPeriodTransaction {
+			if rule.MaxAmount > 0 && amount > rule.MaxAmount {
+				return exceeded
+			}
+			continue
+		}
+
+		usage, err := repos.LimitRule.GetUsage(user.ID, transactionType, currencyCode, now.Add(-period.Window()))
+		if err != nil {
+			return fmt.Errorf("failed to get limit usage: %w", err)
+		}
+		if usage.Oldest != nil {
+			resetsAt := usage.Oldest.Add(period.Window())
+			exceeded.ResetsAt = &resetsAt
+		}
+
+		if rule.MaxCount > 0 && usage.Count+1 > rule.MaxCount {
+			exceeded.Kind = LimitKindCount
+			exceeded.Limit = rule.MaxCount
+			exceeded.Used = usage.Count
+			return exceeded
+		}
+		if rule.MaxAmount > 0 && usage.Amount+amount > rule.MaxAmount {
+			exceeded.Used = usage.Amount
+			return exceeded
+		}
+	}
+	return nil
+}
+
+// mostSpecificLimitRules picks one rule per period: a user's own rule beats
+// their tier's, which beats the global one, and a rule naming the currency
+// beats one that does not. Rules arrive newest first, so the newest wins a tie.
+func mostSpecificLimitRules(rules []models.LimitRule) map[models.LimitPeriod]*models.LimitRule {
+	selected := make(map[models.LimitPeriod]*models.LimitRule)
+	scores := make(map[models.LimitPeriod]int)
+	for i := range rules {
+		score := 0
+		if rules[i].UserID != nil {
+			score += 4
+		}
+		if rules[i].Tier != "" {
+			score += 2
+		}
+		if rules[i].Currency != "" {
+			score++
+		}
+		if best, ok := scores[rules[i].Period]; !ok || score > best {
+			selected[rules[i].Period] = &rules[i]
+			scores[rules[i].Period] = score
+		}
+	}
+	return selected
+}
+
+// validateLimitRule checks a rule is well formed and normalizes its currency
+func validateLimitRule(rule *models.LimitRule) error {
+	if !limitTransactionTypes[rule.TransactionType] {
+		return ErrInvalidLimitRule
+	}
+	if rule.UserID != nil && rule.Tier != "" {
+		return ErrInvalidLimitRule
+	}
+	if rule.Currency != "" {
+		code, err := resolveCurrency(rule.Currency)
+		if err != nil {
+			return err
+		}
+		rule.Currency = code
+	}
+	if rule.MaxAmount < 0 || rule.MaxCount < 0 || (rule.MaxAmount == 0 && rule.MaxCount == 0) {
+		return ErrInvalidLimitRule
+	}
+
+	switch rule.Period {
+	case models.LimitPeriodTransaction:
+		// A single transaction is always a count of one
+		if rule.MaxCount > 0 {
+			return ErrInvalidLimitRule
+		}
+	case models.LimitPeriodDay, models.LimitPeriodWeek, models.LimitPeriodMonth:
+	default:
+		return ErrInvalidLimitRule
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package usecases
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/repositories"
+
+	"github.com/google/uuid"
+)
+
+// lockWallets locks the given wallets with SELECT ... FOR UPDATE for the rest
+// of the database transaction and returns the locked rows keyed by wallet ID.
+// Locks are always taken in user ID order (then wallet ID for wallets of the
+// same user), so concurrent operations on the same wallets cannot deadlock.
+func lockWallets(repos *repositories.Repositories, wallets ...*models.Wallet) (map[uuid.UUID]*models.Wallet, error) {
+	ordered := make([]*models.Wallet, len(wallets))
+	copy(ordered, wallets)
+	sort.Slice(ordered, func(i, j int) bool {
+		if c := bytes.Compare(ordered[i].UserID[:], ordered[j].UserID[:]); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(ordered[i].ID[:], ordered[j].ID[:]) < 0
+	})
+
+	locked := make(map[uuid.UUID]*models.Wallet, len(ordered))
+	for _, wallet := range ordered {
+		if _, ok := locked[wallet.ID]; ok {
+			continue
+		}
+		lockedWallet, err := repos.Wallet.GetByIDForUpdate(wallet.ID)
+		if err != nil {
+			return nil, err
+		}
+		locked[wallet.ID] = lockedWallet
+	}
+	return locked, nil
+}
@@ -0,0 +1,98 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/clock"
+	"github.com/Code-Linx/wallet-service/internal/fx"
+)
+
+// RetryPolicy controls how the scheduler retries a failed occurrence of a scheduled transfer
+type RetryPolicy struct {
+	MaxAttempts int           // Attempts per occurrence, including the first
+	Backoff     time.Duration // Delay before the first retry, doubling after each failure
+}
+
+// DefaultRetryPolicy tries each occurrence three times, 15 and 30 minutes apart
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 3, Backoff: 15 * time.Minute}
+
+// delay returns how long to wait after the given number of failed attempts
+func (p RetryPolicy) delay(failures int) time.Duration {
+	return p.Backoff << uint(failures-1)
+}
+
+// ApprovalPolicy decides how many admins must approve a manual balance
+// adjustment. Adjustments above the threshold for their currency need two
+// approvals; the rest need one.
+type ApprovalPolicy struct {
+	DualApprovalThreshold int64            // Applies to currencies without their own threshold
+	Thresholds            map[string]int64 // Per-currency thresholds in the smallest unit, keyed by currency code
+}
+
+// DefaultApprovalPolicy asks for a second approver above 1,000.00 in any currency
+var DefaultApprovalPolicy = ApprovalPolicy{DualApprovalThreshold: 100000}
+
+// requiredApprovals returns how many approvals an adjustment needs
+func (p ApprovalPolicy) requiredApprovals(amount int64, currency string) int {
+	threshold, ok := p.Thresholds[currency]
+	if !ok {
+		threshold = p.DualApprovalThreshold
+	}
+	if amount > threshold {
+		return 2
+	}
+	return 1
+}
+
+// Option configures optional dependencies of the use cases
+type Option func(*options)
+
+// options holds the optional dependencies passed to NewUseCases
+type options struct {
+	rates    fx.RateProvider
+	clock    clock.Clock
+	retry    RetryPolicy
+	approval ApprovalPolicy
+}
+
+// defaultOptions returns options that work without any external services.
+// Conversions fail with ErrRateUnavailable until a rate provider is set.
+func defaultOptions() *options {
+	return &options{
+		rates:    fx.NewMemoryProvider(),
+		clock:    clock.Real(),
+		retry:    DefaultRetryPolicy,
+		approval: DefaultApprovalPolicy,
+	}
+}
+
+// WithRateProvider sets where exchange rates for conversions come from
+func WithRateProvider(provider fx.RateProvider) Option {
+	return func(o *options) {
+		o.rates = provider
+	}
+}
+
+// WithClock sets the clock used for expiry, limits and scheduling
+func WithClock(c clock.Clock) Option {
+	return func(o *options) {
+		o.clock = c
+	}
+}
+
+// WithRetryPolicy sets how failed scheduled transfers are retried
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *options) {
+		if policy.MaxAttempts < 1 {
+			policy.MaxAttempts = 1
+		}
+		o.retry = policy
+	}
+}
+
+// WithApprovalPolicy sets when manual balance adjustments need a second approver
+func WithApprovalPolicy(policy ApprovalPolicy) Option {
+	return func(o *options) {
+		o.approval = policy
+	}
+}
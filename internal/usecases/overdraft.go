@@ -0,0 +1,236 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// daysPerYear turns an annual rate into a daily one
+const daysPerYear = 365
+
+// Overdraft Use Case Implementation
+
+// SetCreditLine lets a primary wallet go up to creditLimit below zero,
+// charging overdraftRateBps a year on the overdrawn amount. The limit cannot
+// be cut below what the wallet already owes.
+func (uc *walletUseCase) SetCreditLine(walletID uuid.UUID, creditLimit, overdraftRateBps int64) (*models.Wallet, error) {
+	if creditLimit < 0 || overdraftRateBps < 0 {
+		return nil, ErrInvalidCreditLine
+	}
+
+	// Start transaction
+	tx := uc.repos.BeginTransaction()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	txRepos := uc.repos.WithTransaction(tx)
+
+	wallet, err := txRepos.Wallet.GetByIDForUpdate(walletID)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWalletNotFound
+		}
+		return nil, fmt.Errorf("failed to get wallet: %w", err)
+	}
+
+	// Pockets only ever hold the owner's own money
+	if !wallet.IsPrimary && creditLimit > 0 {
+		tx.Rollback()
+		return nil, ErrInvalidCreditLine
+	}
+	if wallet.Status == models.WalletStatusClosed {
+		tx.Rollback()
+		return nil, ErrWalletClosed
+	}
+	if wallet.Overdrawn() > creditLimit {
+		tx.Rollback()
+		return nil, ErrCreditLimitBelowUsage
+	}
+
+	if err := txRepos.Wallet.UpdateCreditLine(wallet.ID, creditLimit, overdraftRateBps); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to update credit line: %w", err)
+	}
+
+	wallet, err = txRepos.Wallet.GetByID(wallet.ID)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to get wallet: %w", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return wallet, nil
+}
+
+// ChargeOverdraftInterest charges each overdrawn wallet a day's interest for
+// the previous UTC day and returns how many it charged. Each charge uses the
+// reference overdraft_interest:{wallet_id}:{date}, so running it again the
+// same day charges nothing twice. Days the job did not run are not made up.
+func (uc *walletUseCase) ChargeOverdraftInterest() (int, error) {
+	day := uc.clock.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -1)
+
+	wallets, err := uc.repos.Wallet.ListOverdrawn()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get overdrawn wallets: %w", err)
+	}
+
+	charged := 0
+	for _, wallet := range wallets {
+		ok, err := uc.chargeInterest(wallet.ID, day)
+		if err != nil {
+			log.Printf("Failed to charge overdraft interest on wallet %s: %v", wallet.ID, err)
+			continue
+		}
+		if ok {
+			charged++
+		}
+	}
+
+	return charged, nil
+}
+
+// chargeInterest charges one wallet's interest for day, reporting whether
+// it charged anything
+func (uc *walletUseCase) chargeInterest(walletID uuid.UUID, day time.Time) (bool, error) {
+	reference := fmt.Sprintf("overdraft_interest:%s:%s", walletID, day.Format(time.DateOnly))
+
+	// Check if transaction already exists (idempotency)
+	existingTxn, err := uc.repos.Transaction.GetByReference(reference)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, fmt.Errorf("failed to check existing transaction: %w", err)
+	}
+	if existingTxn != nil {
+		return false, nil
+	}
+
+	// Start transaction
+	tx := uc.repos.BeginTransaction()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	txRepos := uc.repos.WithTransaction(tx)
+
+	wallet, err := txRepos.Wallet.GetByIDForUpdate(walletID)
+	if err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to get wallet: %w", err)
+	}
+
+	// The wallet may have been paid back since it was listed
+	interest := overdraftInterest(wallet.Overdrawn(), wallet.OverdraftRateBps)
+	if interest == 0 {
+		tx.Rollback()
+		return false, nil
+	}
+
+	// Interest is charged whatever the wallet's status and may take it
+	// past its credit limit
+	transaction := &models.Transaction{
+		UserID:      wallet.UserID,
+		Type:        models.TransactionTypeInterestCharge,
+		Amount:      interest,
+		Currency:    wallet.Currency,
+		Description: fmt.Sprintf("Overdraft interest for %s", day.Format(time.DateOnly)),
+		Status:      models.TransactionStatusCompleted,
+		Reference:   reference,
+	}
+
+	if err := txRepos.Transaction.Create(transaction); err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	account, err := walletAccount(txRepos, wallet)
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	income, err := systemAccount(txRepos, models.AccountCodeInterest, wallet.Currency)
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	if err := postJournal(txRepos, transaction, posting{debit: account, credit: income, amount: interest}); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return true, nil
+}
+
+// interestOverLimit returns the overdraft interest charged to a wallet since
+// it last sat within its credit limit. Interest is the one thing allowed to
+// take a wallet past its limit, so this is how far past it the wallet may
+// legitimately be.
+func interestOverLimit(repos *repositories.Repositories, wallet *models.Wallet) (int64, error) {
+	account, err := repos.Ledger.GetAccountByCode(models.WalletAccountCode(wallet.ID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	entries, err := repos.Ledger.GetAccountEntries(account.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get ledger entries: %w", err)
+	}
+
+	// Walk back from the current balance until the wallet was last within
+	// its limit, adding up the interest charged on the way
+	var interest int64
+	balance := wallet.Balance
+	for _, entry := range entries {
+		if -balance <= wallet.CreditLimit {
+			break
+		}
+
+		transaction, err := repos.Transaction.GetByID(entry.TransactionID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get transaction: %w", err)
+		}
+		if transaction.Type == models.TransactionTypeInterestCharge {
+			interest += entry.Amount
+		}
+
+		if entry.CreditAccountID == account.ID {
+			balance -= entry.Amount
+		} else {
+			balance += entry.Amount
+		}
+	}
+
+	return interest, nil
+}
+
+// overdraftInterest returns one day's interest on an overdrawn amount at an
+// annual rate in basis points, rounded half up to the minor unit
+func overdraftInterest(overdrawn, rateBps int64) int64 {
+	denominator := int64(basisPointsPerUnit * daysPerYear)
+	return (overdrawn*rateBps + denominator/2) / denominator
+}
@@ -0,0 +1,291 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultPaymentRequestTTL is how long a payment request stays open when no expiry is given
+	DefaultPaymentRequestTTL = 7 * 24 * time.Hour
+	// MaxPaymentRequestMemoLength is the longest memo accepted on a payment request
+	MaxPaymentRequestMemoLength = 255
+)
+
+// Payment Request Use Case Implementation
+
+// Create asks the payer for money on behalf of the requester. The request
+// stays open for ttl, or DefaultPaymentRequestTTL if ttl is not positive.
+func (uc *paymentRequestUseCase) Create(request *models.PaymentRequest, ttl time.Duration) (*models.PaymentRequest, error) {
+	if request.Amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+	if request.RequesterID == request.PayerID {
+		return nil, ErrSameUser
+	}
+
+	request.Memo = strings.TrimSpace(request.Memo)
+	if len(request.Memo) > MaxPaymentRequestMemoLength {
+		return nil, ErrMemoTooLong
+	}
+
+	code, err := resolveCurrency(request.Currency)
+	if err != nil {
+		return nil, err
+	}
+	request.Currency = code
+
+	// Check if request already exists (idempotency)
+	existing, err := uc.repos.PaymentRequest.GetByReference(request.Reference)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check existing payment request: %w", err)
+	}
+	if existing != nil {
+		// A reference only replays the requester's own request
+		if existing.RequesterID != request.RequesterID {
+			return nil, ErrTransactionExists
+		}
+		return uc.repos.PaymentRequest.GetByID(existing.ID)
+	}
+
+	for _, userID := range []uuid.UUID{request.RequesterID, request.PayerID} {
+		if _, err := uc.repos.User.GetByID(userID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrUserNotFound
+			}
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+	}
+
+	// The money arrives in the requester's wallet in the request currency
+	if _, err := uc.repos.Wallet.GetByUserIDAndCurrency(request.RequesterID, code); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWalletNotFound
+		}
+		return nil, fmt.Errorf("failed to get wallet: %w", err)
+	}
+
+	if ttl <= 0 {
+		ttl = DefaultPaymentRequestTTL
+	}
+
+	request.Status = models.PaymentRequestStatusPending
+	request.ExpiresAt = uc.clock.Now().Add(ttl)
+	request.RespondedAt = nil
+	request.TransactionID = nil
+
+	if err := uc.repos.PaymentRequest.Create(request); err != nil {
+		return nil, fmt.Errorf("failed to create payment request: %w", err)
+	}
+	return uc.repos.PaymentRequest.GetByID(request.ID)
+}
+
+// Get returns a payment request to either of the two users involved
+func (uc *paymentRequestUseCase) Get(userID, requestID uuid.UUID) (*models.PaymentRequest, error) {
+	request, err := uc.repos.PaymentRequest.GetByID(requestID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPaymentRequestNotFound
+		}
+		return nil, fmt.Errorf("failed to get payment request: %w", err)
+	}
+
+	// Anyone else is told the request does not exist
+	if request.RequesterID != userID && request.PayerID != userID {
+		return nil, ErrPaymentRequestNotFound
+	}
+	return request, nil
+}
+
+// ListIncoming lists the requests the user has been asked to pay, optionally
+// narrowed to one status
+func (uc *paymentRequestUseCase) ListIncoming(userID uuid.UUID, status models.PaymentRequestStatus) ([]models.PaymentRequest, error) {
+	if err := uc.checkUser(userID); err != nil {
+		return nil, err
+	}
+
+	requests, err := uc.repos.PaymentRequest.ListByPayerID(userID, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payment requests: %w", err)
+	}
+	return requests, nil
+}
+
+// ListOutgoing lists the requests the user has sent, optionally narrowed to
+// one status
+func (uc *paymentRequestUseCase) ListOutgoing(userID uuid.UUID, status models.PaymentRequestStatus) ([]models.PaymentRequest, error) {
+	if err := uc.checkUser(userID); err != nil {
+		return nil, err
+	}
+
+	requests, err := uc.repos.PaymentRequest.ListByRequesterID(userID, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payment requests: %w", err)
+	}
+	return requests, nil
+}
+
+// Accept pays a request by transferring its amount from the payer to the
+// requester. Accepting an already accepted request returns it unchanged, so
+// a repeated accept never pays twice.
+func (uc *paymentRequestUseCase) Accept(userID, requestID uuid.UUID) (*models.PaymentRequest, error) {
+	request, err := uc.Get(userID, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if request.PayerID != userID {
+		return nil, ErrPaymentRequestForbidden
+	}
+	payer, requester := request.Payer, request.Requester
+
+	// Start transaction
+	tx := uc.repos.BeginTransaction()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	txRepos := uc.repos.WithTransaction(tx)
+
+	// Lock the request before the wallets, so a second accept waits here and
+	// then finds the request answered. Nothing else holds a request lock
+	// while waiting for a wallet.
+	request, err = txRepos.PaymentRequest.GetByIDForUpdate(requestID)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to get payment request: %w", err)
+	}
+	if request.Status == models.PaymentRequestStatusAccepted {
+		tx.Rollback()
+		return uc.Get(userID, requestID)
+	}
+	if request.Status != models.PaymentRequestStatusPending {
+		tx.Rollback()
+		return nil, ErrPaymentRequestNotPending
+	}
+
+	now := uc.clock.Now()
+	if !now.Before(request.ExpiresAt) {
+		tx.Rollback()
+		return nil, ErrPaymentRequestExpired
+	}
+
+	reference := fmt.Sprintf("payment_request:%s", request.ID)
+	transaction, err := uc.wallet.transfer(txRepos, payer, requester, request.Amount, request.Currency, reference, nil, nil)
+	if err != nil {
+		tx.Rollback()
+		uc.wallet.recordFailure(&models.Transaction{
+			UserID:      payer.ID,
+			Type:        models.TransactionTypeTransfer,
+			Amount:      request.Amount,
+			Currency:    request.Currency,
+			Description: fmt.Sprintf("Transfer to %s", requester.Name),
+			Reference:   reference,
+			FromUserID:  &payer.ID,
+			ToUserID:    &requester.ID,
+		}, err)
+		return nil, err
+	}
+
+	request.Status = models.PaymentRequestStatusAccepted
+	request.RespondedAt = &now
+	request.TransactionID = &transaction.ID
+
+	if err := txRepos.PaymentRequest.Update(request); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to update payment request: %w", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return uc.Get(userID, requestID)
+}
+
+// Decline turns a request down without moving any money. Declining twice is
+// a no-op.
+func (uc *paymentRequestUseCase) Decline(userID, requestID uuid.UUID) (*models.PaymentRequest, error) {
+	request, err := uc.Get(userID, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if request.PayerID != userID {
+		return nil, ErrPaymentRequestForbidden
+	}
+
+	// Start transaction
+	tx := uc.repos.BeginTransaction()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	txRepos := uc.repos.WithTransaction(tx)
+
+	request, err = txRepos.PaymentRequest.GetByIDForUpdate(requestID)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to get payment request: %w", err)
+	}
+	if request.Status == models.PaymentRequestStatusDeclined {
+		tx.Rollback()
+		return uc.Get(userID, requestID)
+	}
+	if request.Status != models.PaymentRequestStatusPending {
+		tx.Rollback()
+		return nil, ErrPaymentRequestNotPending
+	}
+
+	now := uc.clock.Now()
+	if !now.Before(request.ExpiresAt) {
+		tx.Rollback()
+		return nil, ErrPaymentRequestExpired
+	}
+
+	request.Status = models.PaymentRequestStatusDeclined
+	request.RespondedAt = &now
+
+	if err := txRepos.PaymentRequest.Update(request); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to update payment request: %w", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return uc.Get(userID, requestID)
+}
+
+// ExpirePending marks unanswered requests past their expiry as expired and
+// returns how many it expired
+func (uc *paymentRequestUseCase) ExpirePending() (int, error) {
+	expired, err := uc.repos.PaymentRequest.ExpirePending(uc.clock.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire payment requests: %w", err)
+	}
+	return int(expired), nil
+}
+
+// checkUser makes sure the user exists
+func (uc *paymentRequestUseCase) checkUser(userID uuid.UUID) error {
+	if _, err := uc.repos.User.GetByID(userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,505 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MaxPayoutItems caps the number of items in one payout batch
+const MaxPayoutItems = 5000
+
+// payoutBatchSize caps how many batches one processing run picks up
+const payoutBatchSize = 10
+
+// PayoutItemError describes why one item of a batch failed validation
+type PayoutItemError struct {
+	Position  int    `json:"position"`
+	Reference string `json:"reference,omitempty"`
+	Error     string `json:"error"`
+}
+
+// PayoutValidationError lists every invalid item of a rejected batch. It
+// matches ErrInvalidPayoutBatch with errors.Is.
+type PayoutValidationError struct {
+	Items []PayoutItemError `json:"items"`
+}
+
+func (e *PayoutValidationError) Error() string {
+	return fmt.Sprintf("%d invalid payout items; first at position %d: %s", len(e.Items), e.Items[0].Position, e.Items[0].Error)
+}
+
+func (e *PayoutValidationError) Is(target error) bool {
+	return target == ErrInvalidPayoutBatch
+}
+
+// Payout Use Case Implementation
+
+// CreateBatch validates a whole batch and queues it for processing. Nothing
+// is saved unless every item is valid.
+func (uc *payoutUseCase) CreateBatch(batch *models.PayoutBatch) (*models.PayoutBatch, error) {
+	if batch.Reference == "" || len(batch.Items) == 0 || len(batch.Items) > MaxPayoutItems {
+		return nil, ErrInvalidPayoutBatch
+	}
+	if batch.Mode != models.PayoutModeAllOrNothing && batch.Mode != models.PayoutModeBestEffort {
+		return nil, ErrInvalidPayoutBatch
+	}
+
+	code, err := resolveCurrency(batch.Currency)
+	if err != nil {
+		return nil, err
+	}
+	batch.Currency = code
+
+	// Check if batch already exists (idempotency)
+	existingBatch, err := uc.repos.Payout.GetBatchByReference(batch.Reference)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check existing batch: %w", err)
+	}
+	if existingBatch != nil {
+		if existingBatch.UserID != batch.UserID {
+			return nil, ErrTransactionExists
+		}
+		return existingBatch, nil
+	}
+
+	// Check if sender exists
+	if _, err := uc.repos.User.GetByID(batch.UserID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	senderWallet, err := uc.repos.Wallet.GetByUserIDAndCurrency(batch.UserID, batch.Currency)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWalletNotFound
+		}
+		return nil, fmt.Errorf("failed to get wallet: %w", err)
+	}
+
+	total, err := uc.validateItems(batch)
+	if err != nil {
+		return nil, err
+	}
+
+	// An all-or-nothing batch the sender cannot cover would only be rolled
+	// back; fees are checked when it runs
+	if batch.Mode == models.PayoutModeAllOrNothing && senderWallet.Spendable() < total {
+		return nil, ErrInsufficientFunds
+	}
+
+	batch.Status = models.PayoutBatchStatusPending
+	batch.ItemCount = len(batch.Items)
+	batch.TotalAmount = total
+	batch.SucceededCount = 0
+	batch.FailedCount = 0
+	for i := range batch.Items {
+		batch.Items[i].Position = i + 1
+		batch.Items[i].Status = models.PayoutItemStatusPending
+	}
+
+	// Start transaction
+	tx := uc.repos.BeginTransaction()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	txRepos := uc.repos.WithTransaction(tx)
+
+	if err := txRepos.Payout.CreateBatch(batch); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to create payout batch: %w", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return batch, nil
+}
+
+func (uc *payoutUseCase) GetBatch(userID, batchID uuid.UUID) (*models.PayoutBatch, error) {
+	batch, err := uc.repos.Payout.GetBatchByID(batchID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPayoutBatchNotFound
+		}
+		return nil, fmt.Errorf("failed to get payout batch: %w", err)
+	}
+
+	// Batches are only visible to the sender
+	if batch.UserID != userID {
+		return nil, ErrPayoutBatchNotFound
+	}
+	return batch, nil
+}
+
+func (uc *payoutUseCase) ListBatches(userID uuid.UUID) ([]models.PayoutBatch, error) {
+	if _, err := uc.repos.User.GetByID(userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	batches, err := uc.repos.Payout.ListBatchesByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payout batches: %w", err)
+	}
+	return batches, nil
+}
+
+// ProcessPendingBatches runs every queued batch and returns how many were processed
+func (uc *payoutUseCase) ProcessPendingBatches() (int, error) {
+	batches, err := uc.repos.Payout.GetUnfinishedBatches(payoutBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get pending payout batches: %w", err)
+	}
+
+	processed := 0
+	for _, batch := range batches {
+		if _, err := uc.ProcessBatch(batch.ID); err != nil {
+			log.Printf("Failed to process payout batch %s: %v", batch.ID, err)
+			continue
+		}
+		processed++
+	}
+
+	return processed, nil
+}
+
+// ProcessBatch executes the items of a queued batch and records the outcome
+// of each. Running a finished batch again has no effect.
+func (uc *payoutUseCase) ProcessBatch(batchID uuid.UUID) (*models.PayoutBatch, error) {
+	batch, err := uc.repos.Payout.GetBatchByID(batchID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPayoutBatchNotFound
+		}
+		return nil, fmt.Errorf("failed to get payout batch: %w", err)
+	}
+	if batch.Status != models.PayoutBatchStatusPending && batch.Status != models.PayoutBatchStatusProcessing {
+		return batch, nil
+	}
+
+	batch.Status = models.PayoutBatchStatusProcessing
+	if err := uc.repos.Payout.UpdateBatch(batch); err != nil {
+		return nil, fmt.Errorf("failed to update payout batch: %w", err)
+	}
+
+	switch batch.Mode {
+	case models.PayoutModeAllOrNothing:
+		err = uc.runAllOrNothing(batch)
+	default:
+		err = uc.runBestEffort(batch)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return batch, nil
+}
+
+// validateItems checks every item of a batch, collecting all problems rather
+// than stopping at the first, and returns the batch total
+func (uc *payoutUseCase) validateItems(batch *models.PayoutBatch) (int64, error) {
+	var problems []PayoutItemError
+	invalid := func(position int, item models.PayoutItem, reason string) {
+		problems = append(problems, PayoutItemError{Position: position, Reference: item.Reference, Error: reason})
+	}
+
+	references := make(map[string]int, len(batch.Items))
+	recipients := make(map[uuid.UUID]string)
+	var total int64
+
+	for i, item := range batch.Items {
+		position := i + 1
+		if item.Amount <= 0 {
+			invalid(position, item, ErrInvalidAmount.Error())
+			continue
+		}
+		if item.Reference == "" {
+			invalid(position, item, "reference is required")
+			continue
+		}
+		if first, ok := references[item.Reference]; ok {
+			invalid(position, item, fmt.Sprintf("duplicate reference; also used at position %d", first))
+			continue
+		}
+		references[item.Reference] = position
+
+		// A reference already in use must be this item's own transfer,
+		// or the item would be marked sent without moving any money
+		existingTxn, err := uc.repos.Transaction.GetByReference(item.Reference)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, fmt.Errorf("failed to check existing transaction: %w", err)
+		}
+		if existingTxn != nil && !isItemTransfer(existingTxn, batch.UserID, item, batch.Currency) {
+			invalid(position, item, ErrTransactionExists.Error())
+			continue
+		}
+
+		if item.ToUserID == batch.UserID {
+			invalid(position, item, ErrSameUser.Error())
+			continue
+		}
+
+		// Look each recipient up once however many items pay them
+		reason, checked := recipients[item.ToUserID]
+		if !checked {
+			var err error
+			if reason, err = uc.checkRecipient(item.ToUserID, batch.Currency); err != nil {
+				return 0, err
+			}
+			recipients[item.ToUserID] = reason
+		}
+		if reason != "" {
+			invalid(position, item, reason)
+			continue
+		}
+
+		total += item.Amount
+	}
+
+	if len(problems) > 0 {
+		return 0, &PayoutValidationError{Items: problems}
+	}
+	return total, nil
+}
+
+// checkRecipient returns why a user cannot receive a payout in the currency,
+// or an empty string if they can
+func (uc *payoutUseCase) checkRecipient(userID uuid.UUID, currencyCode string) (string, error) {
+	if _, err := uc.repos.User.GetByID(userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "recipient not found", nil
+		}
+		return "", fmt.Errorf("failed to get recipient: %w", err)
+	}
+	wallet, err := uc.repos.Wallet.GetByUserIDAndCurrency(userID, currencyCode)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrCurrencyMismatch.Error(), nil
+		}
+		return "", fmt.Errorf("failed to get recipient wallet: %w", err)
+	}
+	if err := checkCanCredit(wallet); err != nil {
+		return err.Error(), nil
+	}
+	return "", nil
+}
+
+// runBestEffort sends each pending item as its own transfer, saving the
+// outcome as it goes so progress can be polled
+func (uc *payoutUseCase) runBestEffort(batch *models.PayoutBatch) error {
+	for i := range batch.Items {
+		item := &batch.Items[i]
+		if item.Status != models.PayoutItemStatusPending {
+			continue
+		}
+
+		// TransferFunds returns whatever transaction already holds the
+		// reference, so make sure it is this item's transfer
+		transaction, err := uc.wallet.TransferFunds(batch.UserID, item.ToUserID, item.Amount, batch.Currency, item.Reference)
+		if err == nil && !isItemTransfer(transaction, batch.UserID, *item, batch.Currency) {
+			err = ErrTransactionExists
+		}
+		if err != nil {
+			item.Status = models.PayoutItemStatusFailed
+			item.Error = err.Error()
+		} else {
+			item.Status = models.PayoutItemStatusSucceeded
+			item.TransactionID = &transaction.ID
+		}
+
+		if err := uc.repos.Payout.UpdateItem(item); err != nil {
+			return fmt.Errorf("failed to update payout item: %w", err)
+		}
+	}
+
+	return uc.finish(uc.repos.Payout.UpdateBatch, batch)
+}
+
+// runAllOrNothing sends every item inside one database transaction, so
+// either all transfers are applied or none are
+func (uc *payoutUseCase) runAllOrNothing(batch *models.PayoutBatch) error {
+	sender, err := uc.repos.User.GetByID(batch.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get sender: %w", err)
+	}
+
+	recipients := make(map[uuid.UUID]*models.User)
+	for _, item := range batch.Items {
+		if _, ok := recipients[item.ToUserID]; ok {
+			continue
+		}
+		recipient, err := uc.repos.User.GetByID(item.ToUserID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to get recipient: %w", err)
+		}
+		recipients[item.ToUserID] = recipient
+	}
+
+	// Start transaction
+	tx := uc.repos.BeginTransaction()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	txRepos := uc.repos.WithTransaction(tx)
+
+	// Lock every wallet in the batch up front, in the usual order, so the
+	// per-item locks below are already held and cannot deadlock
+	var toLock []*models.Wallet
+	for _, userID := range append([]uuid.UUID{batch.UserID}, recipientIDs(batch)...) {
+		wallet, err := txRepos.Wallet.GetByUserIDAndCurrency(userID, batch.Currency)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue // Reported against the item below
+			}
+			tx.Rollback()
+			return fmt.Errorf("failed to get wallet: %w", err)
+		}
+		toLock = append(toLock, wallet)
+	}
+	if _, err := lockWallets(txRepos, toLock...); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to get wallets: %w", err)
+	}
+
+	failed, failure := -1, error(nil)
+	for i := range batch.Items {
+		item := &batch.Items[i]
+
+		transaction, err := uc.sendItem(txRepos, sender, recipients[item.ToUserID], item, batch.Currency)
+		if err != nil {
+			failed, failure = i, err
+			break
+		}
+		item.Status = models.PayoutItemStatusSucceeded
+		item.TransactionID = &transaction.ID
+
+		if err := txRepos.Payout.UpdateItem(item); err != nil {
+			failed, failure = i, fmt.Errorf("failed to update payout item: %w", err)
+			break
+		}
+	}
+
+	if failed < 0 {
+		if err := uc.finish(txRepos.Payout.UpdateBatch, batch); err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		// Commit transaction
+		if err := tx.Commit().Error; err != nil {
+			return fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		return nil
+	}
+
+	// One item failed: undo everything and record why
+	tx.Rollback()
+	for i := range batch.Items {
+		item := &batch.Items[i]
+		item.TransactionID = nil
+		if i == failed {
+			item.Status = models.PayoutItemStatusFailed
+			item.Error = failure.Error()
+		} else {
+			item.Status = models.PayoutItemStatusRolledBack
+			item.Error = fmt.Sprintf("batch rolled back: item at position %d failed", batch.Items[failed].Position)
+		}
+		if err := uc.repos.Payout.UpdateItem(item); err != nil {
+			return fmt.Errorf("failed to update payout item: %w", err)
+		}
+	}
+
+	return uc.finish(uc.repos.Payout.UpdateBatch, batch)
+}
+
+// sendItem applies one item of an all-or-nothing batch inside its transaction
+func (uc *payoutUseCase) sendItem(txRepos *repositories.Repositories, sender, recipient *models.User, item *models.PayoutItem, currencyCode string) (*models.Transaction, error) {
+	if recipient == nil {
+		return nil, ErrRecipientNotFound
+	}
+
+	// Check if transaction already exists (idempotency)
+	existingTxn, err := txRepos.Transaction.GetByReference(item.Reference)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check existing transaction: %w", err)
+	}
+	if existingTxn != nil {
+		if !isItemTransfer(existingTxn, sender.ID, *item, currencyCode) {
+			return nil, ErrTransactionExists
+		}
+		return existingTxn, nil
+	}
+
+	return uc.wallet.transfer(txRepos, sender, recipient, item.Amount, currencyCode, item.Reference, nil, nil)
+}
+
+// isItemTransfer reports whether a transaction is the sender's completed
+// transfer paying the item, as left behind by an earlier run of the batch
+func isItemTransfer(txn *models.Transaction, senderID uuid.UUID, item models.PayoutItem, currencyCode string) bool {
+	return txn.Type == models.TransactionTypeTransfer &&
+		txn.Status == models.TransactionStatusCompleted &&
+		txn.FromUserID != nil && *txn.FromUserID == senderID &&
+		txn.ToUserID != nil && *txn.ToUserID == item.ToUserID &&
+		txn.Amount == item.Amount &&
+		txn.Currency == currencyCode
+}
+
+// finish tallies the item outcomes into the batch status and saves it
+func (uc *payoutUseCase) finish(save func(*models.PayoutBatch) error, batch *models.PayoutBatch) error {
+	batch.SucceededCount, batch.FailedCount = 0, 0
+	for _, item := range batch.Items {
+		if item.Status == models.PayoutItemStatusSucceeded {
+			batch.SucceededCount++
+		} else {
+			batch.FailedCount++
+		}
+	}
+
+	switch {
+	case batch.FailedCount == 0:
+		batch.Status = models.PayoutBatchStatusCompleted
+	case batch.SucceededCount == 0:
+		batch.Status = models.PayoutBatchStatusFailed
+	default:
+		batch.Status = models.PayoutBatchStatusPartiallyCompleted
+	}
+	now := uc.clock.Now()
+	batch.CompletedAt = &now
+
+	if err := save(batch); err != nil {
+		return fmt.Errorf("failed to update payout batch: %w", err)
+	}
+	return nil
+}
+
+// recipientIDs lists the distinct recipients of a batch
+func recipientIDs(batch *models.PayoutBatch) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool)
+	var ids []uuid.UUID
+	for _, item := range batch.Items {
+		if !seen[item.ToUserID] {
+			seen[item.ToUserID] = true
+			ids = append(ids, item.ToUserID)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,234 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MaxPocketNameLength is the longest pocket name accepted
+const MaxPocketNameLength = 64
+
+// Pocket Use Case Implementation
+
+// CreatePocket opens a named wallet beside the user's primary wallet in the
+// same currency. Money in a pocket is only reachable by moving it back.
+func (uc *walletUseCase) CreatePocket(userID uuid.UUID, name, currencyCode string) (*models.Wallet, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > MaxPocketNameLength {
+		return nil, ErrInvalidPocketName
+	}
+
+	currencyCode, err := resolveCurrency(currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if user exists
+	if _, err := uc.repos.User.GetByID(userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// Pockets sit beside a primary wallet, so the currency must be open
+	primary, err := uc.repos.Wallet.GetByUserIDAndCurrency(userID, currencyCode)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWalletNotFound
+		}
+		return nil, fmt.Errorf("failed to get wallet: %w", err)
+	}
+	if primary.Status == models.WalletStatusClosed {
+		return nil, ErrWalletClosed
+	}
+
+	existing, err := uc.repos.Wallet.GetPocket(userID, currencyCode, name)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check existing pocket: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrPocketExists
+	}
+
+	// Start transaction
+	tx := uc.repos.BeginTransaction()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	txRepos := uc.repos.WithTransaction(tx)
+
+	pocket := &models.Wallet{
+		UserID:   userID,
+		Currency: currencyCode,
+		Name:     name,
+		Balance:  0,
+		Status:   models.WalletStatusActive,
+	}
+
+	if err := txRepos.Wallet.Create(pocket); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to create pocket: %w", err)
+	}
+
+	// Open the ledger account backing the pocket
+	if _, err := walletAccount(txRepos, pocket); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return uc.repos.Wallet.GetByID(pocket.ID)
+}
+
+// MoveFunds moves money between two wallets of the same user and currency.
+// Moves stay within the user's own money, so fees and limits do not apply.
+func (uc *walletUseCase) MoveFunds(userID, fromWalletID, toWalletID uuid.UUID, amount int64, reference string) (*models.Transaction, error) {
+	if amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	if fromWalletID == toWalletID {
+		return nil, ErrSameWallet
+	}
+
+	// Check if transaction already exists (idempotency)
+	existingTxn, err := uc.repos.Transaction.GetByReference(reference)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check existing transaction: %w", err)
+	}
+	if existingTxn != nil {
+		return existingTxn, nil // Return existing transaction
+	}
+
+	fromWallet, err := uc.userWallet(userID, fromWalletID)
+	if err != nil {
+		return nil, err
+	}
+	toWallet, err := uc.userWallet(userID, toWalletID)
+	if err != nil {
+		return nil, err
+	}
+	if fromWallet.Currency != toWallet.Currency {
+		return nil, ErrCurrencyMismatch
+	}
+
+	// Start transaction
+	tx := uc.repos.BeginTransaction()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	txRepos := uc.repos.WithTransaction(tx)
+
+	wallets, err := lockWallets(txRepos, fromWallet, toWallet)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to get wallets: %w", err)
+	}
+	fromWallet, toWallet = wallets[fromWalletID], wallets[toWalletID]
+
+	if err := checkCanDebit(fromWallet); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := checkCanCredit(toWallet); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if fromWallet.Available() < amount {
+		tx.Rollback()
+		return nil, ErrInsufficientFunds
+	}
+
+	transaction := &models.Transaction{
+		UserID:      userID,
+		Type:        models.TransactionTypeMove,
+		Amount:      amount,
+		Currency:    fromWallet.Currency,
+		Description: fmt.Sprintf("Move from %s to %s", walletLabel(fromWallet), walletLabel(toWallet)),
+		Status:      models.TransactionStatusCompleted,
+		Reference:   reference,
+		FromUserID:  &userID,
+		ToUserID:    &userID,
+	}
+
+	if err := txRepos.Transaction.Create(transaction); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	fromAccount, err := walletAccount(txRepos, fromWallet)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	toAccount, err := walletAccount(txRepos, toWallet)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := postJournal(txRepos, transaction, posting{debit: fromAccount, credit: toAccount, amount: amount}); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return transaction, nil
+}
+
+// GetWalletTransactionHistory lists the transactions that touched one of the
+// user's wallets
+func (uc *walletUseCase) GetWalletTransactionHistory(userID, walletID uuid.UUID, page, pageSize int) ([]models.Transaction, int64, error) {
+	if _, err := uc.userWallet(userID, walletID); err != nil {
+		return nil, 0, err
+	}
+
+	limit, offset := pageBounds(page, pageSize)
+	return uc.repos.Transaction.GetByWalletID(walletID, limit, offset)
+}
+
+// userWallet loads a wallet and makes sure it belongs to the user
+func (uc *walletUseCase) userWallet(userID, walletID uuid.UUID) (*models.Wallet, error) {
+	wallet, err := uc.repos.Wallet.GetByID(walletID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWalletNotFound
+		}
+		return nil, fmt.Errorf("failed to get wallet: %w", err)
+	}
+	// Someone else's wallet is reported the same as a missing one
+	if wallet.UserID != userID {
+		return nil, ErrWalletNotFound
+	}
+	return wallet, nil
+}
+
+// walletLabel names a wallet in transaction descriptions
+func walletLabel(wallet *models.Wallet) string {
+	if wallet.IsPrimary {
+		return "primary wallet"
+	}
+	return fmt.Sprintf("pocket %q", wallet.Name)
+}
@@ -0,0 +1,396 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// promoExpiryBatchSize caps how many buckets a single ExpireBuckets run expires
+const promoExpiryBatchSize = 100
+
+// Promo Use Case Implementation
+
+// Grant credits a user's primary wallet with promotional credit that can
+// only be spent on transfers to merchants until expiresAt
+func (uc *promoUseCase) Grant(userID uuid.UUID, amount int64, currencyCode string, expiresAt time.Time, description, reference string) (*models.PromoBucket, error) {
+	if amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+	if !expiresAt.After(uc.clock.Now()) {
+		return nil, ErrInvalidPromoExpiry
+	}
+
+	currencyCode, err := resolveCurrency(currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if transaction already exists (idempotency)
+	existingTxn, err := uc.repos.Transaction.GetByReference(reference)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check existing transaction: %w", err)
+	}
+	if existingTxn != nil {
+		if existingTxn.Type != models.TransactionTypePromoCredit {
+			return nil, ErrTransactionExists
+		}
+		return uc.repos.Promo.GetBucketByTransactionID(existingTxn.ID)
+	}
+
+	if _, err := uc.repos.User.GetByID(userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	wallet, err := uc.repos.Wallet.GetByUserIDAndCurrency(userID, currencyCode)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWalletNotFound
+		}
+		return nil, fmt.Errorf("failed to get wallet: %w", err)
+	}
+
+	if description == "" {
+		description = "Promotional credit"
+	}
+
+	// Start transaction
+	tx := uc.repos.BeginTransaction()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	txRepos := uc.repos.WithTransaction(tx)
+
+	wallet, err = txRepos.Wallet.GetByIDForUpdate(wallet.ID)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to get wallet: %w", err)
+	}
+	if err := checkCanCredit(wallet); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	transaction := &models.Transaction{
+		UserID:      userID,
+		Type:        models.TransactionTypePromoCredit,
+		Amount:      amount,
+		Currency:    currencyCode,
+		Description: description,
+		Status:      models.TransactionStatusCompleted,
+		Reference:   reference,
+		PromoAmount: amount,
+	}
+
+	if err := txRepos.Transaction.Create(transaction); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	// Post the journal: the promotions account pays for the credit
+	promotions, err := systemAccount(txRepos, models.AccountCodePromotions, currencyCode)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	walletAcc, err := walletAccount(txRepos, wallet)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := postJournal(txRepos, transaction, posting{debit: promotions, credit: walletAcc, amount: amount}); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := txRepos.Wallet.AdjustPromoBalance(wallet.ID, amount); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to update promo balance: %w", err)
+	}
+
+	bucket := &models.PromoBucket{
+		WalletID:      wallet.ID,
+		UserID:        userID,
+		Amount:        amount,
+		Remaining:     amount,
+		Currency:      currencyCode,
+		Description:   description,
+		Status:        models.PromoBucketStatusActive,
+		ExpiresAt:     expiresAt,
+		TransactionID: transaction.ID,
+	}
+
+	if err := txRepos.Promo.CreateBucket(bucket); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to create promo bucket: %w", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return bucket, nil
+}
+
+// ListBuckets returns the promo buckets of the user's primary wallet in a
+// currency, newest first
+func (uc *promoUseCase) ListBuckets(userID uuid.UUID, currencyCode string) ([]models.PromoBucket, error) {
+	currencyCode, err := resolveCurrency(currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := uc.repos.Wallet.GetByUserIDAndCurrency(userID, currencyCode)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			if _, err := uc.repos.User.GetByID(userID); errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrUserNotFound
+			}
+			return nil, ErrWalletNotFound
+		}
+		return nil, fmt.Errorf("failed to get wallet: %w", err)
+	}
+
+	buckets, err := uc.repos.Promo.ListByWalletID(wallet.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list promo buckets: %w", err)
+	}
+	return buckets, nil
+}
+
+// ExpireBuckets takes back the unspent credit of buckets past their expiry
+// and returns how many it expired
+func (uc *promoUseCase) ExpireBuckets() (int, error) {
+	buckets, err := uc.repos.Promo.GetExpired(uc.clock.Now(), promoExpiryBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get expired promo buckets: %w", err)
+	}
+
+	expired := 0
+	for _, bucket := range buckets {
+		if err := uc.expireBucket(bucket.WalletID, bucket.ID); err != nil {
+			log.Printf("Failed to expire promo bucket %s: %v", bucket.ID, err)
+			continue
+		}
+		expired++
+	}
+
+	return expired, nil
+}
+
+func (uc *promoUseCase) expireBucket(walletID, bucketID uuid.UUID) error {
+	// Start transaction
+	tx := uc.repos.BeginTransaction()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	txRepos := uc.repos.WithTransaction(tx)
+
+	wallet, err := txRepos.Wallet.GetByIDForUpdate(walletID)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to get wallet: %w", err)
+	}
+
+	bucket, err := txRepos.Promo.GetBucketForUpdate(bucketID)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to get promo bucket: %w", err)
+	}
+
+	// The bucket may have been spent or forfeited since it was listed
+	if bucket.Status != models.PromoBucketStatusActive || bucket.ExpiresAt.After(uc.clock.Now()) {
+		tx.Rollback()
+		return nil
+	}
+
+	if err := takeBackPromo(txRepos, wallet, bucket, "Promotional credit expired"); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// spendablePromo locks the wallet's spendable buckets and works out how much
+// of amount they cover
+func spendablePromo(txRepos *repositories.Repositories, wallet *models.Wallet, amount int64, now time.Time) ([]models.PromoBucket, int64, error) {
+	if wallet.PromoBalance <= 0 {
+		return nil, 0, nil
+	}
+
+	buckets, err := txRepos.Promo.ListSpendableForUpdate(wallet.ID, now)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get promo buckets: %w", err)
+	}
+
+	var covered int64
+	for _, bucket := range buckets {
+		covered += bucket.Remaining
+	}
+	return buckets, min(covered, amount), nil
+}
+
+// spendPromo takes amount out of the buckets in order on behalf of a
+// transaction, recording each draw so a refund can put it back
+func spendPromo(txRepos *repositories.Repositories, transaction *models.Transaction, wallet *models.Wallet, buckets []models.PromoBucket, amount int64) error {
+	left := amount
+	for i := range buckets {
+		if left == 0 {
+			break
+		}
+		bucket := &buckets[i]
+		draw := min(bucket.Remaining, left)
+
+		bucket.Remaining -= draw
+		if bucket.Remaining == 0 {
+			bucket.Status = models.PromoBucketStatusSpent
+		}
+		if err := txRepos.Promo.UpdateBucket(bucket); err != nil {
+			return fmt.Errorf("failed to update promo bucket: %w", err)
+		}
+
+		if err := txRepos.Promo.CreateSpend(&models.PromoSpend{
+			BucketID:      bucket.ID,
+			TransactionID: transaction.ID,
+			Amount:        draw,
+		}); err != nil {
+			return fmt.Errorf("failed to record promo spend: %w", err)
+		}
+		left -= draw
+	}
+
+	if err := txRepos.Wallet.AdjustPromoBalance(wallet.ID, -amount); err != nil {
+		return fmt.Errorf("failed to update promo balance: %w", err)
+	}
+	return nil
+}
+
+// restorePromo gives back up to amount of the promotional credit a
+// transaction spent, latest-expiring bucket first, and returns how much it
+// gave back. A bucket that has expired in the meantime is taken back again
+// by the next expiry run.
+func restorePromo(txRepos *repositories.Repositories, parent *models.Transaction, wallet *models.Wallet, amount int64) (int64, error) {
+	spends, err := txRepos.Promo.ListRestorableSpends(parent.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get promo spends: %w", err)
+	}
+
+	var restored int64
+	for i := range spends {
+		if restored == amount {
+			break
+		}
+		spend := &spends[i]
+		back := min(spend.Amount-spend.Restored, amount-restored)
+
+		bucket, err := txRepos.Promo.GetBucketForUpdate(spend.BucketID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get promo bucket: %w", err)
+		}
+		bucket.Remaining += back
+		bucket.Status = models.PromoBucketStatusActive
+		if err := txRepos.Promo.UpdateBucket(bucket); err != nil {
+			return 0, fmt.Errorf("failed to update promo bucket: %w", err)
+		}
+
+		spend.Restored += back
+		if err := txRepos.Promo.UpdateSpend(spend); err != nil {
+			return 0, fmt.Errorf("failed to update promo spend: %w", err)
+		}
+		restored += back
+	}
+
+	if restored > 0 {
+		if err := txRepos.Wallet.AdjustPromoBalance(wallet.ID, restored); err != nil {
+			return 0, fmt.Errorf("failed to update promo balance: %w", err)
+		}
+	}
+	return restored, nil
+}
+
+// forfeitPromo takes back every active bucket of a wallet, whatever its
+// expiry, and returns the total taken
+func forfeitPromo(txRepos *repositories.Repositories, wallet *models.Wallet) (int64, error) {
+	buckets, err := txRepos.Promo.ListActiveForUpdate(wallet.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get promo buckets: %w", err)
+	}
+
+	var forfeited int64
+	for i := range buckets {
+		forfeited += buckets[i].Remaining
+		if err := takeBackPromo(txRepos, wallet, &buckets[i], "Promotional credit forfeited on wallet close"); err != nil {
+			return 0, err
+		}
+	}
+	return forfeited, nil
+}
+
+// takeBackPromo returns the credit left in a bucket to the promotions
+// account and closes the bucket as expired. The caller holds the wallet lock.
+func takeBackPromo(txRepos *repositories.Repositories, wallet *models.Wallet, bucket *models.PromoBucket, description string) error {
+	remaining := bucket.Remaining
+	bucket.Remaining = 0
+	bucket.Status = models.PromoBucketStatusExpired
+	if err := txRepos.Promo.UpdateBucket(bucket); err != nil {
+		return fmt.Errorf("failed to update promo bucket: %w", err)
+	}
+	if remaining == 0 {
+		return nil
+	}
+
+	// A refund can reopen a bucket, so the bucket may be taken back more
+	// than once
+	transaction := &models.Transaction{
+		UserID:              wallet.UserID,
+		Type:                models.TransactionTypePromoExpiry,
+		Amount:              remaining,
+		Currency:            wallet.Currency,
+		Description:         description,
+		Status:              models.TransactionStatusCompleted,
+		Reference:           fmt.Sprintf("promo_expiry:%s:%s", bucket.ID, uuid.New()),
+		ParentTransactionID: &bucket.TransactionID,
+		PromoAmount:         remaining,
+	}
+
+	if err := txRepos.Transaction.Create(transaction); err != nil {
+		return fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	walletAcc, err := walletAccount(txRepos, wallet)
+	if err != nil {
+		return err
+	}
+	promotions, err := systemAccount(txRepos, models.AccountCodePromotions, wallet.Currency)
+	if err != nil {
+		return err
+	}
+
+	if err := postJournal(txRepos, transaction, posting{debit: walletAcc, credit: promotions, amount: remaining}); err != nil {
+		return err
+	}
+
+	if err := txRepos.Wallet.AdjustPromoBalance(wallet.ID, -remaining); err != nil {
+		return fmt.Errorf("failed to update promo balance: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Refund Use Case Implementation
+
+func (uc *walletUseCase) RefundTransaction(transactionID uuid.UUID, amount int64, reference string) (*models.Transaction, error) {
+	if amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+	return uc.refund(transactionID, amount, reference)
+}
+
+// ReverseTransaction refunds whatever is left of a transaction
+func (uc *walletUseCase) ReverseTransaction(transactionID uuid.UUID, reference string) (*models.Transaction, error) {
+	return uc.refund(transactionID, 0, reference)
+}
+
+// refund creates a compensating transaction for amount out of the parent;
+// an amount of zero refunds the remainder
+func (uc *walletUseCase) refund(transactionID uuid.UUID, amount int64, reference string) (*models.Transaction, error) {
+	// Check if transaction already exists (idempotency)
+	existingTxn, err := uc.repos.Transaction.GetByReference(reference)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check existing transaction: %w", err)
+	}
+	if existingTxn != nil {
+		return existingTxn, nil // Return existing transaction
+	}
+
+	parent, err := uc.repos.Transaction.GetByID(transactionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTransactionNotFound
+		}
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+
+	if parent.Type == models.TransactionTypeRefund || parent.Status != models.TransactionStatusCompleted {
+		return nil, ErrTransactionNotRefundable
+	}
+
+	// Money flows back the way it came: from whoever received it to whoever paid it
+	var fromUserID, toUserID *uuid.UUID
+	switch parent.Type {
+	case models.TransactionTypeCredit:
+		fromUserID = &parent.UserID
+	case models.TransactionTypeDebit:
+		toUserID = &parent.UserID
+	case models.TransactionTypeTransfer, models.TransactionTypeEscrow:
+		fromUserID, toUserID = parent.ToUserID, parent.FromUserID
+	default:
+		return nil, ErrTransactionNotRefundable
+	}
+
+	// Start transaction
+	tx := uc.repos.BeginTransaction()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	txRepos := uc.repos.WithTransaction(tx)
+
+	// Lock the wallets involved, then the parent so concurrent refunds
+	// cannot both see the same remaining amount
+	walletsByUser := make(map[uuid.UUID]*models.Wallet)
+	var toLock []*models.Wallet
+	for _, userID := range []*uuid.UUID{fromUserID, toUserID} {
+		if userID == nil {
+			continue
+		}
+		wallet, err := txRepos.Wallet.GetByUserIDAndCurrency(*userID, parent.Currency)
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to get wallet: %w", err)
+		}
+		toLock = append(toLock, wallet)
+	}
+
+	locked, err := lockWallets(txRepos, toLock...)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to get wallets: %w", err)
+	}
+	for _, wallet := range locked {
+		walletsByUser[wallet.UserID] = wallet
+	}
+
+	if fromUserID != nil {
+		if err := checkCanDebit(walletsByUser[*fromUserID]); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+	if toUserID != nil {
+		if err := checkCanCredit(walletsByUser[*toUserID]); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	parent, err = txRepos.Transaction.GetByIDForUpdate(transactionID)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+
+	remaining := parent.RefundableAmount()
+	if amount == 0 {
+		amount = remaining
+	}
+	if amount <= 0 || amount > remaining {
+		tx.Rollback()
+		return nil, ErrRefundExceedsRemaining
+	}
+
+	// The party giving the money back must still have it
+	if fromUserID != nil && walletsByUser[*fromUserID].Available() < amount {
+		tx.Rollback()
+		return nil, ErrInsufficientFunds
+	}
+
+	// Promotional credit spent on a merchant payment comes back as
+	// promotional credit, so a refund cannot turn it into cash
+	var promoAmount int64
+	if parent.Type == models.TransactionTypeTransfer && parent.PromoAmount > 0 {
+		promoAmount, err = restorePromo(txRepos, parent, walletsByUser[*toUserID], amount)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	// Create transaction record
+	transaction := &models.Transaction{
+		UserID:              parent.UserID,
+		Type:                models.TransactionTypeRefund,
+		Amount:              amount,
+		Currency:            parent.Currency,
+		Description:         fmt.Sprintf("Refund of %s", parent.Reference),
+		Status:              models.TransactionStatusCompleted,
+		Reference:           reference,
+		FromUserID:          fromUserID,
+		ToUserID:            toUserID,
+		ParentTransactionID: &parent.ID,
+		PromoAmount:         promoAmount,
+	}
+
+	if err := txRepos.Transaction.Create(transaction); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	// Post the journal: the mirror image of the parent's entries
+	debit, err := refundAccount(txRepos, walletsByUser, fromUserID, models.AccountCodeWithdrawalClearing, parent.Currency)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	credit, err := refundAccount(txRepos, walletsByUser, toUserID, models.AccountCodeFundingSource, parent.Currency)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := postJournal(txRepos, transaction, posting{debit: debit, credit: credit, amount: amount}); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := txRepos.Transaction.UpdateRefundedAmount(parent.ID, parent.RefundedAmount+amount); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to update refunded amount: %w", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return transaction, nil
+}
+
+// refundAccount returns the wallet account of the given user, or the system
+// account that stood in for the outside world in the parent transaction
+func refundAccount(repos *repositories.Repositories, wallets map[uuid.UUID]*models.Wallet, userID *uuid.UUID, systemCode, currencyCode string) (*models.LedgerAccount, error) {
+	if userID == nil {
+		return systemAccount(repos, systemCode, currencyCode)
+	}
+	return walletAccount(repos, wallets[*userID])
+}
@@ -0,0 +1,336 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/cron"
+	"github.com/Code-Linx/wallet-service/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// scheduledTransferBatchSize caps how many due transfers one scheduler run attempts
+const scheduledTransferBatchSize = 100
+
+// ScheduledTransferUpdate holds the fields of a scheduled transfer that can
+// be changed after creation; nil fields are left as they are
+type ScheduledTransferUpdate struct {
+	Amount      *int64
+	Description *string
+	Status      *models.ScheduledTransferStatus // Active or paused
+}
+
+// Scheduled Transfer Use Case Implementation
+
+func (uc *scheduledTransferUseCase) Create(transfer *models.ScheduledTransfer, startAt time.Time) (*models.ScheduledTransfer, error) {
+	if transfer.Amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+	if transfer.UserID == transfer.ToUserID {
+		return nil, ErrSameUser
+	}
+
+	code, err := resolveCurrency(transfer.Currency)
+	if err != nil {
+		return nil, err
+	}
+	transfer.Currency = code
+
+	for _, userID := range []uuid.UUID{transfer.UserID, transfer.ToUserID} {
+		if _, err := uc.repos.User.GetByID(userID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrUserNotFound
+			}
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+	}
+
+	now := uc.clock.Now().UTC()
+	if startAt.IsZero() {
+		startAt = now
+	}
+	startAt = startAt.UTC()
+
+	// One-off transfers run at the start time; recurring ones at the first
+	// match of their schedule from then on
+	first := startAt
+	if transfer.Schedule != "" {
+		schedule, err := cron.Parse(transfer.Schedule)
+		if err != nil {
+			return nil, ErrInvalidSchedule
+		}
+		if first = schedule.Next(startAt.Add(-time.Nanosecond)); first.IsZero() {
+			return nil, ErrInvalidSchedule
+		}
+	}
+	if transfer.EndsAt != nil {
+		endsAt := transfer.EndsAt.UTC()
+		if endsAt.Before(first) {
+			return nil, ErrInvalidSchedule
+		}
+		transfer.EndsAt = &endsAt
+	}
+
+	transfer.OccurrenceAt = first
+	transfer.NextRunAt = first
+	transfer.Status = models.ScheduledTransferStatusActive
+	transfer.RunCount = 0
+	transfer.FailureCount = 0
+	transfer.LastError = ""
+
+	if err := uc.repos.ScheduledTransfer.Create(transfer); err != nil {
+		return nil, fmt.Errorf("failed to create scheduled transfer: %w", err)
+	}
+	return transfer, nil
+}
+
+func (uc *scheduledTransferUseCase) List(userID uuid.UUID) ([]models.ScheduledTransfer, error) {
+	if _, err := uc.repos.User.GetByID(userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	transfers, err := uc.repos.ScheduledTransfer.ListByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scheduled transfers: %w", err)
+	}
+	return transfers, nil
+}
+
+func (uc *scheduledTransferUseCase) Get(userID, transferID uuid.UUID) (*models.ScheduledTransfer, error) {
+	transfer, err := uc.repos.ScheduledTransfer.GetByID(transferID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrScheduledTransferNotFound
+		}
+		return nil, fmt.Errorf("failed to get scheduled transfer: %w", err)
+	}
+
+	// Scheduled transfers are only visible to the sender
+	if transfer.UserID != userID {
+		return nil, ErrScheduledTransferNotFound
+	}
+	return transfer, nil
+}
+
+func (uc *scheduledTransferUseCase) Update(userID, transferID uuid.UUID, update ScheduledTransferUpdate) (*models.ScheduledTransfer, error) {
+	transfer, err := uc.Get(userID, transferID)
+	if err != nil {
+		return nil, err
+	}
+	if !isOpenSchedule(transfer.Status) {
+		return nil, ErrScheduledTransferClosed
+	}
+
+	if update.Amount != nil {
+		if *update.Amount <= 0 {
+			return nil, ErrInvalidAmount
+		}
+		transfer.Amount = *update.Amount
+	}
+	if update.Description != nil {
+		transfer.Description = *update.Description
+	}
+	if update.Status != nil && *update.Status != transfer.Status {
+		switch *update.Status {
+		case models.ScheduledTransferStatusPaused:
+			transfer.Status = models.ScheduledTransferStatusPaused
+		case models.ScheduledTransferStatusActive:
+			uc.resume(transfer)
+		default:
+			return nil, ErrInvalidSchedule
+		}
+	}
+
+	if err := uc.repos.ScheduledTransfer.Update(transfer); err != nil {
+		return nil, fmt.Errorf("failed to update scheduled transfer: %w", err)
+	}
+	return transfer, nil
+}
+
+func (uc *scheduledTransferUseCase) Cancel(userID, transferID uuid.UUID) (*models.ScheduledTransfer, error) {
+	transfer, err := uc.Get(userID, transferID)
+	if err != nil {
+		return nil, err
+	}
+	if !isOpenSchedule(transfer.Status) {
+		return nil, ErrScheduledTransferClosed
+	}
+
+	transfer.Status = models.ScheduledTransferStatusCancelled
+	if err := uc.repos.ScheduledTransfer.Update(transfer); err != nil {
+		return nil, fmt.Errorf("failed to cancel scheduled transfer: %w", err)
+	}
+	return transfer, nil
+}
+
+func (uc *scheduledTransferUseCase) ListRuns(userID, transferID uuid.UUID) ([]models.ScheduledTransferRun, error) {
+	transfer, err := uc.Get(userID, transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	runs, err := uc.repos.ScheduledTransfer.ListRuns(transfer.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scheduled transfer runs: %w", err)
+	}
+	return runs, nil
+}
+
+// RunDue attempts every active scheduled transfer whose next run time has
+// passed and returns how many were attempted. Failed transfers are recorded
+// and retried under the retry policy rather than returned as errors.
+func (uc *scheduledTransferUseCase) RunDue() (int, error) {
+	now := uc.clock.Now().UTC()
+	transfers, err := uc.repos.ScheduledTransfer.GetDue(now, scheduledTransferBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get due scheduled transfers: %w", err)
+	}
+
+	attempted := 0
+	for i := range transfers {
+		if err := uc.runOccurrence(&transfers[i], now); err != nil {
+			log.Printf("Failed to run scheduled transfer %s: %v", transfers[i].ID, err)
+			continue
+		}
+		attempted++
+	}
+
+	return attempted, nil
+}
+
+// runOccurrence makes one attempt at the transfer's current occurrence and
+// moves the schedule on
+func (uc *scheduledTransferUseCase) runOccurrence(transfer *models.ScheduledTransfer, now time.Time) error {
+	run := &models.ScheduledTransferRun{
+		ScheduledTransferID: transfer.ID,
+		OccurrenceAt:        transfer.OccurrenceAt,
+		Attempt:             transfer.FailureCount + 1,
+	}
+
+	// The reference is fixed per occurrence, so a retry or a second scheduler
+	// instance can never move the money twice
+	transaction, err := uc.wallet.TransferFunds(transfer.UserID, transfer.ToUserID, transfer.Amount, transfer.Currency, occurrenceReference(transfer))
+	if err != nil {
+		run.Status = models.ScheduledTransferRunFailed
+		run.Error = err.Error()
+
+		transfer.FailureCount++
+		transfer.LastError = err.Error()
+		if transfer.FailureCount < uc.retry.MaxAttempts {
+			transfer.NextRunAt = now.Add(uc.retry.delay(transfer.FailureCount))
+		} else {
+			uc.advance(transfer, now, models.ScheduledTransferStatusFailed)
+		}
+	} else {
+		run.Status = models.ScheduledTransferRunSucceeded
+		run.TransactionID = &transaction.ID
+
+		transfer.RunCount++
+		transfer.LastError = ""
+		uc.advance(transfer, now, models.ScheduledTransferStatusCompleted)
+	}
+
+	// Start transaction
+	tx := uc.repos.BeginTransaction()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	txRepos := uc.repos.WithTransaction(tx)
+
+	if err := txRepos.ScheduledTransfer.CreateRun(run); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to record run: %w", err)
+	}
+
+	if err := txRepos.ScheduledTransfer.Update(transfer); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to update scheduled transfer: %w", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// advance moves a transfer to its next occurrence, skipping any missed
+// while the scheduler was down. Transfers with no further occurrence end in
+// the given status.
+func (uc *scheduledTransferUseCase) advance(transfer *models.ScheduledTransfer, now time.Time, final models.ScheduledTransferStatus) {
+	transfer.FailureCount = 0
+
+	next := uc.nextOccurrence(transfer, now)
+	if next.IsZero() {
+		transfer.Status = final
+		return
+	}
+	transfer.OccurrenceAt = next
+	transfer.NextRunAt = next
+}
+
+// resume reactivates a paused transfer. Recurring transfers skip the
+// occurrences that fell due while paused; a one-off runs straight away.
+func (uc *scheduledTransferUseCase) resume(transfer *models.ScheduledTransfer) {
+	now := uc.clock.Now().UTC()
+	transfer.Status = models.ScheduledTransferStatusActive
+	transfer.FailureCount = 0
+
+	if transfer.Schedule == "" || !transfer.OccurrenceAt.Before(now) {
+		transfer.NextRunAt = transfer.OccurrenceAt
+		return
+	}
+
+	next := uc.nextOccurrence(transfer, now)
+	if next.IsZero() {
+		transfer.Status = models.ScheduledTransferStatusCompleted
+		return
+	}
+	transfer.OccurrenceAt = next
+	transfer.NextRunAt = next
+}
+
+// nextOccurrence returns the first occurrence after both the current one and
+// now, or the zero time if the schedule has ended
+func (uc *scheduledTransferUseCase) nextOccurrence(transfer *models.ScheduledTransfer, now time.Time) time.Time {
+	if transfer.Schedule == "" {
+		return time.Time{}
+	}
+
+	schedule, err := cron.Parse(transfer.Schedule)
+	if err != nil {
+		// Validated on create, so only reachable if the row was edited by hand
+		log.Printf("Scheduled transfer %s has an invalid schedule %q: %v", transfer.ID, transfer.Schedule, err)
+		return time.Time{}
+	}
+
+	after := transfer.OccurrenceAt
+	if now.After(after) {
+		after = now
+	}
+	next := schedule.Next(after)
+	if next.IsZero() || (transfer.EndsAt != nil && next.After(*transfer.EndsAt)) {
+		return time.Time{}
+	}
+	return next
+}
+
+// occurrenceReference is the transaction reference for one occurrence of a scheduled transfer
+func occurrenceReference(transfer *models.ScheduledTransfer) string {
+	return fmt.Sprintf("scheduled:%s:%d", transfer.ID, transfer.OccurrenceAt.Unix())
+}
+
+// isOpenSchedule reports whether a scheduled transfer can still run
+func isOpenSchedule(status models.ScheduledTransferStatus) bool {
+	return status == models.ScheduledTransferStatusActive || status == models.ScheduledTransferStatusPaused
+}
@@ -0,0 +1,243 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MaxSplitRecipients caps the number of recipients of one split payment
+const MaxSplitRecipients = 50
+
+// SplitShare is one recipient's part of a split payment: either an exact
+// Amount or BasisPoints of the total. Every share of a split uses the same kind.
+type SplitShare struct {
+	ToUserID    uuid.UUID
+	Amount      int64 // Exact amount in the smallest currency unit
+	BasisPoints int64 // 1 basis point is 0.01% of the total
+}
+
+// Split Payment Use Case Implementation
+
+// SplitPayment debits the sender once and pays each share to its recipient
+// as a transfer leg under one split parent, all in one database transaction.
+// With exact shares amount may be zero, otherwise it must match their sum.
+func (uc *walletUseCase) SplitPayment(fromUserID uuid.UUID, amount int64, currencyCode string, shares []SplitShare, reference string) (*models.Transaction, error) {
+	amounts, total, err := splitAmounts(amount, shares)
+	if err != nil {
+		return nil, err
+	}
+
+	currencyCode, err = resolveCurrency(currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uuid.UUID]bool, len(shares))
+	for _, share := range shares {
+		if share.ToUserID == fromUserID {
+			return nil, ErrSameUser
+		}
+		if seen[share.ToUserID] {
+			return nil, ErrInvalidSplit
+		}
+		seen[share.ToUserID] = true
+	}
+
+	// Check if transaction already exists (idempotency)
+	existingTxn, err := uc.repos.Transaction.GetByReference(reference)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check existing transaction: %w", err)
+	}
+	if existingTxn != nil {
+		return uc.repos.Transaction.GetByID(existingTxn.ID) // Return existing transaction with its legs
+	}
+
+	fromUser, err := uc.repos.User.GetByID(fromUserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get sender: %w", err)
+	}
+
+	attempt := &models.Transaction{
+		UserID:      fromUserID,
+		Type:        models.TransactionTypeSplit,
+		Amount:      total,
+		Currency:    currencyCode,
+		Description: fmt.Sprintf("Split payment to %d recipients", len(shares)),
+		Reference:   reference,
+		FromUserID:  &fromUserID,
+	}
+
+	recipients := make([]*models.User, len(shares))
+	for i, share := range shares {
+		recipients[i], err = uc.repos.User.GetByID(share.ToUserID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				uc.recordFailure(attempt, ErrRecipientNotFound)
+				return nil, ErrRecipientNotFound
+			}
+			return nil, fmt.Errorf("failed to get recipient: %w", err)
+		}
+	}
+
+	// Start transaction
+	tx := uc.repos.BeginTransaction()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	txRepos := uc.repos.WithTransaction(tx)
+
+	transaction, err := uc.split(txRepos, fromUser, recipients, amounts, total, currencyCode, reference)
+	if err != nil {
+		tx.Rollback()
+		uc.recordFailure(attempt, err)
+		return nil, err
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return uc.repos.Transaction.GetByID(transaction.ID)
+}
+
+// split creates the parent transaction and one transfer leg per recipient
+// inside the caller's database transaction; the caller rolls back on error
+func (uc *walletUseCase) split(txRepos *repositories.Repositories, fromUser *models.User, recipients []*models.User, amounts []int64, total int64, currencyCode, reference string) (*models.Transaction, error) {
+	// Lock every wallet up front in the usual order, so the legs cannot
+	// deadlock against other transfers
+	fromWallet, err := txRepos.Wallet.GetByUserIDAndCurrency(fromUser.ID, currencyCode)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWalletNotFound
+		}
+		return nil, fmt.Errorf("failed to get sender wallet: %w", err)
+	}
+
+	wallets := []*models.Wallet{fromWallet}
+	for _, recipient := range recipients {
+		wallet, err := txRepos.Wallet.GetByUserIDAndCurrency(recipient.ID, currencyCode)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrCurrencyMismatch
+			}
+			return nil, fmt.Errorf("failed to get recipient wallet: %w", err)
+		}
+		wallets = append(wallets, wallet)
+	}
+
+	if _, err := lockWallets(txRepos, wallets...); err != nil {
+		return nil, fmt.Errorf("failed to get wallets: %w", err)
+	}
+
+	// A per-transaction limit applies to the split as a whole; the legs are
+	// checked again one by one as they add up
+	if err := checkLimits(txRepos, fromUser, models.TransactionTypeTransfer, total, currencyCode, uc.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	parent := &models.Transaction{
+		UserID:      fromUser.ID,
+		Type:        models.TransactionTypeSplit,
+		Amount:      total,
+		Currency:    currencyCode,
+		Description: fmt.Sprintf("Split payment to %d recipients", len(recipients)),
+		Status:      models.TransactionStatusCompleted,
+		Reference:   reference,
+		FromUserID:  &fromUser.ID,
+	}
+
+	if err := txRepos.Transaction.Create(parent); err != nil {
+		return nil, fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	// Each leg is an ordinary transfer, so fees and wallet status apply to it
+	for i, recipient := range recipients {
+		legReference := fmt.Sprintf("%s:leg:%d", reference, i+1)
+		if _, err := uc.transfer(txRepos, fromUser, recipient, amounts[i], currencyCode, legReference, nil, &parent.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return parent, nil
+}
+
+// splitAmounts works out what each share receives and the total paid.
+// Percentage shares must add up to 100%; the units lost to rounding down go
+// one each to the shares with the largest remainders, the earlier share
+// first on a tie.
+func splitAmounts(total int64, shares []SplitShare) ([]int64, int64, error) {
+	if len(shares) == 0 || len(shares) > MaxSplitRecipients {
+		return nil, 0, ErrInvalidSplit
+	}
+
+	exact := shares[0].Amount > 0
+	amounts := make([]int64, len(shares))
+	for i, share := range shares {
+		if exact && (share.Amount <= 0 || share.BasisPoints != 0) {
+			return nil, 0, ErrInvalidSplit
+		}
+		if !exact && (share.BasisPoints <= 0 || share.Amount != 0) {
+			return nil, 0, ErrInvalidSplit
+		}
+		amounts[i] = share.Amount
+	}
+
+	if exact {
+		var sum int64
+		for _, amount := range amounts {
+			sum += amount
+		}
+		if total != 0 && total != sum {
+			return nil, 0, ErrInvalidSplit
+		}
+		return amounts, sum, nil
+	}
+
+	if total <= 0 {
+		return nil, 0, ErrInvalidAmount
+	}
+
+	var basisPoints, allocated int64
+	remainders := make([]int64, len(shares))
+	for i, share := range shares {
+		basisPoints += share.BasisPoints
+		amounts[i] = total * share.BasisPoints / basisPointsPerUnit
+		remainders[i] = total * share.BasisPoints % basisPointsPerUnit
+		allocated += amounts[i]
+	}
+	if basisPoints != basisPointsPerUnit {
+		return nil, 0, ErrInvalidSplit
+	}
+
+	order := make([]int, len(shares))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]] > remainders[order[b]]
+	})
+	for _, i := range order[:total-allocated] {
+		amounts[i]++
+	}
+
+	for _, amount := range amounts {
+		if amount <= 0 {
+			return nil, 0, ErrInvalidSplit
+		}
+	}
+	return amounts, total, nil
+}
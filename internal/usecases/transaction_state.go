@@ -0,0 +1,25 @@
+package usecases
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// recordFailure keeps a failed attempt so support can see what went wrong.
+// It is written outside the rolled back database transaction, under a
+// reference of its own so the caller can retry with the original one.
+func (uc *walletUseCase) recordFailure(attempt *models.Transaction, cause error) {
+	failedAt := uc.clock.Now()
+	attempt.Status = models.TransactionStatusFailed
+	attempt.FailureReason = cause.Error()
+	attempt.FailedAt = &failedAt
+	attempt.Reference = fmt.Sprintf("%s:failed:%s", attempt.Reference, uuid.New())
+
+	if err := uc.repos.Transaction.Create(attempt); err != nil {
+		log.Printf("failed to record failed %s %s: %v", attempt.Type, attempt.Reference, err)
+	}
+}
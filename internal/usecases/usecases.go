@@ -6,6 +6,9 @@ import (
 	"log"
 	"time"
 
+	"github.com/Code-Linx/wallet-service/internal/clock"
+	"github.com/Code-Linx/wallet-service/internal/currency"
+	"github.com/Code-Linx/wallet-service/internal/fx"
 	"github.com/Code-Linx/wallet-service/internal/models"
 	"github.com/Code-Linx/wallet-service/internal/repositories"
 
@@ -22,20 +25,232 @@ var (
 	ErrTransactionExists = errors.New("transaction with this reference already exists")
 	ErrWalletNotFound    = errors.New("wallet not found")
 	ErrSameUser          = errors.New("cannot transfer to the same user")
+	ErrRecipientNotFound = errors.New("recipient not found")
+	ErrWalletExists      = errors.New("wallet already exists for this currency")
+
+	ErrPocketExists      = errors.New("a pocket with this name already exists in this currency")
+	ErrInvalidPocketName = errors.New("pocket name must be between 1 and 64 characters")
+	ErrSameWallet        = errors.New("cannot move funds to the same wallet")
+
+	ErrInvalidSplit = errors.New("split shares must all be exact amounts or all percentages adding up to 100%, each paying a different recipient")
+
+	ErrWalletFrozen         = errors.New("wallet is frozen; money cannot leave it")
+	ErrWalletSuspended      = errors.New("wallet is suspended")
+	ErrWalletClosed         = errors.New("wallet is closed")
+	ErrWalletNotEmpty       = errors.New("wallet still holds funds; empty it or give a wallet to sweep them to")
+	ErrPocketsNotEmpty      = errors.New("pockets in this currency still hold funds; empty or close them before closing the primary wallet")
+	ErrInvalidWalletStatus  = errors.New("invalid wallet status change")
+	ErrStatusReasonRequired = errors.New("a reason and an actor are required to change wallet status")
+
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
+	ErrCurrencyMismatch    = errors.New("currencies do not match; a conversion must be requested explicitly")
+
+	ErrHoldNotFound  = errors.New("hold not found")
+	ErrHoldNotActive = errors.New("hold is no longer active")
+	ErrHoldExpired   = errors.New("hold has expired")
+
+	ErrTransactionNotFound      = errors.New("transaction not found")
+	ErrTransactionNotRefundable = errors.New("transaction cannot be refunded")
+	ErrRefundExceedsRemaining   = errors.New("refund exceeds the amount left to refund")
+
+	ErrSameCurrency    = errors.New("cannot convert a currency into itself")
+	ErrRateUnavailable = errors.New("exchange rate unavailable")
+	ErrQuoteNotFound   = errors.New("quote not found")
+	ErrQuoteExpired    = errors.New("quote has expired")
+	ErrQuoteUsed       = errors.New("quote has already been used")
+	ErrQuoteMismatch   = errors.New("conversion does not match the quote")
+
+	ErrFeeRuleNotFound = errors.New("fee rule not found")
+	ErrInvalidFeeRule  = errors.New("invalid fee rule")
+	ErrNoFeesForType   = errors.New("fees are not charged on this transaction type")
+
+	ErrLimitExceeded     = errors.New("transaction limit exceeded")
+	ErrLimitRuleNotFound = errors.New("limit rule not found")
+	ErrInvalidLimitRule  = errors.New("invalid limit rule")
+
+	ErrScheduledTransferNotFound = errors.New("scheduled transfer not found")
+	ErrScheduledTransferClosed   = errors.New("scheduled transfer has already finished")
+	ErrInvalidSchedule           = errors.New("invalid schedule")
+
+	ErrPayoutBatchNotFound = errors.New("payout batch not found")
+	ErrInvalidPayoutBatch  = errors.New("invalid payout batch")
+
+	ErrEscrowNotFound       = errors.New("escrow not found")
+	ErrEscrowNotPending     = errors.New("escrow is no longer pending")
+	ErrEscrowExpired        = errors.New("escrow has timed out")
+	ErrEscrowNotDisputed    = errors.New("escrow is not disputed")
+	ErrEscrowForbidden      = errors.New("this user cannot do that to the escrow")
+	ErrEscrowReasonRequired = errors.New("a reason is required")
+	ErrInvalidResolution    = errors.New("resolution must be release or refund")
+
+	ErrPaymentRequestNotFound   = errors.New("payment request not found")
+	ErrPaymentRequestNotPending = errors.New("payment request has already been answered")
+	ErrPaymentRequestExpired    = errors.New("payment request has expired")
+	ErrPaymentRequestForbidden  = errors.New("only the payer can answer a payment request")
+	ErrMemoTooLong              = errors.New("memo must be at most 255 characters")
+
+	ErrInvalidPromoExpiry = errors.New("promotional credit must expire in the future")
+
+	ErrInvalidCreditLine     = errors.New("credit limit and overdraft rate must not be negative, and pockets cannot have a credit line")
+	ErrCreditLimitBelowUsage = errors.New("credit limit cannot be set below the amount already overdrawn")
+
+	ErrVoucherNotFound      = errors.New("voucher not found")
+	ErrVoucherExpired       = errors.New("voucher has expired")
+	ErrVoucherRedeemed      = errors.New("voucher has already been redeemed as many times as allowed")
+	ErrVoucherBatchNotFound = errors.New("voucher batch not found")
+	ErrInvalidVoucherBatch  = errors.New("invalid voucher batch")
+
+	ErrInterestRuleNotFound = errors.New("interest rule not found")
+	ErrInvalidInterestRule  = errors.New("invalid interest rule")
+
+	ErrAPIKeyNotFound     = errors.New("API key not found")
+	ErrAPIKeyRevoked      = errors.New("API key has been revoked")
+	ErrAPIKeyExpired      = errors.New("API key has expired")
+	ErrAPIKeyIPNotAllowed = errors.New("API key cannot be used from this IP address")
+	ErrInvalidAPIKey      = errors.New("API keys need a name of at most 64 characters, known scopes, valid IPs or CIDR ranges and a future expiry")
+
+	ErrInvalidAdjustment       = errors.New("adjustments need a direction of credit or debit, a reason, evidence and a reference")
+	ErrAdjustmentNotFound      = errors.New("adjustment not found")
+	ErrAdjustmentNotPending    = errors.New("adjustment has already been decided")
+	ErrSelfApproval            = errors.New("adjustments must be reviewed by someone other than the admin who proposed them")
+	ErrAdjustmentReviewed      = errors.New("this admin has already approved the adjustment")
+	ErrAdjustmentActorRequired = errors.New("an admin must be named to propose or review an adjustment")
 )
 
 // UserUseCase interface
 type UserUseCase interface {
 	CreateUser(name, email string) (*models.User, error)
 	GetUserByID(id uuid.UUID) (*models.User, error)
+	SetMerchant(id uuid.UUID, isMerchant bool) (*models.User, error)
 }
 
 // WalletUseCase interface
 type WalletUseCase interface {
-	FundWallet(userID uuid.UUID, amount int64, reference string) (*models.Transaction, error)
-	WithdrawFunds(userID uuid.UUID, amount int64, reference string) (*models.Transaction, error)
-	TransferFunds(fromUserID, toUserID uuid.UUID, amount int64, reference string) (*models.Transaction, error)
+	OpenWallet(userID uuid.UUID, currencyCode string) (*models.Wallet, error)
+	ListWallets(userID uuid.UUID) ([]models.Wallet, error)
+	FundWallet(userID uuid.UUID, amount int64, currencyCode, reference string) (*models.Transaction, error)
+	WithdrawFunds(userID uuid.UUID, amount int64, currencyCode, reference string) (*models.Transaction, error)
+	TransferFunds(fromUserID, toUserID uuid.UUID, amount int64, currencyCode, reference string) (*models.Transaction, error)
+	SplitPayment(fromUserID uuid.UUID, amount int64, currencyCode string, shares []SplitShare, reference string) (*models.Transaction, error)
 	GetTransactionHistory(userID uuid.UUID, page, pageSize int) ([]models.Transaction, int64, error)
+	GetWalletTransactionHistory(userID, walletID uuid.UUID, page, pageSize int) ([]models.Transaction, int64, error)
+	CreatePocket(userID uuid.UUID, name, currencyCode string) (*models.Wallet, error)
+	MoveFunds(userID, fromWalletID, toWalletID uuid.UUID, amount int64, reference string) (*models.Transaction, error)
+	PlaceHold(userID uuid.UUID, amount int64, currencyCode string, ttl time.Duration, reference string) (*models.Hold, error)
+	CaptureHold(userID, holdID uuid.UUID, amount int64) (*models.Transaction, error)
+	VoidHold(userID, holdID uuid.UUID) (*models.Hold, error)
+	GetHold(userID, holdID uuid.UUID) (*models.Hold, error)
+	ExpireHolds() (int, error)
+	RefundTransaction(transactionID uuid.UUID, amount int64, reference string) (*models.Transaction, error)
+	ReverseTransaction(transactionID uuid.UUID, reference string) (*models.Transaction, error)
+	QuoteConversion(userID uuid.UUID, amount int64, fromCurrency, toCurrency string) (*models.FXQuote, error)
+	ConvertFunds(userID uuid.UUID, amount int64, fromCurrency, toCurrency string, quoteID *uuid.UUID, reference string) (*models.Transaction, error)
+	CreateEscrow(fromUserID, toUserID uuid.UUID, amount int64, currencyCode string, timeout time.Duration, reference string) (*models.Transaction, error)
+	GetEscrow(userID, transactionID uuid.UUID) (*models.Transaction, error)
+	ReleaseEscrow(userID, transactionID uuid.UUID) (*models.Transaction, error)
+	CancelEscrow(userID, transactionID uuid.UUID) (*models.Transaction, error)
+	DisputeEscrow(userID, transactionID uuid.UUID, reason string) (*models.Transaction, error)
+	ResolveEscrow(transactionID uuid.UUID, resolution models.EscrowResolution, reason, actor string) (*models.Transaction, error)
+	ExpireEscrows() (int, error)
+	ChangeWalletStatus(walletID uuid.UUID, status models.WalletStatus, reason, actor string, sweepToWalletID *uuid.UUID) (*models.Wallet, error)
+	ListWalletStatusChanges(walletID uuid.UUID) ([]models.WalletStatusChange, error)
+	SetCreditLine(walletID uuid.UUID, creditLimit, overdraftRateBps int64) (*models.Wallet, error)
+	ChargeOverdraftInterest() (int, error)
+}
+
+// FeeUseCase interface
+type FeeUseCase interface {
+	CreateRule(rule *models.FeeRule) (*models.FeeRule, error)
+	ListRules() ([]models.FeeRule, error)
+	DeleteRule(id uuid.UUID) error
+	QuoteFee(userID uuid.UUID, transactionType models.TransactionType, amount int64, currencyCode string) (*models.FeeQuote, error)
+}
+
+// LimitUseCase interface
+type LimitUseCase interface {
+	CreateRule(rule *models.LimitRule) (*models.LimitRule, error)
+	ListRules() ([]models.LimitRule, error)
+	DeleteRule(id uuid.UUID) error
+}
+
+// ScheduledTransferUseCase interface
+type ScheduledTransferUseCase interface {
+	Create(transfer *models.ScheduledTransfer, startAt time.Time) (*models.ScheduledTransfer, error)
+	List(userID uuid.UUID) ([]models.ScheduledTransfer, error)
+	Get(userID, transferID uuid.UUID) (*models.ScheduledTransfer, error)
+	Update(userID, transferID uuid.UUID, update ScheduledTransferUpdate) (*models.ScheduledTransfer, error)
+	Cancel(userID, transferID uuid.UUID) (*models.ScheduledTransfer, error)
+	ListRuns(userID, transferID uuid.UUID) ([]models.ScheduledTransferRun, error)
+	RunDue() (int, error)
+}
+
+// PayoutUseCase interface
+type PayoutUseCase interface {
+	CreateBatch(batch *models.PayoutBatch) (*models.PayoutBatch, error)
+	GetBatch(userID, batchID uuid.UUID) (*models.PayoutBatch, error)
+	ListBatches(userID uuid.UUID) ([]models.PayoutBatch, error)
+	ProcessBatch(batchID uuid.UUID) (*models.PayoutBatch, error)
+	ProcessPendingBatches() (int, error)
+}
+
+// PaymentRequestUseCase interface
+type PaymentRequestUseCase interface {
+	Create(request *models.PaymentRequest, ttl time.Duration) (*models.PaymentRequest, error)
+	Get(userID, requestID uuid.UUID) (*models.PaymentRequest, error)
+	ListIncoming(userID uuid.UUID, status models.PaymentRequestStatus) ([]models.PaymentRequest, error)
+	ListOutgoing(userID uuid.UUID, status models.PaymentRequestStatus) ([]models.PaymentRequest, error)
+	Accept(userID, requestID uuid.UUID) (*models.PaymentRequest, error)
+	Decline(userID, requestID uuid.UUID) (*models.PaymentRequest, error)
+	ExpirePending() (int, error)
+}
+
+// PromoUseCase interface
+type PromoUseCase interface {
+	Grant(userID uuid.UUID, amount int64, currencyCode string, expiresAt time.Time, description, reference string) (*models.PromoBucket, error)
+	ListBuckets(userID uuid.UUID, currencyCode string) ([]models.PromoBucket, error)
+	ExpireBuckets() (int, error)
+}
+
+// VoucherUseCase interface
+type VoucherUseCase interface {
+	GenerateBatch(batch *models.VoucherBatch, count int) (*models.VoucherBatch, error)
+	GetBatch(batchID uuid.UUID) (*models.VoucherBatch, error)
+	ListBatches() ([]models.VoucherBatch, error)
+	Redeem(userID uuid.UUID, code string) (*models.Transaction, error)
+}
+
+// InterestUseCase interface
+type InterestUseCase interface {
+	CreateRule(rule *models.InterestRateRule) (*models.InterestRateRule, error)
+	ListRules() ([]models.InterestRateRule, error)
+	DeleteRule(id uuid.UUID) error
+	ListAccruals(userID, walletID uuid.UUID) ([]models.InterestAccrual, error)
+	AccrueDaily() (int, error)
+	PayMonthly() (int, error)
+}
+
+// APIKeyUseCase interface
+type APIKeyUseCase interface {
+	Create(key *models.APIKey) (*models.APIKey, error)
+	List() ([]models.APIKey, error)
+	Revoke(id uuid.UUID) (*models.APIKey, error)
+	Authenticate(rawKey, clientIP string) (*models.APIKey, error)
+}
+
+// AdminUseCase interface
+type AdminUseCase interface {
+	SearchUsers(query string, page, pageSize int) ([]models.User, int64, error)
+	RecordAction(entry *models.AuditLogEntry) error
+	ListAuditLog(filter repositories.AuditLogFilter, page, pageSize int) ([]models.AuditLogEntry, int64, error)
+}
+
+// AdjustmentUseCase interface
+type AdjustmentUseCase interface {
+	Propose(adjustment *models.BalanceAdjustment) (*models.BalanceAdjustment, error)
+	Approve(id uuid.UUID, actor, comment string) (*models.BalanceAdjustment, error)
+	Reject(id uuid.UUID, actor, comment string) (*models.BalanceAdjustment, error)
+	Get(id uuid.UUID) (*models.BalanceAdjustment, error)
+	List(status models.AdjustmentStatus) ([]models.BalanceAdjustment, error)
 }
 
 // ReconciliationUseCase interface
@@ -47,6 +262,17 @@ type ReconciliationUseCase interface {
 type UseCases struct {
 	User           UserUseCase
 	Wallet         WalletUseCase
+	Fee            FeeUseCase
+	Limit          LimitUseCase
+	Scheduled      ScheduledTransferUseCase
+	Payout         PayoutUseCase
+	PaymentRequest PaymentRequestUseCase
+	Promo          PromoUseCase
+	Voucher        VoucherUseCase
+	Interest       InterestUseCase
+	APIKey         APIKeyUseCase
+	Admin          AdminUseCase
+	Adjustment     AdjustmentUseCase
 	Reconciliation ReconciliationUseCase
 }
 
@@ -58,6 +284,78 @@ type userUseCase struct {
 // walletUseCase implements WalletUseCase
 type walletUseCase struct {
 	repos *repositories.Repositories
+	rates fx.RateProvider
+	clock clock.Clock
+}
+
+// feeUseCase implements FeeUseCase
+type feeUseCase struct {
+	repos *repositories.Repositories
+}
+
+// limitUseCase implements LimitUseCase
+type limitUseCase struct {
+	repos *repositories.Repositories
+}
+
+// scheduledTransferUseCase implements ScheduledTransferUseCase
+type scheduledTransferUseCase struct {
+	repos  *repositories.Repositories
+	wallet WalletUseCase
+	clock  clock.Clock
+	retry  RetryPolicy
+}
+
+// payoutUseCase implements PayoutUseCase
+type payoutUseCase struct {
+	repos  *repositories.Repositories
+	wallet *walletUseCase
+	clock  clock.Clock
+}
+
+// paymentRequestUseCase implements PaymentRequestUseCase
+type paymentRequestUseCase struct {
+	repos  *repositories.Repositories
+	wallet *walletUseCase
+	clock  clock.Clock
+}
+
+// promoUseCase implements PromoUseCase
+type promoUseCase struct {
+	repos *repositories.Repositories
+	clock clock.Clock
+}
+
+// voucherUseCase implements VoucherUseCase
+type voucherUseCase struct {
+	repos  *repositories.Repositories
+	wallet *walletUseCase
+	clock  clock.Clock
+}
+
+// interestUseCase implements InterestUseCase
+type interestUseCase struct {
+	repos *repositories.Repositories
+	clock clock.Clock
+}
+
+// apiKeyUseCase implements APIKeyUseCase
+type apiKeyUseCase struct {
+	repos *repositories.Repositories
+	clock clock.Clock
+}
+
+// adminUseCase implements AdminUseCase
+type adminUseCase struct {
+	repos *repositories.Repositories
+	clock clock.Clock
+}
+
+// adjustmentUseCase implements AdjustmentUseCase
+type adjustmentUseCase struct {
+	repos    *repositories.Repositories
+	clock    clock.Clock
+	approval ApprovalPolicy
 }
 
 // reconciliationUseCase implements ReconciliationUseCase
@@ -66,10 +364,28 @@ type reconciliationUseCase struct {
 }
 
 // NewUseCases creates new use case instances
-func NewUseCases(repos *repositories.Repositories) *UseCases {
+func NewUseCases(repos *repositories.Repositories, opts ...Option) *UseCases {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	wallet := &walletUseCase{repos: repos, rates: o.rates, clock: o.clock}
+
 	return &UseCases{
 		User:           &userUseCase{repos: repos},
-		Wallet:         &walletUseCase{repos: repos},
+		Wallet:         wallet,
+		Fee:            &feeUseCase{repos: repos},
+		Limit:          &limitUseCase{repos: repos},
+		Scheduled:      &scheduledTransferUseCase{repos: repos, wallet: wallet, clock: o.clock, retry: o.retry},
+		Payout:         &payoutUseCase{repos: repos, wallet: wallet, clock: o.clock},
+		PaymentRequest: &paymentRequestUseCase{repos: repos, wallet: wallet, clock: o.clock},
+		Promo:          &promoUseCase{repos: repos, clock: o.clock},
+		Voucher:        &voucherUseCase{repos: repos, wallet: wallet, clock: o.clock},
+		Interest:       &interestUseCase{repos: repos, clock: o.clock},
+		APIKey:         &apiKeyUseCase{repos: repos, clock: o.clock},
+		Admin:          &adminUseCase{repos: repos, clock: o.clock},
+		Adjustment:     &adjustmentUseCase{repos: repos, clock: o.clock, approval: o.approval},
 		Reconciliation: &reconciliationUseCase{repos: repos},
 	}
 }
@@ -100,6 +416,7 @@ func (uc *userUseCase) CreateUser(name, email string) (*models.User, error) {
 	user := &models.User{
 		Name:  name,
 		Email: email,
+		Tier:  models.UserTierStandard,
 	}
 
 	if err := txRepos.User.Create(user); err != nil {
@@ -109,8 +426,11 @@ func (uc *userUseCase) CreateUser(name, email string) (*models.User, error) {
 
 	// Create wallet for user
 	wallet := &models.Wallet{
-		UserID:  user.ID,
-		Balance: 0,
+		UserID:    user.ID,
+		Currency:  currency.Default,
+		Balance:   0,
+		Status:    models.WalletStatusActive,
+		IsPrimary: true,
 	}
 
 	if err := txRepos.Wallet.Create(wallet); err != nil {
@@ -118,6 +438,12 @@ func (uc *userUseCase) CreateUser(name, email string) (*models.User, error) {
 		return nil, fmt.Errorf("failed to create wallet: %w", err)
 	}
 
+	// Open the ledger account backing the wallet
+	if _, err := walletAccount(txRepos, wallet); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
 	// Commit transaction
 	if err := tx.Commit().Error; err != nil {
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
@@ -138,13 +464,31 @@ func (uc *userUseCase) GetUserByID(id uuid.UUID) (*models.User, error) {
 	return user, nil
 }
 
+// SetMerchant marks whether a user is a merchant, and so whether
+// promotional credit can be spent on transfers to them
+func (uc *userUseCase) SetMerchant(id uuid.UUID, isMerchant bool) (*models.User, error) {
+	if _, err := uc.GetUserByID(id); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repos.User.UpdateMerchant(id, isMerchant); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+	return uc.GetUserByID(id)
+}
+
 // Wallet Use Case Implementation
 
-func (uc *walletUseCase) FundWallet(userID uuid.UUID, amount int64, reference string) (*models.Transaction, error) {
+func (uc *walletUseCase) FundWallet(userID uuid.UUID, amount int64, currencyCode, reference string) (*models.Transaction, error) {
 	if amount <= 0 {
 		return nil, ErrInvalidAmount
 	}
 
+	currencyCode, err := resolveCurrency(currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
 	// Check if transaction already exists (idempotency)
 	existingTxn, err := uc.repos.Transaction.GetByReference(reference)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
@@ -163,6 +507,26 @@ func (uc *walletUseCase) FundWallet(userID uuid.UUID, amount int64, reference st
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
+	transaction, err := uc.fund(user, amount, currencyCode, reference)
+	if err != nil {
+		uc.recordFailure(&models.Transaction{
+			UserID:      userID,
+			Type:        models.TransactionTypeCredit,
+			Amount:      amount,
+			Currency:    currencyCode,
+			Description: "Wallet funding",
+			Reference:   reference,
+		}, err)
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// fund credits the user's wallet in its own database transaction
+func (uc *walletUseCase) fund(user *models.User, amount int64, currencyCode, reference string) (*models.Transaction, error) {
+	userID := user.ID
+
 	// Start transaction
 	tx := uc.repos.BeginTransaction()
 	defer func() {
@@ -173,50 +537,103 @@ func (uc *walletUseCase) FundWallet(userID uuid.UUID, amount int64, reference st
 
 	txRepos := uc.repos.WithTransaction(tx)
 
-	// Get current wallet
-	wallet, err := txRepos.Wallet.GetByUserID(userID)
+	// Get current wallet, locked until commit
+	wallet, err := txRepos.Wallet.GetByUserIDAndCurrencyForUpdate(userID, currencyCode)
 	if err != nil {
 		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWalletNotFound
+		}
 		return nil, fmt.Errorf("failed to get wallet: %w", err)
 	}
 
+	transaction, err := uc.credit(txRepos, user, wallet, amount, reference, "Wallet funding")
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	// Load transaction with user data
+	transaction.User = *user
+	return transaction, nil
+}
+
+// credit posts a credit of amount from the funding source into a wallet the
+// caller has locked, inside the caller's database transaction; the caller
+// rolls back on error
+func (uc *walletUseCase) credit(txRepos *repositories.Repositories, user *models.User, wallet *models.Wallet, amount int64, reference, description string) (*models.Transaction, error) {
+	if err := checkCanCredit(wallet); err != nil {
+		return nil, err
+	}
+
+	if err := checkLimits(txRepos, user, models.TransactionTypeCredit, amount, wallet.Currency, uc.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	// The fee comes out of the wallet once the funds have arrived
+	fee, _, err := feeFor(txRepos, user, models.TransactionTypeCredit, amount, wallet.Currency)
+	if err != nil {
+		return nil, err
+	}
+	if wallet.Available()+amount < fee {
+		return nil, ErrInsufficientFunds
+	}
+
 	// Create transaction record
 	transaction := &models.Transaction{
-		UserID:      userID,
+		UserID:      user.ID,
 		Type:        models.TransactionTypeCredit,
 		Amount:      amount,
-		Description: "Wallet funding",
+		Currency:    wallet.Currency,
+		Description: description,
 		Status:      models.TransactionStatusCompleted,
 		Reference:   reference,
+		FeeAmount:   fee,
 	}
 
 	if err := txRepos.Transaction.Create(transaction); err != nil {
-		tx.Rollback()
 		return nil, fmt.Errorf("failed to create transaction: %w", err)
 	}
 
-	// Update wallet balance
-	newBalance := wallet.Balance + amount
-	if err := txRepos.Wallet.UpdateBalance(userID, newBalance); err != nil {
-		tx.Rollback()
-		return nil, fmt.Errorf("failed to update wallet balance: %w", err)
+	// Post the journal: money comes in from the funding source
+	source, err := systemAccount(txRepos, models.AccountCodeFundingSource, wallet.Currency)
+	if err != nil {
+		return nil, err
 	}
 
-	// Commit transaction
-	if err := tx.Commit().Error; err != nil {
-		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	account, err := walletAccount(txRepos, wallet)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := postJournal(txRepos, transaction, posting{debit: source, credit: account, amount: amount}); err != nil {
+		return nil, err
+	}
+
+	if fee > 0 {
+		if err := chargeFee(txRepos, transaction, wallet, fee); err != nil {
+			return nil, err
+		}
 	}
 
-	// Load transaction with user data
-	transaction.User = *user
 	return transaction, nil
 }
 
-func (uc *walletUseCase) WithdrawFunds(userID uuid.UUID, amount int64, reference string) (*models.Transaction, error) {
+func (uc *walletUseCase) WithdrawFunds(userID uuid.UUID, amount int64, currencyCode, reference string) (*models.Transaction, error) {
 	if amount <= 0 {
 		return nil, ErrInvalidAmount
 	}
 
+	currencyCode, err := resolveCurrency(currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
 	// Check if transaction already exists (idempotency)
 	existingTxn, err := uc.repos.Transaction.GetByReference(reference)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
@@ -235,6 +652,26 @@ func (uc *walletUseCase) WithdrawFunds(userID uuid.UUID, amount int64, reference
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
+	transaction, err := uc.withdraw(user, amount, currencyCode, reference)
+	if err != nil {
+		uc.recordFailure(&models.Transaction{
+			UserID:      userID,
+			Type:        models.TransactionTypeDebit,
+			Amount:      amount,
+			Currency:    currencyCode,
+			Description: "Wallet withdrawal",
+			Reference:   reference,
+		}, err)
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// withdraw debits the user's wallet in its own database transaction
+func (uc *walletUseCase) withdraw(user *models.User, amount int64, currencyCode, reference string) (*models.Transaction, error) {
+	userID := user.ID
+
 	// Start transaction
 	tx := uc.repos.BeginTransaction()
 	defer func() {
@@ -245,15 +682,35 @@ func (uc *walletUseCase) WithdrawFunds(userID uuid.UUID, amount int64, reference
 
 	txRepos := uc.repos.WithTransaction(tx)
 
-	// Get current wallet
-	wallet, err := txRepos.Wallet.GetByUserID(userID)
+	// Get current wallet, locked until commit
+	wallet, err := txRepos.Wallet.GetByUserIDAndCurrencyForUpdate(userID, currencyCode)
 	if err != nil {
 		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWalletNotFound
+		}
 		return nil, fmt.Errorf("failed to get wallet: %w", err)
 	}
 
-	// Check sufficient funds
-	if wallet.Balance < amount {
+	if err := checkCanDebit(wallet); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := checkLimits(txRepos, user, models.TransactionTypeDebit, amount, currencyCode, uc.clock.Now()); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	fee, _, err := feeFor(txRepos, user, models.TransactionTypeDebit, amount, currencyCode)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Check sufficient funds, leaving reserved money untouched; a credit
+	// line lets the wallet go negative
+	if wallet.Spendable() < amount+fee {
 		tx.Rollback()
 		return nil, ErrInsufficientFunds
 	}
@@ -263,9 +720,11 @@ func (uc *walletUseCase) WithdrawFunds(userID uuid.UUID, amount int64, reference
 		UserID:      userID,
 		Type:        models.TransactionTypeDebit,
 		Amount:      amount,
+		Currency:    currencyCode,
 		Description: "Wallet withdrawal",
 		Status:      models.TransactionStatusCompleted,
 		Reference:   reference,
+		FeeAmount:   fee,
 	}
 
 	if err := txRepos.Transaction.Create(transaction); err != nil {
@@ -273,11 +732,29 @@ func (uc *walletUseCase) WithdrawFunds(userID uuid.UUID, amount int64, reference
 		return nil, fmt.Errorf("failed to create transaction: %w", err)
 	}
 
-	// Update wallet balance
-	newBalance := wallet.Balance - amount
-	if err := txRepos.Wallet.UpdateBalance(userID, newBalance); err != nil {
+	// Post the journal: money leaves through the withdrawal clearing account
+	account, err := walletAccount(txRepos, wallet)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	clearing, err := systemAccount(txRepos, models.AccountCodeWithdrawalClearing, currencyCode)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := postJournal(txRepos, transaction, posting{debit: account, credit: clearing, amount: amount}); err != nil {
 		tx.Rollback()
-		return nil, fmt.Errorf("failed to update wallet balance: %w", err)
+		return nil, err
+	}
+
+	if fee > 0 {
+		if err := chargeFee(txRepos, transaction, wallet, fee); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
 	}
 
 	// Commit transaction
@@ -290,11 +767,16 @@ func (uc *walletUseCase) WithdrawFunds(userID uuid.UUID, amount int64, reference
 	return transaction, nil
 }
 
-func (uc *walletUseCase) TransferFunds(fromUserID, toUserID uuid.UUID, amount int64, reference string) (*models.Transaction, error) {
+func (uc *walletUseCase) TransferFunds(fromUserID, toUserID uuid.UUID, amount int64, currencyCode, reference string) (*models.Transaction, error) {
 	if amount <= 0 {
 		return nil, ErrInvalidAmount
 	}
 
+	currencyCode, err := resolveCurrency(currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
 	if fromUserID == toUserID {
 		return nil, ErrSameUser
 	}
@@ -312,18 +794,33 @@ func (uc *walletUseCase) TransferFunds(fromUserID, toUserID uuid.UUID, amount in
 	fromUser, err := uc.repos.User.GetByID(fromUserID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("sender not found")
+			return nil, ErrUserNotFound
 		}
 		return nil, fmt.Errorf("failed to get sender: %w", err)
 	}
 
+	// Record failed attempts against the sender. An unknown recipient has no
+	// user row to point at, so it is only named in the description.
+	attempt := &models.Transaction{
+		UserID:      fromUserID,
+		Type:        models.TransactionTypeTransfer,
+		Amount:      amount,
+		Currency:    currencyCode,
+		Description: fmt.Sprintf("Transfer to %s", toUserID),
+		Reference:   reference,
+		FromUserID:  &fromUserID,
+	}
+
 	toUser, err := uc.repos.User.GetByID(toUserID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, fmt.Errorf("recipient not found")
+			uc.recordFailure(attempt, ErrRecipientNotFound)
+			return nil, ErrRecipientNotFound
 		}
 		return nil, fmt.Errorf("failed to get recipient: %w", err)
 	}
+	attempt.Description = fmt.Sprintf("Transfer to %s", toUser.Name)
+	attempt.ToUserID = &toUserID
 
 	// Start transaction
 	tx := uc.repos.BeginTransaction()
@@ -335,70 +832,159 @@ func (uc *walletUseCase) TransferFunds(fromUserID, toUserID uuid.UUID, amount in
 
 	txRepos := uc.repos.WithTransaction(tx)
 
-	// Get sender's wallet
-	fromWallet, err := txRepos.Wallet.GetByUserID(fromUserID)
+	transaction, err := uc.transfer(txRepos, fromUser, toUser, amount, currencyCode, reference, nil, nil)
 	if err != nil {
 		tx.Rollback()
-		return nil, fmt.Errorf("failed to get sender wallet: %w", err)
+		uc.recordFailure(attempt, err)
+		return nil, err
 	}
 
-	// Check sufficient funds
-	if fromWallet.Balance < amount {
-		tx.Rollback()
-		return nil, ErrInsufficientFunds
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	// Get recipient's wallet
-	toWallet, err := txRepos.Wallet.GetByUserID(toUserID)
+	// Load transaction with user data
+	transaction.User = *fromUser
+	transaction.FromUser = fromUser
+	transaction.ToUser = toUser
+	return transaction, nil
+}
+
+// transfer moves funds between two users inside the caller's database
+// transaction; the caller rolls back on error. When escrowUntil is set the
+// funds go into escrow until then instead of reaching the recipient. A
+// parentID marks the transfer as one leg of a split payment.
+func (uc *walletUseCase) transfer(txRepos *repositories.Repositories, fromUser, toUser *models.User, amount int64, currencyCode, reference string, escrowUntil *time.Time, parentID *uuid.UUID) (*models.Transaction, error) {
+	// Both sides must hold a wallet in the transfer currency; moving money
+	// across currencies needs an explicit conversion
+	fromWallet, err := txRepos.Wallet.GetByUserIDAndCurrency(fromUser.ID, currencyCode)
 	if err != nil {
-		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWalletNotFound
+		}
+		return nil, fmt.Errorf("failed to get sender wallet: %w", err)
+	}
+
+	toWallet, err := txRepos.Wallet.GetByUserIDAndCurrency(toUser.ID, currencyCode)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCurrencyMismatch
+		}
 		return nil, fmt.Errorf("failed to get recipient wallet: %w", err)
 	}
 
+	// Lock both wallets until commit
+	wallets, err := lockWallets(txRepos, fromWallet, toWallet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallets: %w", err)
+	}
+	fromWallet, toWallet = wallets[fromWallet.ID], wallets[toWallet.ID]
+
+	if err := checkCanDebit(fromWallet); err != nil {
+		return nil, err
+	}
+	if err := checkCanCredit(toWallet); err != nil {
+		return nil, err
+	}
+
+	if err := checkLimits(txRepos, fromUser, models.TransactionTypeTransfer, amount, currencyCode, uc.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	// The sender pays any fee on top of the amount sent
+	fee, _, err := feeFor(txRepos, fromUser, models.TransactionTypeTransfer, amount, currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	// Paying a merchant spends promotional credit before cash; the fee is
+	// always paid in cash
+	var promoBuckets []models.PromoBucket
+	var promoAmount int64
+	if toUser.IsMerchant && escrowUntil == nil {
+		promoBuckets, promoAmount, err = spendablePromo(txRepos, fromWallet, amount, uc.clock.Now())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Check sufficient funds, leaving reserved money untouched; a credit
+	// line lets the wallet go negative
+	if fromWallet.Spendable() < amount-promoAmount+fee {
+		return nil, ErrInsufficientFunds
+	}
+
 	// Create transaction record
 	transaction := &models.Transaction{
-		UserID:      fromUserID,
+		UserID:      fromUser.ID,
 		Type:        models.TransactionTypeTransfer,
 		Amount:      amount,
+		Currency:    currencyCode,
 		Description: fmt.Sprintf("Transfer to %s", toUser.Name),
 		Status:      models.TransactionStatusCompleted,
 		Reference:   reference,
-		FromUserID:  &fromUserID,
-		ToUserID:    &toUserID,
+		FromUserID:  &fromUser.ID,
+		ToUserID:    &toUser.ID,
+		FeeAmount:   fee,
+		PromoAmount: promoAmount,
+
+		ParentTransactionID: parentID,
+	}
+	if escrowUntil != nil {
+		transaction.Type = models.TransactionTypeEscrow
+		transaction.Description = fmt.Sprintf("Escrow for %s", toUser.Name)
+		transaction.Status = models.TransactionStatusPending
+		transaction.ExpiresAt = escrowUntil
 	}
 
 	if err := txRepos.Transaction.Create(transaction); err != nil {
-		tx.Rollback()
 		return nil, fmt.Errorf("failed to create transaction: %w", err)
 	}
 
-	// Update sender's wallet balance
-	newFromBalance := fromWallet.Balance - amount
-	if err := txRepos.Wallet.UpdateBalance(fromUserID, newFromBalance); err != nil {
-		tx.Rollback()
-		return nil, fmt.Errorf("failed to update sender wallet balance: %w", err)
+	// Post the journal: debit the sender's wallet and credit the recipient's,
+	// or the escrow account until the escrow is settled
+	fromAccount, err := walletAccount(txRepos, fromWallet)
+	if err != nil {
+		return nil, err
 	}
 
-	// Update recipient's wallet balance
-	newToBalance := toWallet.Balance + amount
-	if err := txRepos.Wallet.UpdateBalance(toUserID, newToBalance); err != nil {
-		tx.Rollback()
-		return nil, fmt.Errorf("failed to update recipient wallet balance: %w", err)
+	var toAccount *models.LedgerAccount
+	if escrowUntil != nil {
+		toAccount, err = systemAccount(txRepos, models.AccountCodeEscrow, currencyCode)
+	} else {
+		toAccount, err = walletAccount(txRepos, toWallet)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	// Commit transaction
-	if err := tx.Commit().Error; err != nil {
-		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	if err := postJournal(txRepos, transaction, posting{debit: fromAccount, credit: toAccount, amount: amount}); err != nil {
+		return nil, err
+	}
+
+	if promoAmount > 0 {
+		if err := spendPromo(txRepos, transaction, fromWallet, promoBuckets, promoAmount); err != nil {
+			return nil, err
+		}
+	}
+
+	if fee > 0 {
+		if err := chargeFee(txRepos, transaction, fromWallet, fee); err != nil {
+			return nil, err
+		}
 	}
 
-	// Load transaction with user data
-	transaction.User = *fromUser
-	transaction.FromUser = fromUser
-	transaction.ToUser = toUser
 	return transaction, nil
 }
 
 func (uc *walletUseCase) GetTransactionHistory(userID uuid.UUID, page, pageSize int) ([]models.Transaction, int64, error) {
+	limit, offset := pageBounds(page, pageSize)
+	return uc.repos.Transaction.GetByUserID(userID, limit, offset)
+}
+
+// pageBounds turns a page number and size into a limit and offset
+func pageBounds(page, pageSize int) (int, int) {
 	// Validate page and pageSize
 	if page < 1 {
 		page = 1
@@ -410,8 +996,82 @@ func (uc *walletUseCase) GetTransactionHistory(userID uuid.UUID, page, pageSize
 		pageSize = 100
 	}
 
-	offset := (page - 1) * pageSize
-	return uc.repos.Transaction.GetByUserID(userID, pageSize, offset)
+	return pageSize, (page - 1) * pageSize
+}
+
+func (uc *walletUseCase) OpenWallet(userID uuid.UUID, currencyCode string) (*models.Wallet, error) {
+	currencyCode, err := resolveCurrency(currencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if user exists
+	if _, err := uc.repos.User.GetByID(userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// A user holds at most one wallet per currency
+	existingWallet, err := uc.repos.Wallet.GetByUserIDAndCurrency(userID, currencyCode)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check existing wallet: %w", err)
+	}
+	if existingWallet != nil {
+		if existingWallet.Status == models.WalletStatusClosed {
+			return nil, ErrWalletClosed
+		}
+		return nil, ErrWalletExists
+	}
+
+	// Start transaction
+	tx := uc.repos.BeginTransaction()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	txRepos := uc.repos.WithTransaction(tx)
+
+	wallet := &models.Wallet{
+		UserID:    userID,
+		Currency:  currencyCode,
+		Balance:   0,
+		Status:    models.WalletStatusActive,
+		IsPrimary: true,
+	}
+
+	if err := txRepos.Wallet.Create(wallet); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to create wallet: %w", err)
+	}
+
+	// Open the ledger account backing the wallet
+	if _, err := walletAccount(txRepos, wallet); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return uc.repos.Wallet.GetByID(wallet.ID)
+}
+
+func (uc *walletUseCase) ListWallets(userID uuid.UUID) ([]models.Wallet, error) {
+	// Check if user exists
+	if _, err := uc.repos.User.GetByID(userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return uc.repos.Wallet.ListByUserID(userID)
 }
 
 // Reconciliation Use Case Implementation
@@ -425,24 +1085,75 @@ func (uc *reconciliationUseCase) RunReconciliation() ([]models.ReconciliationRes
 		return nil, fmt.Errorf("failed to get wallets: %w", err)
 	}
 
+	// Transactions record whose money moved but not which of the user's
+	// wallets held it, so the transaction total for a currency covers the
+	// primary wallet and its pockets together
+	pocketBalances := make(map[string]int64)
+	for _, wallet := range wallets {
+		if !wallet.IsPrimary {
+			pocketBalances[wallet.UserID.String()+wallet.Currency] += wallet.Balance
+		}
+	}
+
 	var results []models.ReconciliationResult
 
 	for _, wallet := range wallets {
-		// Calculate actual balance from transactions
-		calculatedBalance, err := uc.repos.Transaction.GetUserTransactionSum(wallet.UserID)
+		// Derive the balance from the wallet's ledger entries
+		ledgerBalance, err := walletLedgerBalance(uc.repos, wallet.ID)
+		if err != nil {
+			log.Printf("Failed to calculate ledger balance for user %s: %v", wallet.UserID, err)
+			continue
+		}
+
+		// Calculate actual balance from transactions; pockets only appear
+		// in the ledger
+		calculatedBalance := ledgerBalance
+		if wallet.IsPrimary {
+			sum, err := uc.repos.Transaction.GetUserTransactionSum(wallet.UserID, wallet.Currency)
+			if err != nil {
+				log.Printf("Failed to calculate balance for user %s: %v", wallet.UserID, err)
+				continue
+			}
+			calculatedBalance = sum - pocketBalances[wallet.UserID.String()+wallet.Currency]
+		}
+
+		// The promotional part of the balance must match the buckets behind it
+		bucketBalance, err := uc.repos.Promo.SumActive(wallet.ID)
 		if err != nil {
-			log.Printf("Failed to calculate balance for user %s: %v", wallet.UserID, err)
+			log.Printf("Failed to calculate promo balance for user %s: %v", wallet.UserID, err)
 			continue
 		}
 
+		// A negative balance is fine as long as the credit line covers it.
+		// Overdraft interest may take a wallet past its limit, but only by
+		// the interest charged since it got there; anything more is a
+		// mismatch.
+		overLimit := wallet.Overdrawn() > wallet.CreditLimit
+		unexplainedOverrun := false
+		if overLimit {
+			interest, err := interestOverLimit(uc.repos, &wallet)
+			if err != nil {
+				log.Printf("Failed to calculate interest over limit for user %s: %v", wallet.UserID, err)
+				continue
+			}
+			unexplainedOverrun = wallet.Overdrawn() > wallet.CreditLimit+interest
+		}
+
 		// Compare with stored balance
 		difference := wallet.Balance - calculatedBalance
-		hasMismatch := difference != 0
+		hasMismatch := difference != 0 || wallet.Balance != ledgerBalance || wallet.PromoBalance != bucketBalance || unexplainedOverrun
 
 		result := models.ReconciliationResult{
 			UserID:            wallet.UserID,
+			WalletID:          wallet.ID,
+			Currency:          wallet.Currency,
 			StoredBalance:     wallet.Balance,
+			PromoBalance:      wallet.PromoBalance,
+			BucketBalance:     bucketBalance,
+			CreditLimit:       wallet.CreditLimit,
+			OverLimit:         overLimit,
 			CalculatedBalance: calculatedBalance,
+			LedgerBalance:     ledgerBalance,
 			Difference:        difference,
 			HasMismatch:       hasMismatch,
 			CheckedAt:         time.Now(),
@@ -452,8 +1163,8 @@ func (uc *reconciliationUseCase) RunReconciliation() ([]models.ReconciliationRes
 
 		// Log mismatches
 		if hasMismatch {
-			log.Printf("MISMATCH DETECTED - User: %s, Stored: %d, Calculated: %d, Difference: %d",
-				wallet.UserID, wallet.Balance, calculatedBalance, difference)
+			log.Printf("MISMATCH DETECTED - User: %s, Currency: %s, Stored: %d, Calculated: %d, Ledger: %d, Difference: %d, Promo: %d, Buckets: %d, Credit limit: %d",
+				wallet.UserID, wallet.Currency, wallet.Balance, calculatedBalance, ledgerBalance, difference, wallet.PromoBalance, bucketBalance, wallet.CreditLimit)
 		}
 	}
 
@@ -473,3 +1184,15 @@ func countMismatches(results []models.ReconciliationResult) int {
 	}
 	return count
 }
+
+// resolveCurrency normalizes an ISO 4217 code, falling back to the default currency when empty
+func resolveCurrency(code string) (string, error) {
+	if code == "" {
+		return currency.Default, nil
+	}
+	c, ok := currency.Lookup(code)
+	if !ok {
+		return "", ErrUnsupportedCurrency
+	}
+	return c.Code, nil
+}
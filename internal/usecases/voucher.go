@@ -0,0 +1,257 @@
+package usecases
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// MaxVoucherBatchSize caps how many codes one batch generates
+	MaxVoucherBatchSize = 1000
+
+	// voucherCodeAlphabet is Crockford's base32, which leaves out letters
+	// that are easily misread as digits
+	voucherCodeAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	// voucherCodeLength is the number of random characters in a code, 80 bits
+	voucherCodeLength = 16
+	// voucherCodeGroup is how many characters sit between dashes
+	voucherCodeGroup = 4
+	// voucherHintLength is how much of the end of a code is kept in the clear
+	voucherHintLength = 4
+)
+
+// Voucher Use Case Implementation
+
+// GenerateBatch creates count vouchers with the batch's value, currency,
+// expiry and redemption limit. The codes are only in the returned batch and
+// cannot be recovered later.
+func (uc *voucherUseCase) GenerateBatch(batch *models.VoucherBatch, count int) (*models.VoucherBatch, error) {
+	if batch.Amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+	if count < 1 || count > MaxVoucherBatchSize || batch.MaxRedemptions < 0 {
+		return nil, ErrInvalidVoucherBatch
+	}
+	if !batch.ExpiresAt.After(uc.clock.Now()) {
+		return nil, ErrInvalidVoucherBatch
+	}
+	if batch.MaxRedemptions == 0 {
+		batch.MaxRedemptions = 1
+	}
+
+	code, err := resolveCurrency(batch.Currency)
+	if err != nil {
+		return nil, err
+	}
+	batch.Currency = code
+	batch.Count = count
+
+	batch.Vouchers = make([]models.Voucher, count)
+	for i := range batch.Vouchers {
+		code, err := generateVoucherCode()
+		if err != nil {
+			return nil, err
+		}
+		normalized := normalizeVoucherCode(code)
+		batch.Vouchers[i] = models.Voucher{
+			Code:           code,
+			CodeHash:       hashVoucherCode(normalized),
+			CodeHint:       normalized[len(normalized)-voucherHintLength:],
+			Amount:         batch.Amount,
+			Currency:       batch.Currency,
+			MaxRedemptions: batch.MaxRedemptions,
+			Status:         models.VoucherStatusActive,
+			ExpiresAt:      batch.ExpiresAt,
+		}
+	}
+
+	if err := uc.repos.Voucher.CreateBatch(batch); err != nil {
+		return nil, fmt.Errorf("failed to create voucher batch: %w", err)
+	}
+	return batch, nil
+}
+
+func (uc *voucherUseCase) GetBatch(batchID uuid.UUID) (*models.VoucherBatch, error) {
+	batch, err := uc.repos.Voucher.GetBatchByID(batchID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVoucherBatchNotFound
+		}
+		return nil, fmt.Errorf("failed to get voucher batch: %w", err)
+	}
+	return batch, nil
+}
+
+func (uc *voucherUseCase) ListBatches() ([]models.VoucherBatch, error) {
+	batches, err := uc.repos.Voucher.ListBatches()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list voucher batches: %w", err)
+	}
+	return batches, nil
+}
+
+// Redeem credits the user's wallet with the voucher's amount. The credit
+// uses the reference voucher:{voucher_id}:{user_id}, so redeeming the same
+// code twice returns the first credit.
+func (uc *voucherUseCase) Redeem(userID uuid.UUID, code string) (*models.Transaction, error) {
+	voucher, err := uc.repos.Voucher.GetByCodeHash(hashVoucherCode(normalizeVoucherCode(code)))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVoucherNotFound
+		}
+		return nil, fmt.Errorf("failed to get voucher: %w", err)
+	}
+
+	reference := fmt.Sprintf("voucher:%s:%s", voucher.ID, userID)
+
+	// Check if transaction already exists (idempotency)
+	existingTxn, err := uc.repos.Transaction.GetByReference(reference)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check existing transaction: %w", err)
+	}
+	if existingTxn != nil {
+		return existingTxn, nil // Return existing transaction
+	}
+
+	user, err := uc.repos.User.GetByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// Start transaction
+	tx := uc.repos.BeginTransaction()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	txRepos := uc.repos.WithTransaction(tx)
+
+	// Lock the wallet, then the voucher, so concurrent redemptions of the
+	// same code queue on the voucher and see each other's count
+	wallet, err := txRepos.Wallet.GetByUserIDAndCurrencyForUpdate(userID, voucher.Currency)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWalletNotFound
+		}
+		return nil, fmt.Errorf("failed to get wallet: %w", err)
+	}
+
+	voucher, err = txRepos.Voucher.GetByIDForUpdate(voucher.ID)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to get voucher: %w", err)
+	}
+
+	// A concurrent request by the same user may have redeemed it meanwhile
+	existingTxn, err = txRepos.Transaction.GetByReference(reference)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to check existing transaction: %w", err)
+	}
+	if existingTxn != nil {
+		tx.Rollback()
+		return existingTxn, nil
+	}
+
+	if !uc.clock.Now().Before(voucher.ExpiresAt) {
+		tx.Rollback()
+		return nil, ErrVoucherExpired
+	}
+	if voucher.Redemptions >= voucher.MaxRedemptions {
+		tx.Rollback()
+		return nil, ErrVoucherRedeemed
+	}
+
+	transaction, err := uc.wallet.credit(txRepos, user, wallet, voucher.Amount, reference, "Voucher redemption")
+	if err != nil {
+		tx.Rollback()
+		uc.wallet.recordFailure(&models.Transaction{
+			UserID:      userID,
+			Type:        models.TransactionTypeCredit,
+			Amount:      voucher.Amount,
+			Currency:    voucher.Currency,
+			Description: "Voucher redemption",
+			Reference:   reference,
+		}, err)
+		return nil, err
+	}
+
+	voucher.Redemptions++
+	if voucher.Redemptions >= voucher.MaxRedemptions {
+		voucher.Status = models.VoucherStatusExhausted
+	}
+	if err := txRepos.Voucher.Update(voucher); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to update voucher: %w", err)
+	}
+
+	if err := txRepos.Voucher.CreateRedemption(&models.VoucherRedemption{
+		VoucherID:     voucher.ID,
+		UserID:        userID,
+		TransactionID: transaction.ID,
+	}); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to record redemption: %w", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	transaction.User = *user
+	return transaction, nil
+}
+
+// generateVoucherCode returns a new random code in groups, e.g.
+// 7KQ2-M9XD-4HTP-C1VZ
+func generateVoucherCode() (string, error) {
+	random := make([]byte, voucherCodeLength)
+	if _, err := rand.Read(random); err != nil {
+		return "", fmt.Errorf("failed to generate voucher code: %w", err)
+	}
+
+	var code strings.Builder
+	for i, b := range random {
+		if i > 0 && i%voucherCodeGroup == 0 {
+			code.WriteByte('-')
+		}
+		// 256 is a multiple of 32, so every character is equally likely
+		code.WriteByte(voucherCodeAlphabet[int(b)%len(voucherCodeAlphabet)])
+	}
+	return code.String(), nil
+}
+
+// normalizeVoucherCode makes codes typed by hand match the generated form
+func normalizeVoucherCode(code string) string {
+	code = strings.ToUpper(code)
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, code)
+}
+
+// hashVoucherCode returns the hex SHA-256 of a normalized code. The codes
+// carry 80 random bits, so an unsalted hash is enough to keep them unguessable
+// while still finding a voucher by its code.
+func hashVoucherCode(normalized string) string {
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,267 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// walletTransitions lists the statuses each status may change to. Closed
+// is final.
+var walletTransitions = map[models.WalletStatus][]models.WalletStatus{
+	models.WalletStatusActive:    {models.WalletStatusFrozen, models.WalletStatusSuspended, models.WalletStatusClosed},
+	models.WalletStatusFrozen:    {models.WalletStatusActive, models.WalletStatusSuspended, models.WalletStatusClosed},
+	models.WalletStatusSuspended: {models.WalletStatusActive, models.WalletStatusFrozen, models.WalletStatusClosed},
+}
+
+// Wallet Status Use Case Implementation
+
+// ChangeWalletStatus moves a wallet to a new status on behalf of actor.
+// Closing needs an empty wallet, or a wallet to sweep the balance into.
+func (uc *walletUseCase) ChangeWalletStatus(walletID uuid.UUID, status models.WalletStatus, reason, actor string, sweepToWalletID *uuid.UUID) (*models.Wallet, error) {
+	reason, actor = strings.TrimSpace(reason), strings.TrimSpace(actor)
+	if reason == "" || actor == "" {
+		return nil, ErrStatusReasonRequired
+	}
+	if sweepToWalletID != nil && (status != models.WalletStatusClosed || *sweepToWalletID == walletID) {
+		return nil, ErrInvalidWalletStatus
+	}
+
+	wallet, err := uc.repos.Wallet.GetByID(walletID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWalletNotFound
+		}
+		return nil, fmt.Errorf("failed to get wallet: %w", err)
+	}
+
+	// Start transaction
+	tx := uc.repos.BeginTransaction()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	txRepos := uc.repos.WithTransaction(tx)
+
+	toLock := []*models.Wallet{wallet}
+	if sweepToWalletID != nil {
+		target, err := txRepos.Wallet.GetByID(*sweepToWalletID)
+		if err != nil {
+			tx.Rollback()
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrWalletNotFound
+			}
+			return nil, fmt.Errorf("failed to get sweep wallet: %w", err)
+		}
+		toLock = append(toLock, target)
+	}
+
+	wallets, err := lockWallets(txRepos, toLock...)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to get wallets: %w", err)
+	}
+	wallet = wallets[walletID]
+
+	if wallet.Status == models.WalletStatusClosed {
+		tx.Rollback()
+		return nil, ErrWalletClosed
+	}
+	if !canTransition(wallet.Status, status) {
+		tx.Rollback()
+		return nil, ErrInvalidWalletStatus
+	}
+
+	change := &models.WalletStatusChange{
+		WalletID:   wallet.ID,
+		FromStatus: wallet.Status,
+		ToStatus:   status,
+		Reason:     reason,
+		Actor:      actor,
+	}
+
+	if status == models.WalletStatusClosed {
+		// Pockets can only be emptied through the primary wallet, so a
+		// closed primary would strand the money in them
+		if wallet.IsPrimary {
+			if err := checkPocketsEmpty(txRepos, wallet); err != nil {
+				tx.Rollback()
+				return nil, err
+			}
+		}
+
+		// Promotional credit is not the owner's to take along
+		if wallet.PromoBalance > 0 {
+			forfeited, err := forfeitPromo(txRepos, wallet)
+			if err != nil {
+				tx.Rollback()
+				return nil, err
+			}
+			wallet.Balance -= forfeited
+			wallet.PromoBalance -= forfeited
+		}
+
+		// Reserved money belongs to pending captures and cannot be swept
+		if wallet.HeldBalance != 0 || wallet.Balance < 0 {
+			tx.Rollback()
+			return nil, ErrWalletNotEmpty
+		}
+		if wallet.Balance > 0 {
+			if sweepToWalletID == nil {
+				tx.Rollback()
+				return nil, ErrWalletNotEmpty
+			}
+			sweep, err := uc.sweep(txRepos, wallet, wallets[*sweepToWalletID])
+			if err != nil {
+				tx.Rollback()
+				return nil, err
+			}
+			change.SweepTransactionID = &sweep.ID
+		}
+	}
+
+	if err := txRepos.Wallet.UpdateStatus(wallet.ID, status, reason); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to update wallet status: %w", err)
+	}
+
+	if err := txRepos.Wallet.CreateStatusChange(change); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to record status change: %w", err)
+	}
+
+	wallet, err = txRepos.Wallet.GetByID(wallet.ID)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to get wallet: %w", err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit().Error; err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return wallet, nil
+}
+
+// ListWalletStatusChanges returns a wallet's status history, oldest first
+func (uc *walletUseCase) ListWalletStatusChanges(walletID uuid.UUID) ([]models.WalletStatusChange, error) {
+	if _, err := uc.repos.Wallet.GetByID(walletID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWalletNotFound
+		}
+		return nil, fmt.Errorf("failed to get wallet: %w", err)
+	}
+
+	changes, err := uc.repos.Wallet.ListStatusChanges(walletID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list status changes: %w", err)
+	}
+	return changes, nil
+}
+
+// sweep moves the whole balance of a closing wallet into another wallet of
+// the same currency. Fees and limits do not apply.
+func (uc *walletUseCase) sweep(txRepos *repositories.Repositories, wallet, target *models.Wallet) (*models.Transaction, error) {
+	if target.Currency != wallet.Currency {
+		return nil, ErrCurrencyMismatch
+	}
+	if err := checkCanCredit(target); err != nil {
+		return nil, err
+	}
+
+	// Sweeping into another of the owner's wallets is a move, not a transfer
+	transactionType := models.TransactionTypeTransfer
+	if target.UserID == wallet.UserID {
+		transactionType = models.TransactionTypeMove
+	}
+
+	transaction := &models.Transaction{
+		UserID:      wallet.UserID,
+		Type:        transactionType,
+		Amount:      wallet.Balance,
+		Currency:    wallet.Currency,
+		Description: fmt.Sprintf("Closing balance swept to wallet %s", target.ID),
+		Status:      models.TransactionStatusCompleted,
+		Reference:   fmt.Sprintf("close:%s", wallet.ID),
+		FromUserID:  &wallet.UserID,
+		ToUserID:    &target.UserID,
+	}
+
+	if err := txRepos.Transaction.Create(transaction); err != nil {
+		return nil, fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	fromAccount, err := walletAccount(txRepos, wallet)
+	if err != nil {
+		return nil, err
+	}
+	toAccount, err := walletAccount(txRepos, target)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := postJournal(txRepos, transaction, posting{debit: fromAccount, credit: toAccount, amount: wallet.Balance}); err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+// checkPocketsEmpty returns ErrPocketsNotEmpty if any open pocket beside
+// the primary wallet still holds money
+func checkPocketsEmpty(txRepos *repositories.Repositories, primary *models.Wallet) error {
+	wallets, err := txRepos.Wallet.ListByUserID(primary.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to list wallets: %w", err)
+	}
+	for _, pocket := range wallets {
+		if pocket.IsPrimary || pocket.Currency != primary.Currency || pocket.Status == models.WalletStatusClosed {
+			continue
+		}
+		if pocket.Balance != 0 || pocket.HeldBalance != 0 || pocket.PromoBalance != 0 {
+			return ErrPocketsNotEmpty
+		}
+	}
+	return nil
+}
+
+// checkCanDebit returns why money may not leave the wallet, if it may not
+func checkCanDebit(wallet *models.Wallet) error {
+	switch wallet.Status {
+	case models.WalletStatusFrozen:
+		return ErrWalletFrozen
+	case models.WalletStatusSuspended:
+		return ErrWalletSuspended
+	case models.WalletStatusClosed:
+		return ErrWalletClosed
+	}
+	return nil
+}
+
+// checkCanCredit returns why money may not enter the wallet, if it may not
+func checkCanCredit(wallet *models.Wallet) error {
+	switch wallet.Status {
+	case models.WalletStatusSuspended:
+		return ErrWalletSuspended
+	case models.WalletStatusClosed:
+		return ErrWalletClosed
+	}
+	return nil
+}
+
+func canTransition(from, to models.WalletStatus) bool {
+	for _, allowed := range walletTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
@@ -1,7 +1,9 @@
 package database
 
 import (
+	"fmt"
 	"log"
+	"time"
 
 	"github.com/Code-Linx/wallet-service/internal/config"
 	"github.com/Code-Linx/wallet-service/internal/models"
@@ -50,17 +52,153 @@ func AutoMigrate(db *gorm.DB) error {
 	err := db.AutoMigrate(
 		&models.User{},
 		&models.Wallet{},
+		&models.WalletStatusChange{},
 		&models.Transaction{},
+		&models.EscrowEvent{},
+		&models.LedgerAccount{},
+		&models.LedgerEntry{},
+		&models.Hold{},
+		&models.FXQuote{},
+		&models.FXConversion{},
+		&models.FeeRule{},
+		&models.LimitRule{},
+		&models.ScheduledTransfer{},
+		&models.ScheduledTransferRun{},
+		&models.PayoutBatch{},
+		&models.PayoutItem{},
+		&models.PaymentRequest{},
+		&models.PromoBucket{},
+		&models.PromoSpend{},
+		&models.VoucherBatch{},
+		&models.Voucher{},
+		&models.VoucherRedemption{},
+		&models.InterestRateRule{},
+		&models.InterestAccrual{},
+		&models.APIKey{},
+		&models.AuditLogEntry{},
+		&models.BalanceAdjustment{},
+		&models.AdjustmentDecision{},
 	)
 
 	if err != nil {
 		return err
 	}
 
+	// Wallets used to be unique per user and currency, which left no room
+	// for pockets. Wallets from before pockets existed are primary.
+	if db.Migrator().HasIndex(&models.Wallet{}, "idx_wallets_user_currency") {
+		if err := db.Migrator().DropIndex(&models.Wallet{}, "idx_wallets_user_currency"); err != nil {
+			return err
+		}
+	}
+	if err := db.Model(&models.Wallet{}).Where("name = ? AND is_primary = ?", "", false).Update("is_primary", true).Error; err != nil {
+		return err
+	}
+
+	// Transactions completed before status timestamps existed take their
+	// creation time
+	if err := db.Model(&models.Transaction{}).Where("status = ? AND completed_at IS NULL", models.TransactionStatusCompleted).
+		Update("completed_at", gorm.Expr("created_at")).Error; err != nil {
+		return err
+	}
+
+	if err := backfillOpeningBalances(db); err != nil {
+		return err
+	}
+
 	log.Println("Database migrations completed successfully")
 	return nil
 }
 
+// backfillOpeningBalances brings wallets from before the ledger onto it.
+// Their balances were never posted, so each one gets an opening journal from
+// the opening balances account for whatever it holds. The journal's
+// reference is derived from the wallet, which makes the backfill safe to run
+// on every start.
+func backfillOpeningBalances(db *gorm.DB) error {
+	posted := db.Model(&models.LedgerEntry{}).
+		Select("ledger_accounts.wallet_id").
+		Joins("JOIN ledger_accounts ON ledger_accounts.id IN (ledger_entries.debit_account_id, ledger_entries.credit_account_id)").
+		Where("ledger_accounts.wallet_id IS NOT NULL")
+
+	var wallets []models.Wallet
+	if err := db.Where("balance <> 0 AND id NOT IN (?)", posted).Find(&wallets).Error; err != nil {
+		return err
+	}
+
+	for _, wallet := range wallets {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			return postOpeningBalance(tx, &wallet)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to post opening balance for wallet %s: %w", wallet.ID, err)
+		}
+	}
+
+	if len(wallets) > 0 {
+		log.Printf("Posted opening balances for %d wallets", len(wallets))
+	}
+	return nil
+}
+
+// postOpeningBalance writes the opening journal for one wallet. The balance
+// is already on the wallet, so only the ledger side is written.
+func postOpeningBalance(tx *gorm.DB, wallet *models.Wallet) error {
+	walletID := wallet.ID
+	walletAccount := models.LedgerAccount{
+		Code:     models.WalletAccountCode(walletID),
+		Type:     models.AccountTypeUserWallet,
+		Currency: wallet.Currency,
+		WalletID: &walletID,
+	}
+	if err := tx.Where("code = ?", walletAccount.Code).FirstOrCreate(&walletAccount).Error; err != nil {
+		return err
+	}
+
+	openingAccount := models.LedgerAccount{
+		Code:     fmt.Sprintf("%s:%s", models.AccountCodeOpeningBalances, wallet.Currency),
+		Type:     models.AccountTypeOpeningBalances,
+		Currency: wallet.Currency,
+	}
+	if err := tx.Where("code = ?", openingAccount.Code).FirstOrCreate(&openingAccount).Error; err != nil {
+		return err
+	}
+
+	// The wallet's history already accounts for the balance, so the journal
+	// is recorded against a transaction that reconciliation does not count
+	now := time.Now()
+	transaction := models.Transaction{
+		UserID:      wallet.UserID,
+		Type:        models.TransactionTypeOpeningBalance,
+		Amount:      wallet.Balance,
+		Currency:    wallet.Currency,
+		Description: "Opening balance carried onto the ledger",
+		Status:      models.TransactionStatusCompleted,
+		Reference:   fmt.Sprintf("opening_balance:%s", walletID),
+		CompletedAt: &now,
+	}
+
+	// A wallet in credit is owed by the opening balances account; an
+	// overdrawn one owes it
+	entry := models.LedgerEntry{
+		DebitAccountID:  openingAccount.ID,
+		CreditAccountID: walletAccount.ID,
+		Amount:          wallet.Balance,
+		Currency:        wallet.Currency,
+	}
+	if wallet.Balance < 0 {
+		transaction.Amount = -wallet.Balance
+		entry.DebitAccountID, entry.CreditAccountID = walletAccount.ID, openingAccount.ID
+		entry.Amount = -wallet.Balance
+	}
+
+	if err := tx.Create(&transaction).Error; err != nil {
+		return err
+	}
+	entry.TransactionID = transaction.ID
+	return tx.Create(&entry).Error
+}
+
 // TestConnection tests the database connection
 func TestConnection(db *gorm.DB) error {
 	sqlDB, err := db.DB()
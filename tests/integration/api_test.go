@@ -9,7 +9,10 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/Code-Linx/wallet-service/internal/auth"
+	"github.com/Code-Linx/wallet-service/internal/clock"
 	"github.com/Code-Linx/wallet-service/internal/config"
 	"github.com/Code-Linx/wallet-service/internal/handlers"
 	"github.com/Code-Linx/wallet-service/internal/repositories"
@@ -25,9 +28,10 @@ import (
 
 type APITestSuite struct {
 	suite.Suite
-	router  *gin.Engine
-	db      *gorm.DB
-	cleanup func()
+	router       http.Handler
+	db           *gorm.DB
+	cleanup      func()
+	financeToken string // For the admin routes
 }
 
 func (suite *APITestSuite) SetupSuite() {
@@ -55,11 +59,51 @@ func (suite *APITestSuite) SetupSuite() {
 	// Init app
 	repos := repositories.NewRepositories(db)
 	useCases := usecases.NewUseCases(repos)
-	handlersInstance := handlers.NewHandlers(useCases)
-	suite.router = handlersInstance.SetupRouter(handlersInstance)
+	keys, err := auth.NewKeyring(cfg.App.JWTKeyID, cfg.App.JWTSecret, cfg.App.JWTPreviousKeys)
+	suite.Require().NoError(err)
+	tokens := auth.NewTokens(keys, cfg.App.JWTAccessTTL, cfg.App.JWTRefreshTTL, clock.Real())
+	handlersInstance := handlers.NewHandlers(useCases, tokens, nil)
+	engine := handlersInstance.SetupRouter(handlersInstance)
+
+	// The suite calls the API as a backend service, which may act for any user
+	serviceToken, err := tokens.Sign("integration-tests", auth.RoleService, auth.TokenTypeAccess, time.Hour)
+	suite.Require().NoError(err)
+	suite.financeToken, err = tokens.Sign("fin@example.com", auth.RoleFinance, auth.TokenTypeAccess, time.Hour)
+	suite.Require().NoError(err)
+	suite.router = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			r.Header.Set("Authorization", "Bearer "+serviceToken)
+		}
+		engine.ServeHTTP(w, r)
+	})
 
 	// Cleanup setup
 	suite.cleanup = func() {
+		db.Exec("DELETE FROM adjustment_decisions")
+		db.Exec("DELETE FROM balance_adjustments")
+		db.Exec("DELETE FROM audit_log_entries")
+		db.Exec("DELETE FROM api_keys")
+		db.Exec("DELETE FROM interest_accruals")
+		db.Exec("DELETE FROM interest_rate_rules")
+		db.Exec("DELETE FROM voucher_redemptions")
+		db.Exec("DELETE FROM vouchers")
+		db.Exec("DELETE FROM voucher_batches")
+		db.Exec("DELETE FROM promo_spends")
+		db.Exec("DELETE FROM promo_buckets")
+		db.Exec("DELETE FROM payment_requests")
+		db.Exec("DELETE FROM escrow_events")
+		db.Exec("DELETE FROM wallet_status_changes")
+		db.Exec("DELETE FROM payout_items")
+		db.Exec("DELETE FROM payout_batches")
+		db.Exec("DELETE FROM scheduled_transfer_runs")
+		db.Exec("DELETE FROM scheduled_transfers")
+		db.Exec("DELETE FROM limit_rules")
+		db.Exec("DELETE FROM fee_rules")
+		db.Exec("DELETE FROM fx_conversions")
+		db.Exec("DELETE FROM fx_quotes")
+		db.Exec("DELETE FROM holds")
+		db.Exec("DELETE FROM ledger_entries")
+		db.Exec("DELETE FROM ledger_accounts")
 		db.Exec("DELETE FROM transactions")
 		db.Exec("DELETE FROM wallets")
 		db.Exec("DELETE FROM users")
@@ -215,7 +259,8 @@ func (suite *APITestSuite) TestFullWalletFlow() {
 	assert.True(suite.T(), historyResponse.Success)
 
 	// Step 8: Run reconciliation
-	req, _ = http.NewRequest("POST", "/api/v1/reconciliation/run", nil)
+	req, _ = http.NewRequest("POST", "/api/v1/admin/reconciliation/run", nil)
+	req.Header.Set("Authorization", "Bearer "+suite.financeToken)
 	resp = httptest.NewRecorder()
 	suite.router.ServeHTTP(resp, req)
 	assert.Equal(suite.T(), http.StatusOK, resp.Code)
@@ -0,0 +1,116 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Code-Linx/wallet-service/internal/handlers"
+	"github.com/Code-Linx/wallet-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *APITestSuite) TestConcurrentWithdrawalsAndTransfers() {
+	const (
+		userCount     = 5
+		initialFunds  = 10000
+		operations    = 400
+		withdrawValue = 100
+		transferValue = 150
+	)
+
+	// Create and fund the users taking part
+	userIDs := make([]string, userCount)
+	for i := range userIDs {
+		userIDs[i] = suite.createTestUser(fmt.Sprintf("Stress User %d", i), fmt.Sprintf("stress%d@example.com", i))
+		fundPayload := map[string]interface{}{
+			"amount":    initialFunds,
+			"reference": fmt.Sprintf("stress_fund_%d", i),
+		}
+		suite.makeWalletRequest("POST", fmt.Sprintf("/api/v1/users/%s/wallet/fund", userIDs[i]), fundPayload, http.StatusOK)
+	}
+
+	// Fire withdrawals and transfers in both directions at the same wallets
+	var withdrawn int64
+	var wg sync.WaitGroup
+	for i := 0; i < operations; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			from := userIDs[i%userCount]
+			var resp *httptest.ResponseRecorder
+			if i%2 == 0 {
+				resp = suite.postJSON(fmt.Sprintf("/api/v1/users/%s/wallet/withdraw", from), map[string]interface{}{
+					"amount":    withdrawValue,
+					"reference": fmt.Sprintf("stress_withdraw_%d", i),
+				})
+				if resp.Code == http.StatusOK {
+					atomic.AddInt64(&withdrawn, withdrawValue)
+				}
+			} else {
+				to := userIDs[(i+i/userCount+1)%userCount]
+				if to == from {
+					to = userIDs[(i+1)%userCount]
+				}
+				resp = suite.postJSON(fmt.Sprintf("/api/v1/users/%s/wallet/transfer", from), map[string]interface{}{
+					"to_user_id": to,
+					"amount":     transferValue,
+					"reference":  fmt.Sprintf("stress_transfer_%d", i),
+				})
+			}
+
+			// Running out of funds is expected; anything else is a failure
+			if resp.Code != http.StatusOK && resp.Code != http.StatusBadRequest {
+				suite.T().Errorf("operation %d failed with status %d: %s", i, resp.Code, resp.Body.String())
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// No money may be created or lost: what is left is what went in minus what was withdrawn
+	var total int64
+	for _, userID := range userIDs {
+		wallet := suite.getUser(userID)["wallet"].(map[string]interface{})
+		balance := int64(wallet["balance"].(float64))
+		assert.GreaterOrEqual(suite.T(), balance, int64(0))
+		total += balance
+	}
+	assert.Equal(suite.T(), int64(userCount*initialFunds)-withdrawn, total)
+
+	// Stored balances must still agree with the transactions and the ledger
+	req, _ := http.NewRequest("POST", "/api/v1/admin/reconciliation/run", nil)
+	req.Header.Set("Authorization", "Bearer "+suite.financeToken)
+	resp := httptest.NewRecorder()
+	suite.router.ServeHTTP(resp, req)
+	assert.Equal(suite.T(), http.StatusOK, resp.Code)
+
+	var response struct {
+		handlers.APIResponse
+		Data []models.ReconciliationResult `json:"data"`
+	}
+	err := json.Unmarshal(resp.Body.Bytes(), &response)
+	assert.NoError(suite.T(), err)
+	for _, result := range response.Data {
+		assert.False(suite.T(), result.HasMismatch, "reconciliation mismatch for user %s", result.UserID)
+	}
+}
+
+// postJSON sends a JSON request without asserting on the response status
+func (suite *APITestSuite) postJSON(url string, payload map[string]interface{}) *httptest.ResponseRecorder {
+	var body bytes.Buffer
+	if payload != nil {
+		json.NewEncoder(&body).Encode(payload)
+	}
+	req, _ := http.NewRequest("POST", url, &body)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp := httptest.NewRecorder()
+	suite.router.ServeHTTP(resp, req)
+	return resp
+}
@@ -0,0 +1,172 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdjustment_ApprovalPostsAndRejectionDoesNot(t *testing.T) {
+	useCases, repos := setupUseCases()
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(alice.ID, 1000, "USD", "adjust_fund")
+	require.NoError(t, err)
+
+	proposal := func(direction models.AdjustmentDirection, amount int64, reference string) *models.BalanceAdjustment {
+		return &models.BalanceAdjustment{
+			WalletID:   alice.Wallet.ID,
+			Direction:  direction,
+			Amount:     amount,
+			Reason:     "Missed deposit",
+			Evidence:   "Bank statement 2024-03",
+			Reference:  reference,
+			ProposedBy: "fay@example.com",
+		}
+	}
+
+	credit, err := useCases.Adjustment.Propose(proposal(models.AdjustmentDirectionCredit, 250, "adjust_credit"))
+	require.NoError(t, err)
+	assert.Equal(t, models.AdjustmentStatusPending, credit.Status)
+	assert.Equal(t, 1, credit.RequiredApprovals)
+	assert.Equal(t, "USD", credit.Currency)
+
+	// Proposing does not move money, and retrying returns the same proposal
+	again, err := useCases.Adjustment.Propose(proposal(models.AdjustmentDirectionCredit, 250, "adjust_credit"))
+	require.NoError(t, err)
+	assert.Equal(t, credit.ID, again.ID)
+	wallet, err := repos.Wallet.GetByID(alice.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), wallet.Balance)
+
+	// The proposer cannot approve their own adjustment
+	_, err = useCases.Adjustment.Approve(credit.ID, "fay@example.com", "")
+	assert.Equal(t, usecases.ErrSelfApproval, err)
+
+	approved, err := useCases.Adjustment.Approve(credit.ID, "sam@example.com", "Matches the statement")
+	require.NoError(t, err)
+	assert.Equal(t, models.AdjustmentStatusApproved, approved.Status)
+	require.NotNil(t, approved.TransactionID)
+	require.NotNil(t, approved.DecidedAt)
+
+	txn, err := repos.Transaction.GetByID(*approved.TransactionID)
+	require.NoError(t, err)
+	assert.Equal(t, models.TransactionTypeAdjustment, txn.Type)
+	assert.Equal(t, "adjust_credit", txn.Reference)
+
+	_, err = useCases.Adjustment.Approve(credit.ID, "lee@example.com", "")
+	assert.Equal(t, usecases.ErrAdjustmentNotPending, err)
+
+	// A rejected debit never reaches the ledger
+	debit, err := useCases.Adjustment.Propose(proposal(models.AdjustmentDirectionDebit, 100, "adjust_debit"))
+	require.NoError(t, err)
+	rejected, err := useCases.Adjustment.Reject(debit.ID, "sam@example.com", "No evidence of a duplicate")
+	require.NoError(t, err)
+	assert.Equal(t, models.AdjustmentStatusRejected, rejected.Status)
+	assert.Nil(t, rejected.TransactionID)
+	_, err = repos.Transaction.GetByReference("adjust_debit")
+	assert.Error(t, err)
+
+	// An approval that cannot be posted leaves the adjustment pending
+	overdraw, err := useCases.Adjustment.Propose(proposal(models.AdjustmentDirectionDebit, 5000, "adjust_overdraw"))
+	require.NoError(t, err)
+	_, err = useCases.Adjustment.Approve(overdraw.ID, "sam@example.com", "")
+	assert.Equal(t, usecases.ErrInsufficientFunds, err)
+	stored, err := useCases.Adjustment.Get(overdraw.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.AdjustmentStatusPending, stored.Status)
+	assert.Empty(t, stored.Decisions)
+
+	// Evidence is required, and references already used by a transaction are refused
+	missing := proposal(models.AdjustmentDirectionCredit, 100, "adjust_missing")
+	missing.Evidence = " "
+	_, err = useCases.Adjustment.Propose(missing)
+	assert.Equal(t, usecases.ErrInvalidAdjustment, err)
+	_, err = useCases.Adjustment.Propose(proposal(models.AdjustmentDirectionCredit, 100, "adjust_fund"))
+	assert.Equal(t, usecases.ErrTransactionExists, err)
+
+	wallet, err = repos.Wallet.GetByID(alice.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1250), wallet.Balance)
+
+	// Adjustments keep the ledger and the transaction history in step
+	results, err := useCases.Reconciliation.RunReconciliation()
+	require.NoError(t, err)
+	for _, result := range results {
+		assert.False(t, result.HasMismatch, "wallet %s", result.WalletID)
+	}
+}
+
+func TestAdjustment_AboveThresholdNeedsTwoApprovers(t *testing.T) {
+	useCases, repos := setupUseCases(usecases.WithApprovalPolicy(usecases.ApprovalPolicy{
+		DualApprovalThreshold: 10000,
+		Thresholds:            map[string]int64{"EUR": 500},
+	}))
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	eur, err := useCases.Wallet.OpenWallet(alice.ID, "EUR")
+	require.NoError(t, err)
+
+	propose := func(walletID uuid.UUID, reference string, amount int64) *models.BalanceAdjustment {
+		proposed, err := useCases.Adjustment.Propose(&models.BalanceAdjustment{
+			WalletID:   walletID,
+			Direction:  models.AdjustmentDirectionCredit,
+			Amount:     amount,
+			Reason:     "Chargeback reversal",
+			Evidence:   "CASE-77",
+			Reference:  reference,
+			ProposedBy: "fay@example.com",
+		})
+		require.NoError(t, err)
+		return proposed
+	}
+
+	// The threshold itself still needs only one approver; currencies can set their own
+	assert.Equal(t, 1, propose(alice.Wallet.ID, "adjust_at_threshold", 10000).RequiredApprovals)
+	assert.Equal(t, 2, propose(eur.ID, "adjust_eur", 600).RequiredApprovals)
+
+	large := propose(alice.Wallet.ID, "adjust_large", 20000)
+	assert.Equal(t, 2, large.RequiredApprovals)
+
+	first, err := useCases.Adjustment.Approve(large.ID, "sam@example.com", "")
+	require.NoError(t, err)
+	assert.Equal(t, models.AdjustmentStatusPending, first.Status)
+	assert.Equal(t, 1, first.Approvals())
+	wallet, err := repos.Wallet.GetByID(alice.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), wallet.Balance)
+
+	// The same admin cannot count twice, nor can the proposer make up the second
+	_, err = useCases.Adjustment.Approve(large.ID, "sam@example.com", "")
+	assert.Equal(t, usecases.ErrAdjustmentReviewed, err)
+	_, err = useCases.Adjustment.Approve(large.ID, "fay@example.com", "")
+	assert.Equal(t, usecases.ErrSelfApproval, err)
+
+	second, err := useCases.Adjustment.Approve(large.ID, "lee@example.com", "Second check")
+	require.NoError(t, err)
+	assert.Equal(t, models.AdjustmentStatusApproved, second.Status)
+	require.NotNil(t, second.TransactionID)
+
+	// The trail shows who proposed and who approved
+	stored, err := useCases.Adjustment.Get(large.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "fay@example.com", stored.ProposedBy)
+	require.Len(t, stored.Decisions, 2)
+	actors := []string{stored.Decisions[0].Actor, stored.Decisions[1].Actor}
+	assert.ElementsMatch(t, []string{"sam@example.com", "lee@example.com"}, actors)
+
+	wallet, err = repos.Wallet.GetByID(alice.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(20000), wallet.Balance)
+
+	pending, err := useCases.Adjustment.List(models.AdjustmentStatusPending)
+	require.NoError(t, err)
+	assert.Len(t, pending, 2)
+}
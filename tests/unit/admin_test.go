@@ -0,0 +1,147 @@
+package unit
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/auth"
+	"github.com/Code-Linx/wallet-service/internal/clock"
+	"github.com/Code-Linx/wallet-service/internal/handlers"
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/repositories"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdmin_SearchUsers(t *testing.T) {
+	useCases, _ := setupUseCases()
+
+	alice, err := useCases.User.CreateUser("Alice Smith", "alice@example.com")
+	require.NoError(t, err)
+	_, err = useCases.User.CreateUser("Bob_Jones", "bob@example.com")
+	require.NoError(t, err)
+
+	users, total, err := useCases.Admin.SearchUsers("ALICE@", 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	assert.Equal(t, alice.ID, users[0].ID)
+
+	users, _, err = useCases.Admin.SearchUsers(alice.ID.String(), 1, 10)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+
+	// Wildcards in the query are matched literally
+	users, _, err = useCases.Admin.SearchUsers("b_j", 1, 10)
+	require.NoError(t, err)
+	assert.Len(t, users, 1)
+	_, total, err = useCases.Admin.SearchUsers("%", 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+}
+
+func TestAdmin_RoutesDeclareRolesAndAreAudited(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	useCases, _ := setupUseCases()
+
+	keys, err := auth.NewKeyring("test", "test-secret", nil)
+	require.NoError(t, err)
+	tokens := auth.NewTokens(keys, 15*time.Minute, 24*time.Hour, clock.Real())
+	h := handlers.NewHandlers(useCases, tokens, nil)
+	router := h.SetupRouter(h)
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+
+	token := func(subject string, role auth.Role) string {
+		signed, err := tokens.Sign(subject, role, auth.TokenTypeAccess, time.Hour)
+		require.NoError(t, err)
+		return signed
+	}
+	support := token("sam@example.com", auth.RoleSupport)
+	finance := token("fay@example.com", auth.RoleFinance)
+	superadmin := token("root@example.com", auth.RoleSuperadmin)
+	service := token("billing", auth.RoleService)
+
+	call := func(method, path, token string, body interface{}) int {
+		var buf bytes.Buffer
+		if body != nil {
+			json.NewEncoder(&buf).Encode(body)
+		}
+		req := httptest.NewRequest(method, path, &buf)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp.Code
+	}
+
+	walletPath := "/api/v1/admin/wallets/" + alice.Wallet.ID.String()
+	adjustment := map[string]interface{}{"direction": "credit", "amount": 500, "reason": "Goodwill", "evidence": "TICKET-1", "reference": "admin_route_adjust"}
+
+	// Support looks users up and changes wallet state, but cannot move money
+	assert.Equal(t, http.StatusOK, call("GET", "/api/v1/admin/users?q=alice", support, nil))
+	assert.Equal(t, http.StatusOK, call("POST", walletPath+"/status", support, map[string]string{"status": "frozen", "reason": "Chargeback"}))
+	assert.Equal(t, http.StatusForbidden, call("POST", walletPath+"/adjustments", support, adjustment))
+
+	// Finance proposes and reviews adjustments and runs reconciliation, but
+	// cannot manage keys or approve its own proposals
+	assert.Equal(t, http.StatusOK, call("POST", walletPath+"/adjustments", finance, adjustment))
+	pending, err := useCases.Adjustment.List(models.AdjustmentStatusPending)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	approvePath := "/api/v1/admin/adjustments/" + pending[0].ID.String() + "/approve"
+	assert.Equal(t, http.StatusForbidden, call("POST", approvePath, finance, nil))
+	assert.Equal(t, http.StatusForbidden, call("POST", approvePath, support, nil))
+	assert.Equal(t, http.StatusOK, call("POST", approvePath, token("fin@example.com", auth.RoleFinance), map[string]string{"comment": "Matches ticket"}))
+	assert.Equal(t, http.StatusOK, call("POST", "/api/v1/admin/reconciliation/run", finance, nil))
+	assert.Equal(t, http.StatusForbidden, call("GET", "/api/v1/admin/api-keys", finance, nil))
+	assert.Equal(t, http.StatusForbidden, call("GET", "/api/v1/admin/audit-log", finance, nil))
+
+	// Services are not admins
+	assert.Equal(t, http.StatusForbidden, call("GET", "/api/v1/admin/users", service, nil))
+
+	// The wallet status change names the admin who made it
+	changes, err := useCases.Wallet.ListWalletStatusChanges(alice.Wallet.ID)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "sam@example.com", changes[0].Actor)
+
+	// Superadmins can do everything, including reading who did what
+	assert.Equal(t, http.StatusOK, call("GET", "/api/v1/admin/audit-log", superadmin, nil))
+
+	entries, total, err := useCases.Admin.ListAuditLog(repositories.AuditLogFilter{Actor: "fay@example.com"}, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+
+	var refused, selfApproved, adjusted *models.AuditLogEntry
+	for i := range entries {
+		switch {
+		case entries[i].Route == "/api/v1/admin/api-keys":
+			refused = &entries[i]
+		case entries[i].Route == "/api/v1/admin/adjustments/:adjustment_id/approve":
+			selfApproved = &entries[i]
+		case entries[i].Route == "/api/v1/admin/wallets/:wallet_id/adjustments":
+			adjusted = &entries[i]
+		}
+	}
+	require.NotNil(t, refused)
+	assert.Equal(t, http.StatusForbidden, refused.StatusCode)
+	require.NotNil(t, selfApproved)
+	assert.Equal(t, http.StatusForbidden, selfApproved.StatusCode)
+	require.NotNil(t, adjusted)
+	assert.Equal(t, "finance", adjusted.ActorRole)
+	assert.Equal(t, http.StatusOK, adjusted.StatusCode)
+	assert.Equal(t, alice.Wallet.ID.String(), adjusted.Params["wallet_id"])
+	assert.Contains(t, adjusted.Body, "Goodwill")
+
+	// Requests by non-admins never reach the audit log
+	_, total, err = useCases.Admin.ListAuditLog(repositories.AuditLogFilter{Actor: "billing"}, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+}
@@ -0,0 +1,119 @@
+package unit
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/auth"
+	"github.com/Code-Linx/wallet-service/internal/clock"
+	"github.com/Code-Linx/wallet-service/internal/handlers"
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKey_StoredHashedAndChecked(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	useCases, repos := setupUseCases(usecases.WithClock(fake))
+
+	expiresAt := fake.Now().Add(24 * time.Hour)
+	key, err := useCases.APIKey.Create(&models.APIKey{
+		Name:       "billing",
+		Scopes:     []string{"wallet:fund", "wallet:fund", "users:read"},
+		AllowedIPs: []string{"10.0.0.0/8", "192.0.2.7"},
+		ExpiresAt:  &expiresAt,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"wallet:fund", "users:read"}, key.Scopes)
+	assert.Equal(t, key.Key[:12], key.Prefix)
+
+	// Only the hash is kept
+	stored, err := repos.APIKey.GetByID(key.ID)
+	require.NoError(t, err)
+	assert.Empty(t, stored.Key)
+	assert.Len(t, stored.KeyHash, 64)
+	assert.NotContains(t, stored.KeyHash, key.Key)
+	assert.Nil(t, stored.LastUsedAt)
+
+	_, err = useCases.APIKey.Authenticate(key.Key, "10.1.2.3")
+	require.NoError(t, err)
+	stored, err = repos.APIKey.GetByID(key.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored.LastUsedAt)
+	assert.Equal(t, "10.1.2.3", stored.LastUsedIP)
+
+	_, err = useCases.APIKey.Authenticate(key.Key, "192.0.2.8")
+	assert.Equal(t, usecases.ErrAPIKeyIPNotAllowed, err)
+	_, err = useCases.APIKey.Authenticate(key.Key+"0", "192.0.2.7")
+	assert.Equal(t, usecases.ErrAPIKeyNotFound, err)
+
+	fake.Advance(24 * time.Hour)
+	_, err = useCases.APIKey.Authenticate(key.Key, "192.0.2.7")
+	assert.Equal(t, usecases.ErrAPIKeyExpired, err)
+
+	other, err := useCases.APIKey.Create(&models.APIKey{Name: "reports", Scopes: []string{"users:read"}})
+	require.NoError(t, err)
+	_, err = useCases.APIKey.Revoke(other.ID)
+	require.NoError(t, err)
+	_, err = useCases.APIKey.Authenticate(other.Key, "192.0.2.7")
+	assert.Equal(t, usecases.ErrAPIKeyRevoked, err)
+
+	// Unknown scopes and bad allowlists are refused; reconciliation is for
+	// admins only
+	_, err = useCases.APIKey.Create(&models.APIKey{Name: "bad", Scopes: []string{"wallet:everything"}})
+	assert.Equal(t, usecases.ErrInvalidAPIKey, err)
+	_, err = useCases.APIKey.Create(&models.APIKey{Name: "bad", Scopes: []string{"reconciliation:run"}})
+	assert.Equal(t, usecases.ErrInvalidAPIKey, err)
+	_, err = useCases.APIKey.Create(&models.APIKey{Name: "bad", Scopes: []string{"users:read"}, AllowedIPs: []string{"10.0.0"}})
+	assert.Equal(t, usecases.ErrInvalidAPIKey, err)
+}
+
+func TestAPIKey_ScopesGuardRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	useCases, repos := setupUseCases()
+
+	keys, err := auth.NewKeyring("test", "test-secret", nil)
+	require.NoError(t, err)
+	h := handlers.NewHandlers(useCases, auth.NewTokens(keys, 15*time.Minute, 24*time.Hour, clock.Real()), nil)
+	router := h.SetupRouter(h)
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	key, err := useCases.APIKey.Create(&models.APIKey{Name: "topups", Scopes: []string{"wallet:fund", "users:read"}})
+	require.NoError(t, err)
+
+	call := func(method, path, apiKey string, body interface{}) int {
+		var buf bytes.Buffer
+		if body != nil {
+			json.NewEncoder(&buf).Encode(body)
+		}
+		req := httptest.NewRequest(method, path, &buf)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-API-Key", apiKey)
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp.Code
+	}
+
+	userPath := "/api/v1/users/" + alice.ID.String()
+	assert.Equal(t, http.StatusOK, call("POST", userPath+"/wallet/fund", key.Key, map[string]interface{}{"amount": 1000, "reference": "key_fund"}))
+	assert.Equal(t, http.StatusOK, call("GET", userPath, key.Key, nil))
+	assert.Equal(t, http.StatusUnauthorized, call("GET", userPath, "wsk_unknown", nil))
+
+	// Scopes the key lacks, and routes that name no scope, are refused
+	assert.Equal(t, http.StatusForbidden, call("POST", userPath+"/wallet/withdraw", key.Key, map[string]interface{}{"amount": 100, "reference": "key_withdraw"}))
+	assert.Equal(t, http.StatusForbidden, call("POST", "/api/v1/admin/reconciliation/run", key.Key, nil))
+	assert.Equal(t, http.StatusForbidden, call("POST", userPath+"/pockets", key.Key, map[string]string{"name": "Tax"}))
+	assert.Equal(t, http.StatusForbidden, call("GET", "/api/v1/admin/api-keys", key.Key, nil))
+
+	wallet, err := repos.Wallet.GetByID(alice.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), wallet.Balance)
+}
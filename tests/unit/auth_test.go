@@ -0,0 +1,126 @@
+package unit
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/auth"
+	"github.com/Code-Linx/wallet-service/internal/clock"
+	"github.com/Code-Linx/wallet-service/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuth_TokensSurviveKeyRotationAndExpire(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+
+	oldKeys, err := auth.NewKeyring("2026-01", "old-secret", nil)
+	require.NoError(t, err)
+	oldTokens := auth.NewTokens(oldKeys, 15*time.Minute, 24*time.Hour, fake)
+	pair, err := oldTokens.Issue("alice", auth.RoleUser)
+	require.NoError(t, err)
+	assert.Equal(t, int64(900), pair.ExpiresIn)
+
+	// After rotation the old key still verifies, and new tokens use the new one
+	keys, err := auth.NewKeyring("2026-03", "new-secret", map[string]string{"2026-01": "old-secret"})
+	require.NoError(t, err)
+	tokens := auth.NewTokens(keys, 15*time.Minute, 24*time.Hour, fake)
+
+	claims, err := tokens.Verify(pair.AccessToken, auth.TokenTypeAccess)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", claims.Subject)
+	assert.Equal(t, auth.RoleUser, claims.Role)
+
+	newToken, err := tokens.Sign("alice", auth.RoleUser, auth.TokenTypeAccess, time.Minute)
+	require.NoError(t, err)
+	_, err = oldTokens.Verify(newToken, auth.TokenTypeAccess)
+	assert.Equal(t, auth.ErrUnknownKey, err)
+
+	// Refresh tokens are not access tokens, and tampering breaks the signature
+	_, err = tokens.Verify(pair.RefreshToken, auth.TokenTypeAccess)
+	assert.Equal(t, auth.ErrInvalidToken, err)
+	_, err = tokens.Verify(pair.AccessToken+"x", auth.TokenTypeAccess)
+	assert.Equal(t, auth.ErrInvalidToken, err)
+
+	fake.Advance(15 * time.Minute)
+	_, err = tokens.Verify(pair.AccessToken, auth.TokenTypeAccess)
+	assert.Equal(t, auth.ErrTokenExpired, err)
+	_, err = tokens.Verify(pair.RefreshToken, auth.TokenTypeRefresh)
+	assert.NoError(t, err)
+}
+
+func TestAuth_UsersOnlyActOnTheirOwnID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	useCases, _ := setupUseCases()
+
+	keys, err := auth.NewKeyring("test", "test-secret", nil)
+	require.NoError(t, err)
+	tokens := auth.NewTokens(keys, 15*time.Minute, 24*time.Hour, clock.Real())
+	h := handlers.NewHandlers(useCases, tokens, nil)
+	router := h.SetupRouter(h)
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	bob, err := useCases.User.CreateUser("Bob", "bob@example.com")
+	require.NoError(t, err)
+
+	service, err := tokens.Sign("billing", auth.RoleService, auth.TokenTypeAccess, time.Hour)
+	require.NoError(t, err)
+
+	call := func(method, path, token string, body interface{}) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		if body != nil {
+			json.NewEncoder(&buf).Encode(body)
+		}
+		req := httptest.NewRequest(method, path, &buf)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	assert.Equal(t, http.StatusOK, call("GET", "/api/v1/health", "", nil).Code)
+	assert.Equal(t, http.StatusUnauthorized, call("GET", "/api/v1/users/"+alice.ID.String(), "", nil).Code)
+
+	// A service gets tokens for a user it has signed in
+	resp := call("POST", "/api/v1/auth/token", service, map[string]string{"user_id": alice.ID.String()})
+	require.Equal(t, http.StatusOK, resp.Code)
+	var issued struct {
+		Data auth.TokenPair `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &issued))
+	aliceToken := issued.Data.AccessToken
+
+	assert.Equal(t, http.StatusOK, call("GET", "/api/v1/users/"+alice.ID.String(), aliceToken, nil).Code)
+	assert.Equal(t, http.StatusForbidden, call("GET", "/api/v1/users/"+bob.ID.String(), aliceToken, nil).Code)
+	assert.Equal(t, http.StatusForbidden, call("POST", "/api/v1/users/"+bob.ID.String()+"/wallet/withdraw", aliceToken,
+		map[string]interface{}{"amount": 100, "reference": "auth_steal"}).Code)
+	assert.Equal(t, http.StatusForbidden, call("POST", "/api/v1/admin/reconciliation/run", aliceToken, nil).Code)
+	assert.Equal(t, http.StatusForbidden, call("POST", "/api/v1/auth/token", aliceToken, map[string]string{"user_id": bob.ID.String()}).Code)
+
+	// Services act for any user but are not admins
+	assert.Equal(t, http.StatusOK, call("GET", "/api/v1/users/"+bob.ID.String(), service, nil).Code)
+	assert.Equal(t, http.StatusForbidden, call("GET", "/api/v1/admin/fees/rules", service, nil).Code)
+	assert.Equal(t, http.StatusForbidden, call("POST", "/api/v1/admin/reconciliation/run", service, nil).Code)
+
+	// Admin routes that used to sit outside /admin are gone
+	resp = call("POST", "/api/v1/reconciliation/run", service, nil)
+	assert.Equal(t, http.StatusGone, resp.Code)
+	assert.Contains(t, resp.Body.String(), "/api/v1/admin/reconciliation/run")
+	assert.Equal(t, http.StatusGone, call("DELETE", "/api/v1/interest/rules/"+bob.ID.String(), service, nil).Code)
+
+	// Refreshing needs no access token
+	resp = call("POST", "/api/v1/auth/refresh", "", map[string]string{"refresh_token": issued.Data.RefreshToken})
+	require.Equal(t, http.StatusOK, resp.Code)
+	resp = call("POST", "/api/v1/auth/refresh", "", map[string]string{"refresh_token": aliceToken})
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+}
@@ -0,0 +1,84 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/Code-Linx/wallet-service/internal/currency"
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurrency_Exponents(t *testing.T) {
+	assert.Equal(t, 2, currency.Exponent("USD"))
+	assert.Equal(t, 0, currency.Exponent("jpy"))
+	assert.Equal(t, 3, currency.Exponent("KWD"))
+
+	assert.Equal(t, "12.34", currency.Format(1234, "USD"))
+	assert.Equal(t, "1234", currency.Format(1234, "JPY"))
+	assert.Equal(t, "-1.234", currency.Format(-1234, "KWD"))
+
+	_, ok := currency.Lookup("XYZ")
+	assert.False(t, ok)
+}
+
+func TestMultiCurrency_WalletsAreIndependent(t *testing.T) {
+	useCases, repos := setupUseCases()
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	bob, err := useCases.User.CreateUser("Bob", "bob@example.com")
+	require.NoError(t, err)
+
+	// Every user starts with a wallet in the default currency
+	jpy, err := useCases.Wallet.OpenWallet(alice.ID, "jpy")
+	require.NoError(t, err)
+	assert.Equal(t, "JPY", jpy.Currency)
+	assert.Equal(t, 0, jpy.Exponent)
+
+	_, err = useCases.Wallet.OpenWallet(alice.ID, "JPY")
+	assert.Equal(t, usecases.ErrWalletExists, err)
+	_, err = useCases.Wallet.OpenWallet(alice.ID, "XYZ")
+	assert.Equal(t, usecases.ErrUnsupportedCurrency, err)
+
+	wallets, err := useCases.Wallet.ListWallets(alice.ID)
+	require.NoError(t, err)
+	assert.Len(t, wallets, 2)
+
+	_, err = useCases.Wallet.FundWallet(alice.ID, 5000, "USD", "mc_fund_usd")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(alice.ID, 800, "JPY", "mc_fund_jpy")
+	require.NoError(t, err)
+
+	// Funding a currency the user has no wallet in is refused
+	_, err = useCases.Wallet.FundWallet(bob.ID, 800, "JPY", "mc_fund_bob_jpy")
+	assert.Equal(t, usecases.ErrWalletNotFound, err)
+
+	// Transfers never convert: the recipient needs a wallet in the same currency
+	_, err = useCases.Wallet.TransferFunds(alice.ID, bob.ID, 100, "JPY", "mc_transfer_jpy")
+	assert.Equal(t, usecases.ErrCurrencyMismatch, err)
+
+	_, err = useCases.Wallet.TransferFunds(alice.ID, bob.ID, 1000, "USD", "mc_transfer_usd")
+	require.NoError(t, err)
+
+	usd, err := repos.Wallet.GetByUserIDAndCurrency(alice.ID, "USD")
+	require.NoError(t, err)
+	assert.Equal(t, int64(4000), usd.Balance)
+
+	jpy, err = repos.Wallet.GetByUserIDAndCurrency(alice.ID, "JPY")
+	require.NoError(t, err)
+	assert.Equal(t, int64(800), jpy.Balance)
+
+	// Reconciliation runs per wallet and never mixes currencies
+	results, err := useCases.Reconciliation.RunReconciliation()
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	for _, result := range results {
+		assert.False(t, result.HasMismatch)
+		if result.WalletID == jpy.ID {
+			assert.Equal(t, "JPY", result.Currency)
+			assert.Equal(t, int64(800), result.CalculatedBalance)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/clock"
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscrow_ReleasePaysRecipient(t *testing.T) {
+	useCases, repos := setupUseCases()
+
+	buyer, err := useCases.User.CreateUser("Buyer", "buyer@example.com")
+	require.NoError(t, err)
+	seller, err := useCases.User.CreateUser("Seller", "seller@example.com")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(buyer.ID, 10000, "USD", "escrow_fund")
+	require.NoError(t, err)
+
+	escrow, err := useCases.Wallet.CreateEscrow(buyer.ID, seller.ID, 4000, "USD", time.Hour, "order_1")
+	require.NoError(t, err)
+	assert.Equal(t, models.TransactionTypeEscrow, escrow.Type)
+	assert.Equal(t, models.TransactionStatusPending, escrow.Status)
+
+	// The funds have left the buyer but not reached the seller
+	wallet, err := repos.Wallet.GetByID(buyer.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(6000), wallet.Balance)
+	wallet, err = repos.Wallet.GetByID(seller.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), wallet.Balance)
+
+	// Only the buyer releases
+	_, err = useCases.Wallet.ReleaseEscrow(seller.ID, escrow.ID)
+	assert.Equal(t, usecases.ErrEscrowForbidden, err)
+
+	released, err := useCases.Wallet.ReleaseEscrow(buyer.ID, escrow.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.TransactionStatusCompleted, released.Status)
+
+	_, err = useCases.Wallet.CancelEscrow(seller.ID, escrow.ID)
+	assert.Equal(t, usecases.ErrEscrowNotPending, err)
+
+	wallet, err = repos.Wallet.GetByID(seller.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4000), wallet.Balance)
+
+	escrow, err = useCases.Wallet.GetEscrow(seller.ID, escrow.ID)
+	require.NoError(t, err)
+	require.Len(t, escrow.EscrowEvents, 1)
+	assert.Equal(t, models.TransactionStatusCompleted, escrow.EscrowEvents[0].ToStatus)
+	assert.Equal(t, buyer.ID.String(), escrow.EscrowEvents[0].Actor)
+
+	results, err := useCases.Reconciliation.RunReconciliation()
+	require.NoError(t, err)
+	for _, result := range results {
+		assert.False(t, result.HasMismatch, result.WalletID)
+	}
+}
+
+func TestEscrow_CancelAndTimeoutReturnFunds(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	useCases, repos := setupUseCases(usecases.WithClock(fake))
+
+	buyer, err := useCases.User.CreateUser("Buyer", "buyer@example.com")
+	require.NoError(t, err)
+	seller, err := useCases.User.CreateUser("Seller", "seller@example.com")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(buyer.ID, 10000, "USD", "escrow_fund")
+	require.NoError(t, err)
+
+	cancelled, err := useCases.Wallet.CreateEscrow(buyer.ID, seller.ID, 3000, "USD", time.Hour, "order_cancelled")
+	require.NoError(t, err)
+	timedOut, err := useCases.Wallet.CreateEscrow(buyer.ID, seller.ID, 2000, "USD", time.Hour, "order_timed_out")
+	require.NoError(t, err)
+
+	// Only the seller can call the deal off
+	_, err = useCases.Wallet.CancelEscrow(buyer.ID, cancelled.ID)
+	assert.Equal(t, usecases.ErrEscrowForbidden, err)
+	cancelled, err = useCases.Wallet.CancelEscrow(seller.ID, cancelled.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.TransactionStatusCancelled, cancelled.Status)
+
+	expired, err := useCases.Wallet.ExpireEscrows()
+	require.NoError(t, err)
+	assert.Equal(t, 0, expired)
+
+	fake.Advance(time.Hour)
+
+	// Past the timeout the escrow can no longer be released
+	_, err = useCases.Wallet.ReleaseEscrow(buyer.ID, timedOut.ID)
+	assert.Equal(t, usecases.ErrEscrowExpired, err)
+
+	expired, err = useCases.Wallet.ExpireEscrows()
+	require.NoError(t, err)
+	assert.Equal(t, 1, expired)
+
+	timedOut, err = useCases.Wallet.GetEscrow(buyer.ID, timedOut.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.TransactionStatusCancelled, timedOut.Status)
+	require.Len(t, timedOut.EscrowEvents, 1)
+	assert.Equal(t, "system", timedOut.EscrowEvents[0].Actor)
+
+	wallet, err := repos.Wallet.GetByID(buyer.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10000), wallet.Balance)
+
+	results, err := useCases.Reconciliation.RunReconciliation()
+	require.NoError(t, err)
+	for _, result := range results {
+		assert.False(t, result.HasMismatch, result.WalletID)
+	}
+}
+
+func TestEscrow_DisputeFreezesUntilResolved(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	useCases, repos := setupUseCases(usecases.WithClock(fake))
+
+	buyer, err := useCases.User.CreateUser("Buyer", "buyer@example.com")
+	require.NoError(t, err)
+	seller, err := useCases.User.CreateUser("Seller", "seller@example.com")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(buyer.ID, 10000, "USD", "escrow_fund")
+	require.NoError(t, err)
+
+	escrow, err := useCases.Wallet.CreateEscrow(buyer.ID, seller.ID, 5000, "USD", time.Hour, "order_disputed")
+	require.NoError(t, err)
+
+	_, err = useCases.Wallet.DisputeEscrow(buyer.ID, escrow.ID, " ")
+	assert.Equal(t, usecases.ErrEscrowReasonRequired, err)
+	escrow, err = useCases.Wallet.DisputeEscrow(buyer.ID, escrow.ID, "Item never arrived")
+	require.NoError(t, err)
+	assert.Equal(t, models.TransactionStatusDisputed, escrow.Status)
+
+	// Neither party can settle it, and it does not time out
+	_, err = useCases.Wallet.ReleaseEscrow(buyer.ID, escrow.ID)
+	assert.Equal(t, usecases.ErrEscrowNotPending, err)
+	_, err = useCases.Wallet.CancelEscrow(seller.ID, escrow.ID)
+	assert.Equal(t, usecases.ErrEscrowNotPending, err)
+
+	fake.Advance(2 * time.Hour)
+	expired, err := useCases.Wallet.ExpireEscrows()
+	require.NoError(t, err)
+	assert.Equal(t, 0, expired)
+
+	// Escrowed funds count towards transfer limits while they are held
+	_, err = useCases.Limit.CreateRule(&models.LimitRule{
+		TransactionType: models.TransactionTypeTransfer,
+		UserID:          &buyer.ID,
+		Period:          models.LimitPeriodMonth,
+		MaxAmount:       6000,
+	})
+	require.NoError(t, err)
+	_, err = useCases.Wallet.TransferFunds(buyer.ID, seller.ID, 2000, "USD", "over_limit")
+	assert.ErrorIs(t, err, usecases.ErrLimitExceeded)
+
+	escrow, err = useCases.Wallet.ResolveEscrow(escrow.ID, models.EscrowResolutionRelease, "Tracking shows delivery", "disputes@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, models.TransactionStatusCompleted, escrow.Status)
+
+	_, err = useCases.Wallet.ResolveEscrow(escrow.ID, models.EscrowResolutionRefund, "Second review", "disputes@example.com")
+	assert.Equal(t, usecases.ErrEscrowNotDisputed, err)
+
+	wallet, err := repos.Wallet.GetByID(seller.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5000), wallet.Balance)
+
+	results, err := useCases.Reconciliation.RunReconciliation()
+	require.NoError(t, err)
+	for _, result := range results {
+		assert.False(t, result.HasMismatch, result.WalletID)
+	}
+}
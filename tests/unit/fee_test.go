@@ -0,0 +1,159 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFee_RuleKindsAndCaps(t *testing.T) {
+	useCases, _ := setupUseCases()
+
+	user, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+
+	// No rule means no fee
+	quote, err := useCases.Fee.QuoteFee(user.ID, models.TransactionTypeDebit, 10000, "USD")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), quote.Fee)
+	assert.Nil(t, quote.RuleID)
+
+	// 1.5% with a 50 minimum and 500 maximum
+	_, err = useCases.Fee.CreateRule(&models.FeeRule{
+		TransactionType: models.TransactionTypeDebit,
+		Kind:            models.FeeKindPercentage,
+		BasisPoints:     150,
+		MinFee:          50,
+		MaxFee:          500,
+	})
+	require.NoError(t, err)
+
+	for amount, expected := range map[int64]int64{1000: 50, 10001: 151, 100000: 500} {
+		quote, err := useCases.Fee.QuoteFee(user.ID, models.TransactionTypeDebit, amount, "USD")
+		require.NoError(t, err)
+		assert.Equal(t, expected, quote.Fee, "amount %d", amount)
+		assert.Equal(t, -(amount + expected), quote.WalletChange)
+	}
+
+	// Tiered: flat 25 up to 5000, then 1% beyond
+	_, err = useCases.Fee.CreateRule(&models.FeeRule{
+		TransactionType: models.TransactionTypeTransfer,
+		Kind:            models.FeeKindTiered,
+		Tiers: []models.FeeTier{
+			{UpTo: 5000, FlatAmount: 25},
+			{BasisPoints: 100},
+		},
+	})
+	require.NoError(t, err)
+
+	quote, err = useCases.Fee.QuoteFee(user.ID, models.TransactionTypeTransfer, 5000, "USD")
+	require.NoError(t, err)
+	assert.Equal(t, int64(25), quote.Fee)
+	quote, err = useCases.Fee.QuoteFee(user.ID, models.TransactionTypeTransfer, 20000, "USD")
+	require.NoError(t, err)
+	assert.Equal(t, int64(200), quote.Fee)
+
+	// Unbounded bands must come last
+	_, err = useCases.Fee.CreateRule(&models.FeeRule{
+		TransactionType: models.TransactionTypeTransfer,
+		Kind:            models.FeeKindTiered,
+		Tiers:           []models.FeeTier{{BasisPoints: 100}, {UpTo: 5000, FlatAmount: 25}},
+	})
+	assert.Equal(t, usecases.ErrInvalidFeeRule, err)
+}
+
+func TestFee_MostSpecificRuleApplies(t *testing.T) {
+	useCases, repos := setupUseCases()
+
+	user, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+
+	for _, rule := range []*models.FeeRule{
+		{TransactionType: models.TransactionTypeDebit, Kind: models.FeeKindFlat, FlatAmount: 100},
+		{TransactionType: models.TransactionTypeDebit, Currency: "usd", Kind: models.FeeKindFlat, FlatAmount: 75},
+		{TransactionType: models.TransactionTypeDebit, Tier: "gold", Kind: models.FeeKindFlat, FlatAmount: 10},
+	} {
+		_, err := useCases.Fee.CreateRule(rule)
+		require.NoError(t, err)
+	}
+
+	quote, err := useCases.Fee.QuoteFee(user.ID, models.TransactionTypeDebit, 1000, "USD")
+	require.NoError(t, err)
+	assert.Equal(t, int64(75), quote.Fee)
+
+	quote, err = useCases.Fee.QuoteFee(user.ID, models.TransactionTypeDebit, 1000, "EUR")
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), quote.Fee)
+
+	require.NoError(t, repos.DB.Model(&models.User{}).Where("id = ?", user.ID).Update("tier", "gold").Error)
+
+	quote, err = useCases.Fee.QuoteFee(user.ID, models.TransactionTypeDebit, 1000, "USD")
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), quote.Fee)
+}
+
+func TestFee_ChargedAsLinkedTransaction(t *testing.T) {
+	useCases, repos := setupUseCases()
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	bob, err := useCases.User.CreateUser("Bob", "bob@example.com")
+	require.NoError(t, err)
+
+	_, err = useCases.Fee.CreateRule(&models.FeeRule{
+		TransactionType: models.TransactionTypeDebit,
+		Kind:            models.FeeKindFlat,
+		FlatAmount:      100,
+	})
+	require.NoError(t, err)
+	_, err = useCases.Fee.CreateRule(&models.FeeRule{
+		TransactionType: models.TransactionTypeTransfer,
+		Kind:            models.FeeKindPercentage,
+		BasisPoints:     100,
+	})
+	require.NoError(t, err)
+
+	_, err = useCases.Wallet.FundWallet(alice.ID, 10000, "USD", "fee_fund")
+	require.NoError(t, err)
+
+	// The fee must be covered on top of the amount
+	_, err = useCases.Wallet.WithdrawFunds(alice.ID, 9950, "USD", "fee_withdraw_too_much")
+	assert.Equal(t, usecases.ErrInsufficientFunds, err)
+
+	withdrawal, err := useCases.Wallet.WithdrawFunds(alice.ID, 2000, "USD", "fee_withdraw")
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), withdrawal.FeeAmount)
+
+	feeTxn, err := repos.Transaction.GetByReference("fee_withdraw:fee")
+	require.NoError(t, err)
+	assert.Equal(t, models.TransactionTypeFee, feeTxn.Type)
+	assert.Equal(t, int64(100), feeTxn.Amount)
+	assert.Equal(t, &withdrawal.ID, feeTxn.ParentTransactionID)
+
+	transfer, err := useCases.Wallet.TransferFunds(alice.ID, bob.ID, 3000, "USD", "fee_transfer")
+	require.NoError(t, err)
+	assert.Equal(t, int64(30), transfer.FeeAmount)
+
+	aliceWallet, err := repos.Wallet.GetByUserIDAndCurrency(alice.ID, "USD")
+	require.NoError(t, err)
+	assert.Equal(t, int64(10000-2000-100-3000-30), aliceWallet.Balance)
+	bobWallet, err := repos.Wallet.GetByUserIDAndCurrency(bob.ID, "USD")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3000), bobWallet.Balance)
+
+	income, err := repos.Ledger.GetAccountByCode(models.AccountCodeFeeIncome + ":USD")
+	require.NoError(t, err)
+	incomeBalance, err := repos.Ledger.GetAccountBalance(income.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(130), incomeBalance)
+
+	results, err := useCases.Reconciliation.RunReconciliation()
+	require.NoError(t, err)
+	for _, result := range results {
+		assert.False(t, result.HasMismatch)
+	}
+}
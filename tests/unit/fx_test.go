@@ -0,0 +1,122 @@
+package unit
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Code-Linx/wallet-service/internal/fx"
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFX_ConvertRoundsDownAndKeepsRemainder(t *testing.T) {
+	// 10.01 USD at 151.37 JPY is 1515.2137 JPY
+	conversion, err := fx.Convert(1001, big.NewRat(15137, 100), 2, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1515), conversion.TargetAmount)
+	assert.Equal(t, "0.2137000000000000", conversion.RemainderString())
+
+	// 1 JPY at 0.0066 USD is 0.66 USD cents
+	conversion, err = fx.Convert(1, big.NewRat(66, 10000), 0, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), conversion.TargetAmount)
+}
+
+func TestFX_StaticProviderDerivesCrossRates(t *testing.T) {
+	provider, err := fx.NewStaticProvider("USD", map[string]string{"EUR": "0.8", "GBP": "0.5"})
+	require.NoError(t, err)
+
+	rate, err := provider.Rate("EUR", "GBP")
+	require.NoError(t, err)
+	assert.Equal(t, "0.6250000000", fx.FormatRate(rate))
+
+	_, err = provider.Rate("USD", "JPY")
+	assert.ErrorIs(t, err, fx.ErrRateUnavailable)
+}
+
+func TestFX_ConvertFunds(t *testing.T) {
+	rates := fx.NewMemoryProvider()
+	require.NoError(t, rates.Set("USD", "JPY", "151.37"))
+	useCases, repos := setupUseCases(usecases.WithRateProvider(rates))
+
+	user, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(user.ID, 10000, "USD", "fx_fund")
+	require.NoError(t, err)
+
+	// Converting into a currency without a wallet is refused
+	_, err = useCases.Wallet.ConvertFunds(user.ID, 1001, "USD", "JPY", nil, "fx_no_wallet")
+	assert.Equal(t, usecases.ErrWalletNotFound, err)
+
+	_, err = useCases.Wallet.OpenWallet(user.ID, "JPY")
+	require.NoError(t, err)
+
+	txn, err := useCases.Wallet.ConvertFunds(user.ID, 1001, "USD", "JPY", nil, "fx_convert")
+	require.NoError(t, err)
+	require.NotNil(t, txn.Conversion)
+	assert.Equal(t, int64(1515), txn.Conversion.TargetAmount)
+	assert.Equal(t, "151.3700000000", txn.Conversion.Rate)
+	assert.Equal(t, "0.2137000000000000", txn.Conversion.Remainder)
+
+	// Retrying with the same reference returns the original conversion
+	again, err := useCases.Wallet.ConvertFunds(user.ID, 1001, "USD", "JPY", nil, "fx_convert")
+	require.NoError(t, err)
+	assert.Equal(t, txn.ID, again.ID)
+
+	usd, err := repos.Wallet.GetByUserIDAndCurrency(user.ID, "USD")
+	require.NoError(t, err)
+	assert.Equal(t, int64(8999), usd.Balance)
+	jpy, err := repos.Wallet.GetByUserIDAndCurrency(user.ID, "JPY")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1515), jpy.Balance)
+
+	// Both legs reconcile in their own currency
+	results, err := useCases.Reconciliation.RunReconciliation()
+	require.NoError(t, err)
+	for _, result := range results {
+		assert.False(t, result.HasMismatch)
+	}
+}
+
+func TestFX_QuoteLocksRate(t *testing.T) {
+	rates := fx.NewMemoryProvider()
+	require.NoError(t, rates.Set("EUR", "USD", "1.25"))
+	useCases, repos := setupUseCases(usecases.WithRateProvider(rates))
+
+	user, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.OpenWallet(user.ID, "EUR")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(user.ID, 5000, "EUR", "quote_fund")
+	require.NoError(t, err)
+
+	// The inverse pair is derived from the rate that was set
+	quote, err := useCases.Wallet.QuoteConversion(user.ID, 1000, "USD", "EUR")
+	require.NoError(t, err)
+	assert.Equal(t, int64(800), quote.TargetAmount)
+
+	quote, err = useCases.Wallet.QuoteConversion(user.ID, 1000, "EUR", "USD")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1250), quote.TargetAmount)
+
+	// The market moves, but the quoted rate still applies
+	require.NoError(t, rates.Set("EUR", "USD", "1.10"))
+
+	_, err = useCases.Wallet.ConvertFunds(user.ID, 999, "EUR", "USD", &quote.ID, "quote_mismatch")
+	assert.Equal(t, usecases.ErrQuoteMismatch, err)
+
+	txn, err := useCases.Wallet.ConvertFunds(user.ID, 1000, "EUR", "USD", &quote.ID, "quote_convert")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1250), txn.Conversion.TargetAmount)
+	assert.Equal(t, &quote.ID, txn.Conversion.QuoteID)
+
+	// A quote can only be used once
+	_, err = useCases.Wallet.ConvertFunds(user.ID, 1000, "EUR", "USD", &quote.ID, "quote_reuse")
+	assert.Equal(t, usecases.ErrQuoteUsed, err)
+
+	used, err := repos.FX.GetQuoteByID(quote.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &txn.ID, used.TransactionID)
+}
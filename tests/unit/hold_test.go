@@ -0,0 +1,115 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHold_ReservesAvailableBalance(t *testing.T) {
+	useCases, repos := setupUseCases()
+
+	user, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(user.ID, 10000, "USD", "hold_fund")
+	require.NoError(t, err)
+
+	hold, err := useCases.Wallet.PlaceHold(user.ID, 7000, "USD", time.Hour, "hold_001")
+	require.NoError(t, err)
+	assert.Equal(t, models.HoldStatusActive, hold.Status)
+
+	// Placing the same hold again is idempotent
+	again, err := useCases.Wallet.PlaceHold(user.ID, 7000, "USD", time.Hour, "hold_001")
+	require.NoError(t, err)
+	assert.Equal(t, hold.ID, again.ID)
+
+	wallet, err := repos.Wallet.GetByUserIDAndCurrency(user.ID, "USD")
+	require.NoError(t, err)
+	assert.Equal(t, int64(10000), wallet.Balance)
+	assert.Equal(t, int64(3000), wallet.AvailableBalance)
+
+	// Withdrawals and further holds only see the available balance
+	_, err = useCases.Wallet.WithdrawFunds(user.ID, 5000, "USD", "hold_withdraw")
+	assert.Equal(t, usecases.ErrInsufficientFunds, err)
+	_, err = useCases.Wallet.PlaceHold(user.ID, 5000, "USD", time.Hour, "hold_002")
+	assert.Equal(t, usecases.ErrInsufficientFunds, err)
+}
+
+func TestHold_PartialCaptureReleasesRemainder(t *testing.T) {
+	useCases, repos := setupUseCases()
+
+	user, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(user.ID, 10000, "USD", "capture_fund")
+	require.NoError(t, err)
+
+	hold, err := useCases.Wallet.PlaceHold(user.ID, 4000, "USD", time.Hour, "capture_hold")
+	require.NoError(t, err)
+
+	// Capturing more than was reserved is rejected
+	_, err = useCases.Wallet.CaptureHold(user.ID, hold.ID, 5000)
+	assert.Equal(t, usecases.ErrInvalidAmount, err)
+
+	transaction, err := useCases.Wallet.CaptureHold(user.ID, hold.ID, 2500)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2500), transaction.Amount)
+
+	// A repeated capture returns the original transaction
+	again, err := useCases.Wallet.CaptureHold(user.ID, hold.ID, 2500)
+	require.NoError(t, err)
+	assert.Equal(t, transaction.ID, again.ID)
+
+	wallet, err := repos.Wallet.GetByUserIDAndCurrency(user.ID, "USD")
+	require.NoError(t, err)
+	assert.Equal(t, int64(7500), wallet.Balance)
+	assert.Equal(t, int64(0), wallet.HeldBalance)
+	assert.Equal(t, int64(7500), wallet.AvailableBalance)
+
+	_, err = useCases.Wallet.VoidHold(user.ID, hold.ID)
+	assert.Equal(t, usecases.ErrHoldNotActive, err)
+
+	results, err := useCases.Reconciliation.RunReconciliation()
+	require.NoError(t, err)
+	for _, result := range results {
+		assert.False(t, result.HasMismatch)
+	}
+}
+
+func TestHold_VoidAndExpiry(t *testing.T) {
+	useCases, repos := setupUseCases()
+
+	user, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(user.ID, 10000, "USD", "void_fund")
+	require.NoError(t, err)
+
+	voided, err := useCases.Wallet.PlaceHold(user.ID, 1000, "USD", time.Hour, "void_hold")
+	require.NoError(t, err)
+	voided, err = useCases.Wallet.VoidHold(user.ID, voided.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.HoldStatusVoided, voided.Status)
+
+	expiring, err := useCases.Wallet.PlaceHold(user.ID, 2000, "USD", time.Millisecond, "expiring_hold")
+	require.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+
+	expired, err := useCases.Wallet.ExpireHolds()
+	require.NoError(t, err)
+	assert.Equal(t, 1, expired)
+
+	expiring, err = useCases.Wallet.GetHold(user.ID, expiring.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.HoldStatusExpired, expiring.Status)
+
+	_, err = useCases.Wallet.CaptureHold(user.ID, expiring.ID, 0)
+	assert.Equal(t, usecases.ErrHoldNotActive, err)
+
+	wallet, err := repos.Wallet.GetByUserIDAndCurrency(user.ID, "USD")
+	require.NoError(t, err)
+	assert.Equal(t, int64(10000), wallet.AvailableBalance)
+}
@@ -0,0 +1,266 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/clock"
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterest_AccruesExactlyAndPaysMonthly(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 2, 27, 1, 0, 0, 0, time.UTC))
+	useCases, repos := setupUseCasesAt(fake)
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(alice.ID, 10000, "USD", "interest_fund")
+	require.NoError(t, err)
+
+	// 5% a year on 10000 is about 1.37 a day, which would round to 1
+	_, err = useCases.Interest.CreateRule(&models.InterestRateRule{Product: models.InterestProductPrimary, AnnualRateBps: 500})
+	require.NoError(t, err)
+
+	// The day the rule arrived earns nothing, and the day in progress is
+	// never accrued
+	fake.Set(time.Date(2026, 2, 28, 23, 0, 0, 0, time.UTC))
+	days, err := useCases.Interest.AccrueDaily()
+	require.NoError(t, err)
+	assert.Equal(t, 0, days)
+
+	fake.Set(time.Date(2026, 3, 1, 1, 0, 0, 0, time.UTC))
+	days, err = useCases.Interest.AccrueDaily()
+	require.NoError(t, err)
+	assert.Equal(t, 1, days)
+
+	// Re-running the same day records nothing new
+	days, err = useCases.Interest.AccrueDaily()
+	require.NoError(t, err)
+	assert.Equal(t, 0, days)
+
+	// February held one day: 1.37, paid as 1 with the rest carried
+	paid, err := useCases.Interest.PayMonthly()
+	require.NoError(t, err)
+	assert.Equal(t, 1, paid)
+	paid, err = useCases.Interest.PayMonthly()
+	require.NoError(t, err)
+	assert.Equal(t, 0, paid)
+
+	february, err := repos.Transaction.GetByReference("interest:" + alice.Wallet.ID.String() + ":2026-02")
+	require.NoError(t, err)
+	assert.Equal(t, models.TransactionTypeInterestPayout, february.Type)
+	assert.Equal(t, int64(1), february.Amount)
+
+	// A payout is not a deposit: it cannot be refunded and no limit counts it
+	_, err = useCases.Wallet.ReverseTransaction(february.ID, "interest_reverse")
+	assert.Equal(t, usecases.ErrTransactionNotRefundable, err)
+	usage, err := repos.LimitRule.GetUsage(alice.ID, models.TransactionTypeCredit, "USD", time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), usage.Count)
+	assert.Equal(t, int64(10000), usage.Amount)
+
+	recorded := 0
+	for day := 2; day <= 10; day++ {
+		fake.Set(time.Date(2026, 3, day, 1, 0, 0, 0, time.UTC))
+		days, err = useCases.Interest.AccrueDaily()
+		require.NoError(t, err)
+		recorded += days
+	}
+	assert.Equal(t, 9, recorded)
+
+	// Money parked across midnight earns nothing: each day earns on the
+	// lowest balance it saw
+	fake.Set(time.Date(2026, 3, 10, 23, 0, 0, 0, time.UTC))
+	_, err = useCases.Wallet.FundWallet(alice.ID, 5000, "USD", "interest_park")
+	require.NoError(t, err)
+	fake.Set(time.Date(2026, 3, 11, 0, 5, 0, 0, time.UTC))
+	_, err = useCases.Wallet.WithdrawFunds(alice.ID, 5000, "USD", "interest_unpark")
+	require.NoError(t, err)
+
+	// The job is then down for the rest of the month; the next run catches
+	// up on every day missed before the month is paid
+	fake.Set(time.Date(2026, 4, 1, 1, 0, 0, 0, time.UTC))
+	days, err = useCases.Interest.AccrueDaily()
+	require.NoError(t, err)
+	assert.Equal(t, 22, days)
+
+	// March: March 1 on 10000, before February's payout arrived, and 30
+	// days on 10001, plus February's carry, is 42.84
+	paid, err = useCases.Interest.PayMonthly()
+	require.NoError(t, err)
+	assert.Equal(t, 1, paid)
+
+	march, err := repos.Transaction.GetByReference("interest:" + alice.Wallet.ID.String() + ":2026-03")
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), march.Amount)
+
+	wallet, err := repos.Wallet.GetByID(alice.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10043), wallet.Balance)
+	assert.Equal(t, int64(3065000), wallet.InterestCarry)
+
+	accruals, err := useCases.Interest.ListAccruals(alice.ID, alice.Wallet.ID)
+	require.NoError(t, err)
+	require.Len(t, accruals, 32)
+	assert.Equal(t, "2026-03-31", accruals[0].Date)
+	assert.Equal(t, march.ID, *accruals[0].TransactionID)
+	for _, accrual := range accruals[:30] {
+		assert.Equal(t, int64(10001), accrual.Balance, accrual.Date)
+	}
+	assert.Equal(t, "2026-03-01", accruals[30].Date)
+	assert.Equal(t, int64(10000), accruals[30].Balance)
+
+	results, err := useCases.Reconciliation.RunReconciliation()
+	require.NoError(t, err)
+	for _, result := range results {
+		assert.False(t, result.HasMismatch, result.WalletID)
+	}
+}
+
+func TestInterest_CatchUpSkipsDaysWithoutARateAndPaidMonths(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 3, 29, 1, 0, 0, 0, time.UTC))
+	useCases, repos := setupUseCasesAt(fake)
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	shop, err := useCases.User.CreateUser("Shop", "shop@example.com")
+	require.NoError(t, err)
+	_, err = useCases.User.SetMerchant(shop.ID, true)
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(alice.ID, 10000, "USD", "gap_fund")
+	require.NoError(t, err)
+	rule, err := useCases.Interest.CreateRule(&models.InterestRateRule{AnnualRateBps: 500})
+	require.NoError(t, err)
+
+	// Promotional credit earns nothing, and spending it leaves the cash
+	// balance where it was
+	_, err = useCases.Promo.Grant(alice.ID, 3000, "USD", fake.Now().Add(90*24*time.Hour), "", "gap_promo")
+	require.NoError(t, err)
+
+	// The shop's empty wallet records its days too, earning nothing
+	fake.Set(time.Date(2026, 3, 31, 1, 0, 0, 0, time.UTC))
+	days, err := useCases.Interest.AccrueDaily()
+	require.NoError(t, err)
+	assert.Equal(t, 2, days)
+
+	fake.Set(time.Date(2026, 4, 2, 12, 0, 0, 0, time.UTC))
+	_, err = useCases.Wallet.TransferFunds(alice.ID, shop.ID, 1000, "USD", "gap_shop")
+	require.NoError(t, err)
+
+	// The job is down across the month end, and March is paid before the
+	// accruals catch up; for Alice, the day that would land in the paid
+	// month is skipped rather than left unpaid
+	fake.Set(time.Date(2026, 4, 3, 1, 0, 0, 0, time.UTC))
+	paid, err := useCases.Interest.PayMonthly()
+	require.NoError(t, err)
+	assert.Equal(t, 1, paid)
+	days, err = useCases.Interest.AccrueDaily()
+	require.NoError(t, err)
+	assert.Equal(t, 2+3, days)
+
+	// Removing the rule loses that whole day, and days without a rule earn
+	// nothing once one is back
+	fake.Set(time.Date(2026, 4, 3, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, useCases.Interest.DeleteRule(rule.ID))
+	fake.Set(time.Date(2026, 4, 5, 12, 0, 0, 0, time.UTC))
+	_, err = useCases.Interest.CreateRule(&models.InterestRateRule{AnnualRateBps: 500})
+	require.NoError(t, err)
+
+	fake.Set(time.Date(2026, 4, 7, 1, 0, 0, 0, time.UTC))
+	days, err = useCases.Interest.AccrueDaily()
+	require.NoError(t, err)
+	assert.Equal(t, 2, days)
+
+	rules, err := useCases.Interest.ListRules()
+	require.NoError(t, err)
+	assert.Len(t, rules, 1)
+
+	accruals, err := useCases.Interest.ListAccruals(alice.ID, alice.Wallet.ID)
+	require.NoError(t, err)
+	dates := make([]string, 0, len(accruals))
+	for _, accrual := range accruals {
+		dates = append(dates, accrual.Date)
+	}
+	assert.Equal(t, []string{"2026-04-06", "2026-04-02", "2026-04-01", "2026-03-30"}, dates)
+	for _, accrual := range accruals[1:] {
+		assert.Equal(t, int64(10000), accrual.Balance, accrual.Date)
+	}
+	assert.Equal(t, int64(10001), accruals[0].Balance)
+
+	// April is two days at 5% on 10000 and one on 10001, once March's
+	// payout arrived, plus March's carry: 4.48, paid as 4
+	fake.Set(time.Date(2026, 5, 1, 1, 0, 0, 0, time.UTC))
+	paid, err = useCases.Interest.PayMonthly()
+	require.NoError(t, err)
+	assert.Equal(t, 1, paid)
+
+	april, err := repos.Transaction.GetByReference("interest:" + alice.Wallet.ID.String() + ":2026-04")
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), april.Amount)
+
+	// Nothing is left due
+	paid, err = useCases.Interest.PayMonthly()
+	require.NoError(t, err)
+	assert.Equal(t, 0, paid)
+}
+
+func TestInterest_MostSpecificRuleApplies(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 3, 1, 1, 0, 0, 0, time.UTC))
+	useCases, repos := setupUseCasesAt(fake)
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	bob, err := useCases.User.CreateUser("Bob", "bob@example.com")
+	require.NoError(t, err)
+
+	pocket, err := useCases.Wallet.CreatePocket(alice.ID, "Savings", "USD")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(alice.ID, 20000, "USD", "interest_alice_fund")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.MoveFunds(alice.ID, alice.Wallet.ID, pocket.ID, 10000, "interest_alice_move")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(bob.ID, 10000, "USD", "interest_bob_fund")
+	require.NoError(t, err)
+
+	_, err = useCases.Interest.CreateRule(&models.InterestRateRule{AnnualRateBps: 100})
+	require.NoError(t, err)
+	_, err = useCases.Interest.CreateRule(&models.InterestRateRule{Product: models.InterestProductPocket, Currency: "usd", AnnualRateBps: 400})
+	require.NoError(t, err)
+	_, err = useCases.Interest.CreateRule(&models.InterestRateRule{Product: "loan", AnnualRateBps: 100})
+	assert.Equal(t, usecases.ErrInvalidInterestRule, err)
+
+	fake.Set(time.Date(2026, 3, 3, 1, 0, 0, 0, time.UTC))
+	days, err := useCases.Interest.AccrueDaily()
+	require.NoError(t, err)
+	assert.Equal(t, 3, days)
+
+	accruals, err := useCases.Interest.ListAccruals(alice.ID, pocket.ID)
+	require.NoError(t, err)
+	require.Len(t, accruals, 1)
+	assert.Equal(t, int64(400), accruals[0].RateBps)
+	assert.Equal(t, int64(10000*400), accruals[0].Accrued)
+
+	accruals, err = useCases.Interest.ListAccruals(bob.ID, bob.Wallet.ID)
+	require.NoError(t, err)
+	require.Len(t, accruals, 1)
+	assert.Equal(t, int64(100), accruals[0].RateBps)
+
+	// Another user's wallet is not found
+	_, err = useCases.Interest.ListAccruals(bob.ID, pocket.ID)
+	assert.Equal(t, usecases.ErrWalletNotFound, err)
+
+	// Less than a minor unit accrued is carried rather than paid
+	fake.Set(time.Date(2026, 4, 15, 1, 0, 0, 0, time.UTC))
+	paid, err := useCases.Interest.PayMonthly()
+	require.NoError(t, err)
+	assert.Equal(t, 1, paid)
+
+	wallet, err := repos.Wallet.GetByID(bob.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10000), wallet.Balance)
+	assert.Equal(t, int64(10000*100), wallet.InterestCarry)
+}
@@ -0,0 +1,145 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/repositories"
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+	"github.com/Code-Linx/wallet-service/pkg/database"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLedger_OperationsPostBalancedEntries(t *testing.T) {
+	useCases, repos := setupUseCases()
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	bob, err := useCases.User.CreateUser("Bob", "bob@example.com")
+	require.NoError(t, err)
+
+	fund, err := useCases.Wallet.FundWallet(alice.ID, 10000, "USD", "ledger_fund")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.WithdrawFunds(alice.ID, 2000, "USD", "ledger_withdraw")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.TransferFunds(alice.ID, bob.ID, 3000, "USD", "ledger_transfer")
+	require.NoError(t, err)
+
+	// The transaction header points at its entries
+	entries, err := repos.Ledger.GetEntriesByTransactionID(fund.ID)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, models.AccountTypeFundingSource, entries[0].DebitAccount.Type)
+	assert.Equal(t, models.AccountTypeUserWallet, entries[0].CreditAccount.Type)
+	assert.Equal(t, int64(10000), entries[0].Amount)
+
+	// Wallet balances are derivable from the ledger
+	results, err := useCases.Reconciliation.RunReconciliation()
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for _, result := range results {
+		assert.False(t, result.HasMismatch)
+		assert.Equal(t, result.StoredBalance, result.LedgerBalance)
+	}
+
+	// System accounts carry the other side of every wallet movement
+	source, err := repos.Ledger.GetAccountByCode(models.AccountCodeFundingSource + ":USD")
+	require.NoError(t, err)
+	sourceBalance, err := repos.Ledger.GetAccountBalance(source.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(-10000), sourceBalance)
+
+	clearing, err := repos.Ledger.GetAccountByCode(models.AccountCodeWithdrawalClearing + ":USD")
+	require.NoError(t, err)
+	clearingBalance, err := repos.Ledger.GetAccountBalance(clearing.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2000), clearingBalance)
+}
+
+func TestLedger_RejectsUnbalancedEntries(t *testing.T) {
+	_, repos := setupUseCases()
+
+	account, err := repos.Ledger.GetOrCreateAccount(&models.LedgerAccount{
+		Code: models.AccountCodeFundingSource,
+		Type: models.AccountTypeFundingSource,
+	})
+	require.NoError(t, err)
+
+	// An entry must move a positive amount between two different accounts
+	err = repos.Ledger.CreateEntries([]models.LedgerEntry{{
+		TransactionID:   uuid.New(),
+		DebitAccountID:  account.ID,
+		CreditAccountID: account.ID,
+		Amount:          100,
+	}})
+	assert.Error(t, err)
+
+	err = repos.Ledger.CreateEntries([]models.LedgerEntry{{
+		TransactionID:   uuid.New(),
+		DebitAccountID:  account.ID,
+		CreditAccountID: uuid.New(),
+		Amount:          -100,
+	}})
+	assert.Error(t, err)
+}
+
+func TestLedger_MigrationOpensBalancesOfWalletsFromBeforeTheLedger(t *testing.T) {
+	db := setupMockDB()
+	repos := repositories.NewRepositories(db)
+	useCases := usecases.NewUseCases(repos)
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	bob, err := useCases.User.CreateUser("Bob", "bob@example.com")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(bob.ID, 700, "USD", "ledger_bob_fund")
+	require.NoError(t, err)
+
+	// Before the ledger, a deposit only wrote the transaction and the balance
+	require.NoError(t, repos.Transaction.Create(&models.Transaction{
+		UserID:    alice.ID,
+		Type:      models.TransactionTypeCredit,
+		Amount:    5000,
+		Currency:  "USD",
+		Status:    models.TransactionStatusCompleted,
+		Reference: "legacy_deposit",
+	}))
+	require.NoError(t, db.Model(&models.Wallet{}).Where("id = ?", alice.Wallet.ID).Update("balance", 5000).Error)
+
+	results, err := useCases.Reconciliation.RunReconciliation()
+	require.NoError(t, err)
+	for _, result := range results {
+		assert.Equal(t, result.WalletID == alice.Wallet.ID, result.HasMismatch, "wallet %s", result.WalletID)
+	}
+
+	// Migrating again carries the legacy wallet onto the ledger, only once
+	require.NoError(t, database.AutoMigrate(db))
+	require.NoError(t, database.AutoMigrate(db))
+
+	results, err = useCases.Reconciliation.RunReconciliation()
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for _, result := range results {
+		assert.False(t, result.HasMismatch, "wallet %s", result.WalletID)
+	}
+
+	opening, err := repos.Transaction.GetByReference("opening_balance:" + alice.Wallet.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, models.TransactionTypeOpeningBalance, opening.Type)
+	entries, err := repos.Ledger.GetEntriesByTransactionID(opening.ID)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, models.AccountTypeOpeningBalances, entries[0].DebitAccount.Type)
+	assert.Equal(t, int64(5000), entries[0].Amount)
+
+	// Wallets already on the ledger are left alone
+	_, err = repos.Transaction.GetByReference("opening_balance:" + bob.Wallet.ID.String())
+	assert.Error(t, err)
+
+	wallet, err := repos.Wallet.GetByID(alice.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5000), wallet.Balance)
+}
@@ -0,0 +1,111 @@
+package unit
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimit_PerTransactionAndRollingTotals(t *testing.T) {
+	useCases, _ := setupUseCases()
+
+	user, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(user.ID, 100000, "USD", "limit_fund")
+	require.NoError(t, err)
+
+	for _, rule := range []*models.LimitRule{
+		{TransactionType: models.TransactionTypeDebit, Period: models.LimitPeriodTransaction, MaxAmount: 5000},
+		{TransactionType: models.TransactionTypeDebit, Period: models.LimitPeriodDay, MaxAmount: 8000, MaxCount: 3},
+	} {
+		_, err := useCases.Limit.CreateRule(rule)
+		require.NoError(t, err)
+	}
+
+	// A single withdrawal above the per-transaction cap is refused outright
+	_, err = useCases.Wallet.WithdrawFunds(user.ID, 6000, "USD", "limit_big")
+	require.ErrorIs(t, err, usecases.ErrLimitExceeded)
+	var limitErr *usecases.LimitExceededError
+	require.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, models.LimitPeriodTransaction, limitErr.Period)
+	assert.Nil(t, limitErr.ResetsAt)
+
+	_, err = useCases.Wallet.WithdrawFunds(user.ID, 5000, "USD", "limit_1")
+	require.NoError(t, err)
+
+	// The daily total counts what was already withdrawn
+	_, err = useCases.Wallet.WithdrawFunds(user.ID, 3001, "USD", "limit_2")
+	require.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, models.LimitPeriodDay, limitErr.Period)
+	assert.Equal(t, usecases.LimitKindAmount, limitErr.Kind)
+	assert.Equal(t, int64(5000), limitErr.Used)
+	require.NotNil(t, limitErr.ResetsAt)
+
+	_, err = useCases.Wallet.WithdrawFunds(user.ID, 1000, "USD", "limit_3")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.WithdrawFunds(user.ID, 1000, "USD", "limit_4")
+	require.NoError(t, err)
+
+	// Three withdrawals a day at most
+	_, err = useCases.Wallet.WithdrawFunds(user.ID, 100, "USD", "limit_5")
+	require.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, usecases.LimitKindCount, limitErr.Kind)
+	assert.Equal(t, int64(3), limitErr.Used)
+
+	// Other transaction types are unaffected
+	_, err = useCases.Wallet.FundWallet(user.ID, 50000, "USD", "limit_fund_again")
+	assert.NoError(t, err)
+}
+
+func TestLimit_UserRuleOverridesTierRule(t *testing.T) {
+	useCases, repos := setupUseCases()
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	bob, err := useCases.User.CreateUser("Bob", "bob@example.com")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(alice.ID, 100000, "USD", "limit_fund_alice")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(bob.ID, 100000, "USD", "limit_fund_bob")
+	require.NoError(t, err)
+
+	_, err = useCases.Limit.CreateRule(&models.LimitRule{
+		TransactionType: models.TransactionTypeTransfer,
+		Tier:            models.UserTierStandard,
+		Period:          models.LimitPeriodTransaction,
+		MaxAmount:       1000,
+	})
+	require.NoError(t, err)
+	_, err = useCases.Limit.CreateRule(&models.LimitRule{
+		TransactionType: models.TransactionTypeTransfer,
+		UserID:          &alice.ID,
+		Period:          models.LimitPeriodTransaction,
+		MaxAmount:       20000,
+	})
+	require.NoError(t, err)
+
+	// A rule cannot target a user and a tier at once
+	_, err = useCases.Limit.CreateRule(&models.LimitRule{
+		TransactionType: models.TransactionTypeTransfer,
+		UserID:          &alice.ID,
+		Tier:            "gold",
+		Period:          models.LimitPeriodDay,
+		MaxCount:        1,
+	})
+	assert.Equal(t, usecases.ErrInvalidLimitRule, err)
+
+	_, err = useCases.Wallet.TransferFunds(alice.ID, bob.ID, 15000, "USD", "limit_alice")
+	assert.NoError(t, err)
+	_, err = useCases.Wallet.TransferFunds(bob.ID, alice.ID, 1500, "USD", "limit_bob")
+	assert.ErrorIs(t, err, usecases.ErrLimitExceeded)
+
+	// Bob leaves the standard tier and with it the tier's limit
+	require.NoError(t, repos.DB.Model(&models.User{}).Where("id = ?", bob.ID).Update("tier", "gold").Error)
+	_, err = useCases.Wallet.TransferFunds(bob.ID, alice.ID, 1500, "USD", "limit_bob")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,175 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/clock"
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverdraft_CreditLineLetsWalletGoNegative(t *testing.T) {
+	useCases, repos := setupUseCases()
+
+	acme, err := useCases.User.CreateUser("Acme", "acme@example.com")
+	require.NoError(t, err)
+	bob, err := useCases.User.CreateUser("Bob", "bob@example.com")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(acme.ID, 1000, "USD", "overdraft_fund")
+	require.NoError(t, err)
+
+	// Without a credit line the balance cannot go below zero
+	_, err = useCases.Wallet.WithdrawFunds(acme.ID, 1500, "USD", "overdraft_early")
+	assert.Equal(t, usecases.ErrInsufficientFunds, err)
+
+	wallet, err := useCases.Wallet.SetCreditLine(acme.Wallet.ID, 5000, 1000)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5000), wallet.CreditLimit)
+
+	_, err = useCases.Wallet.WithdrawFunds(acme.ID, 3000, "USD", "overdraft_withdraw")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.TransferFunds(acme.ID, bob.ID, 2000, "USD", "overdraft_transfer")
+	require.NoError(t, err)
+
+	wallet, err = repos.Wallet.GetByID(acme.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(-4000), wallet.Balance)
+	assert.Equal(t, int64(4000), wallet.OverdraftUsed)
+
+	// The limit is a hard floor, and only withdrawals and transfers use it
+	_, err = useCases.Wallet.TransferFunds(acme.ID, bob.ID, 1001, "USD", "overdraft_too_far")
+	assert.Equal(t, usecases.ErrInsufficientFunds, err)
+	_, err = useCases.Wallet.PlaceHold(acme.ID, 500, "USD", time.Hour, "overdraft_hold")
+	assert.Equal(t, usecases.ErrInsufficientFunds, err)
+
+	// The limit cannot be cut below what is owed, and pockets get none
+	_, err = useCases.Wallet.SetCreditLine(acme.Wallet.ID, 3999, 1000)
+	assert.Equal(t, usecases.ErrCreditLimitBelowUsage, err)
+	pocket, err := useCases.Wallet.CreatePocket(acme.ID, "Tax", "USD")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.SetCreditLine(pocket.ID, 1000, 0)
+	assert.Equal(t, usecases.ErrInvalidCreditLine, err)
+
+	results, err := useCases.Reconciliation.RunReconciliation()
+	require.NoError(t, err)
+	for _, result := range results {
+		assert.False(t, result.HasMismatch, result.WalletID)
+	}
+
+	// Closing needs the overdraft paid back
+	_, err = useCases.Wallet.ChangeWalletStatus(acme.Wallet.ID, models.WalletStatusClosed, "Offboarding", "ops@example.com", nil)
+	assert.Equal(t, usecases.ErrWalletNotEmpty, err)
+}
+
+func TestOverdraft_InterestChargedOncePerDay(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 3, 2, 1, 0, 0, 0, time.UTC))
+	useCases, repos := setupUseCases(usecases.WithClock(fake))
+
+	acme, err := useCases.User.CreateUser("Acme", "acme@example.com")
+	require.NoError(t, err)
+	saver, err := useCases.User.CreateUser("Saver", "saver@example.com")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(saver.ID, 5000, "USD", "interest_saver_fund")
+	require.NoError(t, err)
+
+	// 18.25% a year is 0.05% a day: 50 on 100000
+	_, err = useCases.Wallet.SetCreditLine(acme.Wallet.ID, 200000, 1825)
+	require.NoError(t, err)
+	_, err = useCases.Wallet.WithdrawFunds(acme.ID, 100000, "USD", "interest_withdraw")
+	require.NoError(t, err)
+
+	charged, err := useCases.Wallet.ChargeOverdraftInterest()
+	require.NoError(t, err)
+	assert.Equal(t, 1, charged)
+
+	// Running again the same day charges nothing more
+	charged, err = useCases.Wallet.ChargeOverdraftInterest()
+	require.NoError(t, err)
+	assert.Equal(t, 0, charged)
+
+	fake.Advance(24 * time.Hour)
+	charged, err = useCases.Wallet.ChargeOverdraftInterest()
+	require.NoError(t, err)
+	assert.Equal(t, 1, charged)
+
+	wallet, err := repos.Wallet.GetByID(acme.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(-100100), wallet.Balance)
+
+	charge, err := repos.Transaction.GetByReference("overdraft_interest:" + acme.Wallet.ID.String() + ":2026-03-01")
+	require.NoError(t, err)
+	assert.Equal(t, models.TransactionTypeInterestCharge, charge.Type)
+	assert.Equal(t, int64(50), charge.Amount)
+
+	// Wallets in credit pay nothing
+	wallet, err = repos.Wallet.GetByID(saver.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5000), wallet.Balance)
+
+	results, err := useCases.Reconciliation.RunReconciliation()
+	require.NoError(t, err)
+	for _, result := range results {
+		assert.False(t, result.HasMismatch, result.WalletID)
+	}
+}
+
+func TestOverdraft_InterestAtTheLimitIsNotAMismatch(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 3, 2, 1, 0, 0, 0, time.UTC))
+	useCases, repos := setupUseCases(usecases.WithClock(fake))
+
+	acme, err := useCases.User.CreateUser("Acme", "acme@example.com")
+	require.NoError(t, err)
+
+	// Drawn exactly to the limit
+	_, err = useCases.Wallet.SetCreditLine(acme.Wallet.ID, 100000, 1825)
+	require.NoError(t, err)
+	_, err = useCases.Wallet.WithdrawFunds(acme.ID, 100000, "USD", "limit_withdraw")
+	require.NoError(t, err)
+
+	charged, err := useCases.Wallet.ChargeOverdraftInterest()
+	require.NoError(t, err)
+	assert.Equal(t, 1, charged)
+
+	wallet, err := repos.Wallet.GetByID(acme.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(-100050), wallet.Balance)
+
+	// Interest took the wallet past its limit: reported, but the books agree
+	results, err := useCases.Reconciliation.RunReconciliation()
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].OverLimit)
+	assert.False(t, results[0].HasMismatch)
+	assert.Equal(t, int64(0), results[0].Difference)
+}
+
+func TestOverdraft_OverrunNotExplainedByInterestIsAMismatch(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 3, 2, 1, 0, 0, 0, time.UTC))
+	useCases, repos := setupUseCases(usecases.WithClock(fake))
+
+	acme, err := useCases.User.CreateUser("Acme", "acme@example.com")
+	require.NoError(t, err)
+
+	_, err = useCases.Wallet.SetCreditLine(acme.Wallet.ID, 100000, 1825)
+	require.NoError(t, err)
+	_, err = useCases.Wallet.WithdrawFunds(acme.ID, 100000, "USD", "overrun_withdraw")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.ChargeOverdraftInterest()
+	require.NoError(t, err)
+
+	// The limit is cut behind the service's back, so the wallet is far more
+	// overdrawn than a day's interest can account for
+	require.NoError(t, repos.Wallet.UpdateCreditLine(acme.Wallet.ID, 60000, 1825))
+
+	results, err := useCases.Reconciliation.RunReconciliation()
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].OverLimit)
+	assert.True(t, results[0].HasMismatch)
+	assert.Equal(t, int64(0), results[0].Difference)
+	assert.Equal(t, results[0].StoredBalance, results[0].LedgerBalance)
+}
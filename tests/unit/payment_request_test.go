@@ -0,0 +1,152 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/clock"
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentRequest_AcceptPaysOnce(t *testing.T) {
+	useCases, repos := setupUseCases()
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	bob, err := useCases.User.CreateUser("Bob", "bob@example.com")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(bob.ID, 10000, "USD", "request_fund")
+	require.NoError(t, err)
+
+	request, err := useCases.PaymentRequest.Create(&models.PaymentRequest{
+		RequesterID: alice.ID,
+		PayerID:     bob.ID,
+		Amount:      2500,
+		Memo:        " Dinner ",
+		Reference:   "request_dinner",
+	}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, models.PaymentRequestStatusPending, request.Status)
+	assert.Equal(t, "USD", request.Currency)
+	assert.Equal(t, "Dinner", request.Memo)
+
+	// Creating it again returns the same request
+	again, err := useCases.PaymentRequest.Create(&models.PaymentRequest{
+		RequesterID: alice.ID,
+		PayerID:     bob.ID,
+		Amount:      2500,
+		Reference:   "request_dinner",
+	}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, request.ID, again.ID)
+
+	// Another requester cannot pick up the request by reusing its reference
+	_, err = useCases.PaymentRequest.Create(&models.PaymentRequest{
+		RequesterID: bob.ID,
+		PayerID:     alice.ID,
+		Amount:      2500,
+		Reference:   "request_dinner",
+	}, 0)
+	assert.Equal(t, usecases.ErrTransactionExists, err)
+
+	incoming, err := useCases.PaymentRequest.ListIncoming(bob.ID, "")
+	require.NoError(t, err)
+	require.Len(t, incoming, 1)
+	outgoing, err := useCases.PaymentRequest.ListOutgoing(alice.ID, models.PaymentRequestStatusPending)
+	require.NoError(t, err)
+	require.Len(t, outgoing, 1)
+	incoming, err = useCases.PaymentRequest.ListIncoming(alice.ID, "")
+	require.NoError(t, err)
+	assert.Empty(t, incoming)
+
+	// Only the payer answers
+	_, err = useCases.PaymentRequest.Accept(alice.ID, request.ID)
+	assert.Equal(t, usecases.ErrPaymentRequestForbidden, err)
+
+	accepted, err := useCases.PaymentRequest.Accept(bob.ID, request.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.PaymentRequestStatusAccepted, accepted.Status)
+	require.NotNil(t, accepted.Transaction)
+	assert.Equal(t, int64(2500), accepted.Transaction.Amount)
+
+	// A second accept returns the first payment instead of paying again
+	again, err = useCases.PaymentRequest.Accept(bob.ID, request.ID)
+	require.NoError(t, err)
+	assert.Equal(t, *accepted.TransactionID, *again.TransactionID)
+
+	_, err = useCases.PaymentRequest.Decline(bob.ID, request.ID)
+	assert.Equal(t, usecases.ErrPaymentRequestNotPending, err)
+
+	wallet, err := repos.Wallet.GetByID(alice.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2500), wallet.Balance)
+	wallet, err = repos.Wallet.GetByID(bob.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7500), wallet.Balance)
+}
+
+func TestPaymentRequest_DeclineAndExpiry(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	useCases, repos := setupUseCases(usecases.WithClock(fake))
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	bob, err := useCases.User.CreateUser("Bob", "bob@example.com")
+	require.NoError(t, err)
+
+	declined, err := useCases.PaymentRequest.Create(&models.PaymentRequest{
+		RequesterID: alice.ID,
+		PayerID:     bob.ID,
+		Amount:      1000,
+		Reference:   "request_declined",
+	}, time.Hour)
+	require.NoError(t, err)
+	expiring, err := useCases.PaymentRequest.Create(&models.PaymentRequest{
+		RequesterID: alice.ID,
+		PayerID:     bob.ID,
+		Amount:      1000,
+		Reference:   "request_expiring",
+	}, time.Hour)
+	require.NoError(t, err)
+
+	// Bob cannot pay yet; the request stays open and the attempt is kept
+	_, err = useCases.PaymentRequest.Accept(bob.ID, expiring.ID)
+	assert.Equal(t, usecases.ErrInsufficientFunds, err)
+	expiring, err = useCases.PaymentRequest.Get(alice.ID, expiring.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.PaymentRequestStatusPending, expiring.Status)
+	transactions, _, err := repos.Transaction.GetByUserID(bob.ID, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, transactions, 1)
+	assert.Equal(t, models.TransactionStatusFailed, transactions[0].Status)
+
+	declined, err = useCases.PaymentRequest.Decline(bob.ID, declined.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.PaymentRequestStatusDeclined, declined.Status)
+	require.NotNil(t, declined.RespondedAt)
+
+	fake.Advance(time.Hour)
+
+	// Past its expiry a request cannot be answered, even before the job runs
+	_, err = useCases.Wallet.FundWallet(bob.ID, 5000, "USD", "request_fund")
+	require.NoError(t, err)
+	_, err = useCases.PaymentRequest.Accept(bob.ID, expiring.ID)
+	assert.Equal(t, usecases.ErrPaymentRequestExpired, err)
+
+	expired, err := useCases.PaymentRequest.ExpirePending()
+	require.NoError(t, err)
+	assert.Equal(t, 1, expired)
+
+	requests, err := useCases.PaymentRequest.ListOutgoing(alice.ID, models.PaymentRequestStatusExpired)
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	assert.Equal(t, expiring.ID, requests[0].ID)
+
+	wallet, err := repos.Wallet.GetByID(alice.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), wallet.Balance)
+}
@@ -0,0 +1,222 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayout_ValidatesWholeBatchUpFront(t *testing.T) {
+	useCases, _ := setupUseCases()
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	bob, err := useCases.User.CreateUser("Bob", "bob@example.com")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(alice.ID, 10000, "USD", "payout_fund")
+	require.NoError(t, err)
+
+	_, err = useCases.Payout.CreateBatch(&models.PayoutBatch{
+		UserID:    alice.ID,
+		Mode:      models.PayoutModeBestEffort,
+		Reference: "batch_invalid",
+		Items: []models.PayoutItem{
+			{ToUserID: bob.ID, Amount: 100, Reference: "item_1"},
+			{ToUserID: bob.ID, Amount: 0, Reference: "item_2"},
+			{ToUserID: bob.ID, Amount: 100, Reference: "item_1"},
+			{ToUserID: uuid.New(), Amount: 100, Reference: "item_4"},
+			{ToUserID: alice.ID, Amount: 100, Reference: "item_5"},
+			{ToUserID: bob.ID, Amount: 100, Reference: "payout_fund"},
+		},
+	})
+	require.ErrorIs(t, err, usecases.ErrInvalidPayoutBatch)
+
+	var validationErr *usecases.PayoutValidationError
+	require.ErrorAs(t, err, &validationErr)
+	positions := make([]int, 0, len(validationErr.Items))
+	for _, item := range validationErr.Items {
+		positions = append(positions, item.Position)
+	}
+	assert.Equal(t, []int{2, 3, 4, 5, 6}, positions)
+	assert.Equal(t, usecases.ErrTransactionExists.Error(), validationErr.Items[4].Error)
+
+	// Nothing was saved
+	batches, err := useCases.Payout.ListBatches(alice.ID)
+	require.NoError(t, err)
+	assert.Empty(t, batches)
+
+	// An all-or-nothing batch larger than the balance is refused outright
+	_, err = useCases.Payout.CreateBatch(&models.PayoutBatch{
+		UserID:    alice.ID,
+		Mode:      models.PayoutModeAllOrNothing,
+		Reference: "batch_too_big",
+		Items: []models.PayoutItem{
+			{ToUserID: bob.ID, Amount: 6000, Reference: "big_1"},
+			{ToUserID: bob.ID, Amount: 6000, Reference: "big_2"},
+		},
+	})
+	assert.Equal(t, usecases.ErrInsufficientFunds, err)
+}
+
+func TestPayout_BestEffortRecordsEachItem(t *testing.T) {
+	useCases, repos := setupUseCases()
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	bob, err := useCases.User.CreateUser("Bob", "bob@example.com")
+	require.NoError(t, err)
+	carol, err := useCases.User.CreateUser("Carol", "carol@example.com")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(alice.ID, 5000, "USD", "payout_fund")
+	require.NoError(t, err)
+
+	// An item whose reference was already used reuses that transfer
+	existing, err := useCases.Wallet.TransferFunds(alice.ID, carol.ID, 500, "USD", "pay_carol")
+	require.NoError(t, err)
+
+	batch, err := useCases.Payout.CreateBatch(&models.PayoutBatch{
+		UserID:    alice.ID,
+		Mode:      models.PayoutModeBestEffort,
+		Reference: "batch_best_effort",
+		Items: []models.PayoutItem{
+			{ToUserID: bob.ID, Amount: 3000, Reference: "pay_bob"},
+			{ToUserID: carol.ID, Amount: 500, Reference: "pay_carol"},
+			{ToUserID: bob.ID, Amount: 3000, Reference: "pay_bob_again"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.PayoutBatchStatusPending, batch.Status)
+	assert.Equal(t, int64(6500), batch.TotalAmount)
+
+	// Submitting the same batch again returns it unchanged
+	again, err := useCases.Payout.CreateBatch(&models.PayoutBatch{
+		UserID:    alice.ID,
+		Mode:      models.PayoutModeBestEffort,
+		Reference: "batch_best_effort",
+		Items:     []models.PayoutItem{{ToUserID: bob.ID, Amount: 1, Reference: "other"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, batch.ID, again.ID)
+
+	processed, err := useCases.Payout.ProcessPendingBatches()
+	require.NoError(t, err)
+	assert.Equal(t, 1, processed)
+
+	batch, err = useCases.Payout.GetBatch(alice.ID, batch.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.PayoutBatchStatusPartiallyCompleted, batch.Status)
+	assert.Equal(t, 2, batch.SucceededCount)
+	assert.Equal(t, 1, batch.FailedCount)
+	require.Len(t, batch.Items, 3)
+	assert.Equal(t, models.PayoutItemStatusSucceeded, batch.Items[0].Status)
+	assert.Equal(t, models.PayoutItemStatusSucceeded, batch.Items[1].Status)
+	assert.Equal(t, existing.ID, *batch.Items[1].TransactionID)
+	assert.Equal(t, models.PayoutItemStatusFailed, batch.Items[2].Status)
+	assert.Equal(t, usecases.ErrInsufficientFunds.Error(), batch.Items[2].Error)
+
+	// Processing a finished batch again changes nothing
+	processed, err = useCases.Payout.ProcessPendingBatches()
+	require.NoError(t, err)
+	assert.Equal(t, 0, processed)
+
+	wallet, err := repos.Wallet.GetByUserIDAndCurrency(alice.ID, "USD")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1500), wallet.Balance)
+}
+
+func TestPayout_AllOrNothingRollsBackOnFailure(t *testing.T) {
+	useCases, repos := setupUseCases()
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	bob, err := useCases.User.CreateUser("Bob", "bob@example.com")
+	require.NoError(t, err)
+	carol, err := useCases.User.CreateUser("Carol", "carol@example.com")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(alice.ID, 5000, "USD", "payout_fund")
+	require.NoError(t, err)
+
+	batch, err := useCases.Payout.CreateBatch(&models.PayoutBatch{
+		UserID:    alice.ID,
+		Mode:      models.PayoutModeAllOrNothing,
+		Reference: "batch_atomic",
+		Items: []models.PayoutItem{
+			{ToUserID: bob.ID, Amount: 2000, Reference: "atomic_bob"},
+			{ToUserID: carol.ID, Amount: 2000, Reference: "atomic_carol"},
+		},
+	})
+	require.NoError(t, err)
+
+	// The balance drops after the batch was accepted
+	_, err = useCases.Wallet.WithdrawFunds(alice.ID, 2000, "USD", "payout_withdraw")
+	require.NoError(t, err)
+
+	batch, err = useCases.Payout.ProcessBatch(batch.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.PayoutBatchStatusFailed, batch.Status)
+	assert.Equal(t, models.PayoutItemStatusRolledBack, batch.Items[0].Status)
+	assert.Nil(t, batch.Items[0].TransactionID)
+	assert.Equal(t, models.PayoutItemStatusFailed, batch.Items[1].Status)
+
+	// The first transfer was undone with the rest
+	_, err = repos.Transaction.GetByReference("atomic_bob")
+	assert.Error(t, err)
+	wallet, err := repos.Wallet.GetByUserIDAndCurrency(bob.ID, "USD")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), wallet.Balance)
+
+	// A batch that can be covered applies every item
+	batch, err = useCases.Payout.CreateBatch(&models.PayoutBatch{
+		UserID:    alice.ID,
+		Mode:      models.PayoutModeAllOrNothing,
+		Reference: "batch_atomic_retry",
+		Items: []models.PayoutItem{
+			{ToUserID: bob.ID, Amount: 1500, Reference: "atomic_bob_2"},
+			{ToUserID: carol.ID, Amount: 1500, Reference: "atomic_carol_2"},
+		},
+	})
+	require.NoError(t, err)
+
+	batch, err = useCases.Payout.ProcessBatch(batch.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.PayoutBatchStatusCompleted, batch.Status)
+	assert.Equal(t, 2, batch.SucceededCount)
+
+	wallet, err = repos.Wallet.GetByUserIDAndCurrency(alice.ID, "USD")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), wallet.Balance)
+
+	// A reference taken by someone else's transaction after the batch was
+	// accepted fails the item rather than passing that transaction off as sent
+	_, err = useCases.Wallet.FundWallet(alice.ID, 1000, "USD", "payout_refund")
+	require.NoError(t, err)
+	batch, err = useCases.Payout.CreateBatch(&models.PayoutBatch{
+		UserID:    alice.ID,
+		Mode:      models.PayoutModeAllOrNothing,
+		Reference: "batch_atomic_taken",
+		Items:     []models.PayoutItem{{ToUserID: carol.ID, Amount: 1000, Reference: "atomic_taken"}},
+	})
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(bob.ID, 1000, "USD", "atomic_taken")
+	require.NoError(t, err)
+
+	batch, err = useCases.Payout.ProcessBatch(batch.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.PayoutBatchStatusFailed, batch.Status)
+	assert.Equal(t, usecases.ErrTransactionExists.Error(), batch.Items[0].Error)
+	assert.Nil(t, batch.Items[0].TransactionID)
+	wallet, err = repos.Wallet.GetByUserIDAndCurrency(alice.ID, "USD")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), wallet.Balance)
+
+	results, err := useCases.Reconciliation.RunReconciliation()
+	require.NoError(t, err)
+	for _, result := range results {
+		assert.False(t, result.HasMismatch, result.UserID)
+	}
+}
@@ -0,0 +1,140 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPocket_CreateBesidePrimaryWallet(t *testing.T) {
+	useCases, _ := setupUseCases()
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	assert.True(t, alice.Wallet.IsPrimary)
+
+	pocket, err := useCases.Wallet.CreatePocket(alice.ID, " Rent ", "USD")
+	require.NoError(t, err)
+	assert.Equal(t, "Rent", pocket.Name)
+	assert.False(t, pocket.IsPrimary)
+
+	_, err = useCases.Wallet.CreatePocket(alice.ID, "Rent", "USD")
+	assert.Equal(t, usecases.ErrPocketExists, err)
+	_, err = useCases.Wallet.CreatePocket(alice.ID, "  ", "USD")
+	assert.Equal(t, usecases.ErrInvalidPocketName, err)
+
+	// A pocket needs a primary wallet in its currency
+	_, err = useCases.Wallet.CreatePocket(alice.ID, "Holiday", "EUR")
+	assert.Equal(t, usecases.ErrWalletNotFound, err)
+	_, err = useCases.Wallet.OpenWallet(alice.ID, "EUR")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.CreatePocket(alice.ID, "Holiday", "EUR")
+	require.NoError(t, err)
+
+	// The pocket does not count as the user's USD wallet
+	_, err = useCases.Wallet.OpenWallet(alice.ID, "USD")
+	assert.Equal(t, usecases.ErrWalletExists, err)
+
+	user, err := useCases.User.GetUserByID(alice.ID)
+	require.NoError(t, err)
+	assert.Equal(t, alice.Wallet.ID, user.Wallet.ID)
+	assert.Len(t, user.Wallets, 4)
+}
+
+func TestPocket_MoveFundsKeepsPocketMoneyAside(t *testing.T) {
+	useCases, repos := setupUseCases()
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	bob, err := useCases.User.CreateUser("Bob", "bob@example.com")
+	require.NoError(t, err)
+	pocket, err := useCases.Wallet.CreatePocket(alice.ID, "Holiday", "USD")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(alice.ID, 10000, "USD", "pocket_fund")
+	require.NoError(t, err)
+
+	move, err := useCases.Wallet.MoveFunds(alice.ID, alice.Wallet.ID, pocket.ID, 7000, "pocket_move_in")
+	require.NoError(t, err)
+	assert.Equal(t, models.TransactionTypeMove, move.Type)
+
+	// Retrying the move returns the first one
+	again, err := useCases.Wallet.MoveFunds(alice.ID, alice.Wallet.ID, pocket.ID, 7000, "pocket_move_in")
+	require.NoError(t, err)
+	assert.Equal(t, move.ID, again.ID)
+
+	// User-keyed operations only see the primary wallet
+	_, err = useCases.Wallet.WithdrawFunds(alice.ID, 5000, "USD", "pocket_withdraw")
+	assert.Equal(t, usecases.ErrInsufficientFunds, err)
+
+	_, err = useCases.Wallet.MoveFunds(alice.ID, pocket.ID, alice.Wallet.ID, 8000, "pocket_move_too_much")
+	assert.Equal(t, usecases.ErrInsufficientFunds, err)
+	_, err = useCases.Wallet.MoveFunds(alice.ID, pocket.ID, pocket.ID, 100, "pocket_move_same")
+	assert.Equal(t, usecases.ErrSameWallet, err)
+	_, err = useCases.Wallet.MoveFunds(alice.ID, pocket.ID, bob.Wallet.ID, 100, "pocket_move_bob")
+	assert.Equal(t, usecases.ErrWalletNotFound, err)
+
+	_, err = useCases.Wallet.MoveFunds(alice.ID, pocket.ID, alice.Wallet.ID, 2000, "pocket_move_out")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.WithdrawFunds(alice.ID, 5000, "USD", "pocket_withdraw")
+	require.NoError(t, err)
+
+	stored, err := repos.Wallet.GetByID(pocket.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5000), stored.Balance)
+	stored, err = repos.Wallet.GetByID(alice.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), stored.Balance)
+
+	// History can be narrowed to a single wallet
+	transactions, total, err := useCases.Wallet.GetWalletTransactionHistory(alice.ID, pocket.ID, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Len(t, transactions, 2)
+	// The user's history also keeps the withdrawal that failed
+	_, total, err = useCases.Wallet.GetTransactionHistory(alice.ID, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+	_, _, err = useCases.Wallet.GetWalletTransactionHistory(bob.ID, pocket.ID, 1, 10)
+	assert.Equal(t, usecases.ErrWalletNotFound, err)
+
+	results, err := useCases.Reconciliation.RunReconciliation()
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	for _, result := range results {
+		assert.False(t, result.HasMismatch, result.WalletID)
+	}
+}
+
+func TestPocket_CloseSweepsIntoPrimaryWallet(t *testing.T) {
+	useCases, repos := setupUseCases()
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	pocket, err := useCases.Wallet.CreatePocket(alice.ID, "Rent", "USD")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(alice.ID, 4000, "USD", "pocket_fund")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.MoveFunds(alice.ID, alice.Wallet.ID, pocket.ID, 4000, "pocket_move_in")
+	require.NoError(t, err)
+
+	_, err = useCases.Wallet.ChangeWalletStatus(pocket.ID, models.WalletStatusClosed, "No longer needed", "alice@example.com", &alice.Wallet.ID)
+	require.NoError(t, err)
+
+	sweep, err := repos.Transaction.GetByReference("close:" + pocket.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, models.TransactionTypeMove, sweep.Type)
+
+	stored, err := repos.Wallet.GetByID(alice.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4000), stored.Balance)
+
+	results, err := useCases.Reconciliation.RunReconciliation()
+	require.NoError(t, err)
+	for _, result := range results {
+		assert.False(t, result.HasMismatch, result.WalletID)
+	}
+}
@@ -0,0 +1,165 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/clock"
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromoCredit_SpentOnlyAtMerchantsInPriorityOrder(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	useCases, repos := setupUseCases(usecases.WithClock(fake))
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	bob, err := useCases.User.CreateUser("Bob", "bob@example.com")
+	require.NoError(t, err)
+	shop, err := useCases.User.CreateUser("Shop", "shop@example.com")
+	require.NoError(t, err)
+	shop, err = useCases.User.SetMerchant(shop.ID, true)
+	require.NoError(t, err)
+	assert.True(t, shop.IsMerchant)
+
+	_, err = useCases.Wallet.FundWallet(alice.ID, 1000, "USD", "promo_fund")
+	require.NoError(t, err)
+	late, err := useCases.Promo.Grant(alice.ID, 500, "USD", fake.Now().Add(30*24*time.Hour), "Welcome bonus", "promo_welcome")
+	require.NoError(t, err)
+	soon, err := useCases.Promo.Grant(alice.ID, 300, "USD", fake.Now().Add(7*24*time.Hour), "", "promo_weekend")
+	require.NoError(t, err)
+
+	// Granting again returns the same bucket
+	again, err := useCases.Promo.Grant(alice.ID, 500, "USD", fake.Now().Add(time.Hour), "", "promo_welcome")
+	require.NoError(t, err)
+	assert.Equal(t, late.ID, again.ID)
+
+	wallet, err := repos.Wallet.GetByID(alice.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1800), wallet.Balance)
+	assert.Equal(t, int64(800), wallet.PromoBalance)
+	assert.Equal(t, int64(1000), wallet.AvailableBalance)
+
+	// Promotional credit cannot be withdrawn or sent to other users
+	_, err = useCases.Wallet.WithdrawFunds(alice.ID, 1500, "USD", "promo_withdraw")
+	assert.Equal(t, usecases.ErrInsufficientFunds, err)
+	_, err = useCases.Wallet.TransferFunds(alice.ID, bob.ID, 1500, "USD", "promo_to_bob")
+	assert.Equal(t, usecases.ErrInsufficientFunds, err)
+
+	// A merchant payment uses the bucket expiring soonest, then the next,
+	// then cash
+	payment, err := useCases.Wallet.TransferFunds(alice.ID, shop.ID, 1000, "USD", "promo_shop")
+	require.NoError(t, err)
+	assert.Equal(t, int64(800), payment.PromoAmount)
+
+	buckets, err := useCases.Promo.ListBuckets(alice.ID, "USD")
+	require.NoError(t, err)
+	require.Len(t, buckets, 2)
+	for _, bucket := range buckets {
+		assert.Equal(t, int64(0), bucket.Remaining)
+		assert.Equal(t, models.PromoBucketStatusSpent, bucket.Status)
+	}
+
+	wallet, err = repos.Wallet.GetByID(alice.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(800), wallet.Balance)
+	assert.Equal(t, int64(0), wallet.PromoBalance)
+
+	// A partial refund gives promotional credit back before cash, to the
+	// bucket that was spent last
+	refund, err := useCases.Wallet.RefundTransaction(payment.ID, 400, "promo_refund")
+	require.NoError(t, err)
+	assert.Equal(t, int64(400), refund.PromoAmount)
+
+	buckets, err = useCases.Promo.ListBuckets(alice.ID, "USD")
+	require.NoError(t, err)
+	remaining := map[string]int64{}
+	for _, bucket := range buckets {
+		remaining[bucket.ID.String()] = bucket.Remaining
+	}
+	assert.Equal(t, map[string]int64{late.ID.String(): 400, soon.ID.String(): 0}, remaining)
+
+	wallet, err = repos.Wallet.GetByID(alice.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1200), wallet.Balance)
+	assert.Equal(t, int64(400), wallet.PromoBalance)
+
+	results, err := useCases.Reconciliation.RunReconciliation()
+	require.NoError(t, err)
+	for _, result := range results {
+		assert.False(t, result.HasMismatch, result.WalletID)
+	}
+}
+
+func TestPromoCredit_ExpiresAndIsForfeitedOnClose(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	useCases, repos := setupUseCases(usecases.WithClock(fake))
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	shop, err := useCases.User.CreateUser("Shop", "shop@example.com")
+	require.NoError(t, err)
+	_, err = useCases.User.SetMerchant(shop.ID, true)
+	require.NoError(t, err)
+
+	_, err = useCases.Promo.Grant(alice.ID, 500, "USD", fake.Now(), "", "promo_past")
+	assert.Equal(t, usecases.ErrInvalidPromoExpiry, err)
+
+	expiring, err := useCases.Promo.Grant(alice.ID, 500, "USD", fake.Now().Add(time.Hour), "", "promo_expiring")
+	require.NoError(t, err)
+	_, err = useCases.Promo.Grant(alice.ID, 200, "USD", fake.Now().Add(48*time.Hour), "", "promo_lasting")
+	require.NoError(t, err)
+
+	_, err = useCases.Wallet.TransferFunds(alice.ID, shop.ID, 100, "USD", "promo_coffee")
+	require.NoError(t, err)
+
+	fake.Advance(time.Hour)
+
+	// Past its expiry a bucket cannot be spent, even before the job runs
+	_, err = useCases.Wallet.TransferFunds(alice.ID, shop.ID, 300, "USD", "promo_lunch")
+	assert.Equal(t, usecases.ErrInsufficientFunds, err)
+
+	expired, err := useCases.Promo.ExpireBuckets()
+	require.NoError(t, err)
+	assert.Equal(t, 1, expired)
+
+	buckets, err := useCases.Promo.ListBuckets(alice.ID, "USD")
+	require.NoError(t, err)
+	for _, bucket := range buckets {
+		if bucket.ID == expiring.ID {
+			assert.Equal(t, models.PromoBucketStatusExpired, bucket.Status)
+			assert.Equal(t, int64(0), bucket.Remaining)
+		}
+	}
+
+	wallet, err := repos.Wallet.GetByID(alice.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(200), wallet.Balance)
+	assert.Equal(t, int64(200), wallet.PromoBalance)
+
+	results, err := useCases.Reconciliation.RunReconciliation()
+	require.NoError(t, err)
+	for _, result := range results {
+		assert.False(t, result.HasMismatch, result.WalletID)
+	}
+
+	// Closing the wallet forfeits what is left, so there is nothing to sweep
+	wallet, err = useCases.Wallet.ChangeWalletStatus(alice.Wallet.ID, models.WalletStatusClosed, "Customer request", "ops@example.com", nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), wallet.Balance)
+	assert.Equal(t, int64(0), wallet.PromoBalance)
+
+	transactions, _, err := useCases.Wallet.GetTransactionHistory(alice.ID, 1, 10)
+	require.NoError(t, err)
+	var takenBack int64
+	for _, transaction := range transactions {
+		if transaction.Type == models.TransactionTypePromoExpiry {
+			takenBack += transaction.Amount
+		}
+	}
+	assert.Equal(t, int64(600), takenBack)
+}
@@ -0,0 +1,102 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefund_PartialThenFullTransferRefund(t *testing.T) {
+	useCases, repos := setupUseCases()
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	bob, err := useCases.User.CreateUser("Bob", "bob@example.com")
+	require.NoError(t, err)
+
+	_, err = useCases.Wallet.FundWallet(alice.ID, 10000, "USD", "refund_fund")
+	require.NoError(t, err)
+	transfer, err := useCases.Wallet.TransferFunds(alice.ID, bob.ID, 4000, "USD", "refund_transfer")
+	require.NoError(t, err)
+
+	partial, err := useCases.Wallet.RefundTransaction(transfer.ID, 1500, "refund_partial")
+	require.NoError(t, err)
+	assert.Equal(t, models.TransactionTypeRefund, partial.Type)
+	assert.Equal(t, transfer.ID, *partial.ParentTransactionID)
+	assert.Equal(t, bob.ID, *partial.FromUserID)
+	assert.Equal(t, alice.ID, *partial.ToUserID)
+
+	// Refunding with the same reference is idempotent
+	again, err := useCases.Wallet.RefundTransaction(transfer.ID, 1500, "refund_partial")
+	require.NoError(t, err)
+	assert.Equal(t, partial.ID, again.ID)
+
+	// Refunds larger than what is left are rejected
+	_, err = useCases.Wallet.RefundTransaction(transfer.ID, 3000, "refund_too_much")
+	assert.Equal(t, usecases.ErrRefundExceedsRemaining, err)
+
+	// Reversal refunds the remainder
+	reversal, err := useCases.Wallet.ReverseTransaction(transfer.ID, "refund_reverse")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2500), reversal.Amount)
+
+	_, err = useCases.Wallet.ReverseTransaction(transfer.ID, "refund_reverse_again")
+	assert.Equal(t, usecases.ErrRefundExceedsRemaining, err)
+
+	// Refunds themselves cannot be refunded
+	_, err = useCases.Wallet.ReverseTransaction(reversal.ID, "refund_of_refund")
+	assert.Equal(t, usecases.ErrTransactionNotRefundable, err)
+
+	aliceWallet, err := repos.Wallet.GetByUserIDAndCurrency(alice.ID, "USD")
+	require.NoError(t, err)
+	bobWallet, err := repos.Wallet.GetByUserIDAndCurrency(bob.ID, "USD")
+	require.NoError(t, err)
+	assert.Equal(t, int64(10000), aliceWallet.Balance)
+	assert.Equal(t, int64(0), bobWallet.Balance)
+
+	parent, err := repos.Transaction.GetByID(transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4000), parent.RefundedAmount)
+
+	// Refunds are reflected in the transaction sums used by reconciliation
+	results, err := useCases.Reconciliation.RunReconciliation()
+	require.NoError(t, err)
+	for _, result := range results {
+		assert.False(t, result.HasMismatch)
+	}
+}
+
+func TestRefund_FundingAndWithdrawal(t *testing.T) {
+	useCases, repos := setupUseCases()
+
+	user, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+
+	fund, err := useCases.Wallet.FundWallet(user.ID, 5000, "USD", "refund_fund")
+	require.NoError(t, err)
+	withdrawal, err := useCases.Wallet.WithdrawFunds(user.ID, 3000, "USD", "refund_withdraw")
+	require.NoError(t, err)
+
+	// A funding refund can only take back what is still in the wallet
+	_, err = useCases.Wallet.ReverseTransaction(fund.ID, "refund_fund_reverse")
+	assert.Equal(t, usecases.ErrInsufficientFunds, err)
+
+	_, err = useCases.Wallet.ReverseTransaction(withdrawal.ID, "refund_withdraw_reverse")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.ReverseTransaction(fund.ID, "refund_fund_reverse")
+	require.NoError(t, err)
+
+	wallet, err := repos.Wallet.GetByUserIDAndCurrency(user.ID, "USD")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), wallet.Balance)
+
+	results, err := useCases.Reconciliation.RunReconciliation()
+	require.NoError(t, err)
+	for _, result := range results {
+		assert.False(t, result.HasMismatch)
+	}
+}
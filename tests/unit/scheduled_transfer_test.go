@@ -0,0 +1,279 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/clock"
+	"github.com/Code-Linx/wallet-service/internal/cron"
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCron_Next(t *testing.T) {
+	from := time.Date(2026, 1, 31, 10, 30, 0, 0, time.UTC) // A Saturday
+
+	cases := []struct {
+		expr string
+		want time.Time
+	}{
+		{"*/15 * * * *", time.Date(2026, 1, 31, 10, 45, 0, 0, time.UTC)},
+		{"@daily", time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 9 1 * *", time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC)},
+		{"0 9 * * 1-5", time.Date(2026, 2, 2, 9, 0, 0, 0, time.UTC)},
+		{"0 9 * * 7", time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC)},
+		{"0 0 31 * *", time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)},
+		// With both day fields restricted, either one matching is enough
+		{"0 0 15 * 1", time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range cases {
+		schedule, err := cron.Parse(tc.expr)
+		require.NoError(t, err, tc.expr)
+		assert.Equal(t, tc.want, schedule.Next(from), tc.expr)
+	}
+
+	// Nothing ever matches 30 February
+	schedule, err := cron.Parse("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.True(t, schedule.Next(from).IsZero())
+
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* * * 13 *", "5-1 * * * *", "*/0 * * * *", "@often"} {
+		_, err := cron.Parse(expr)
+		assert.ErrorIs(t, err, cron.ErrInvalidExpression, expr)
+	}
+}
+
+func TestScheduledTransfer_RecurringRunsEachOccurrenceOnce(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC))
+	useCases, repos := setupUseCases(usecases.WithClock(fake))
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	bob, err := useCases.User.CreateUser("Bob", "bob@example.com")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(alice.ID, 10000, "USD", "sched_fund")
+	require.NoError(t, err)
+
+	transfer, err := useCases.Scheduled.Create(&models.ScheduledTransfer{
+		UserID:   alice.ID,
+		ToUserID: bob.ID,
+		Amount:   1500,
+		Schedule: "0 9 1 * *",
+	}, time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, "USD", transfer.Currency)
+	assert.Equal(t, time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC), transfer.NextRunAt)
+
+	// Nothing is due before the first occurrence
+	ran, err := useCases.Scheduled.RunDue()
+	require.NoError(t, err)
+	assert.Equal(t, 0, ran)
+
+	fake.Set(time.Date(2026, 1, 1, 9, 0, 30, 0, time.UTC))
+	ran, err = useCases.Scheduled.RunDue()
+	require.NoError(t, err)
+	assert.Equal(t, 1, ran)
+
+	// A second pass in the same minute finds nothing left to do
+	ran, err = useCases.Scheduled.RunDue()
+	require.NoError(t, err)
+	assert.Equal(t, 0, ran)
+
+	transfer, err = useCases.Scheduled.Get(alice.ID, transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, transfer.RunCount)
+	assert.Equal(t, models.ScheduledTransferStatusActive, transfer.Status)
+	assert.Equal(t, time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC), transfer.NextRunAt.UTC())
+
+	// The occurrence reference is deterministic
+	txn, err := repos.Transaction.GetByReference("scheduled:" + transfer.ID.String() + ":1767258000")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1500), txn.Amount)
+
+	// Occurrences missed while the scheduler was down are skipped, not replayed
+	fake.Set(time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC))
+	ran, err = useCases.Scheduled.RunDue()
+	require.NoError(t, err)
+	assert.Equal(t, 1, ran)
+
+	transfer, err = useCases.Scheduled.Get(alice.ID, transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, transfer.RunCount)
+	assert.Equal(t, time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC), transfer.NextRunAt.UTC())
+
+	wallet, err := repos.Wallet.GetByUserIDAndCurrency(bob.ID, "USD")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3000), wallet.Balance)
+
+	runs, err := useCases.Scheduled.ListRuns(alice.ID, transfer.ID)
+	require.NoError(t, err)
+	assert.Len(t, runs, 2)
+}
+
+func TestScheduledTransfer_RetriesFailuresWithBackoff(t *testing.T) {
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(start)
+	useCases, repos := setupUseCases(
+		usecases.WithClock(fake),
+		usecases.WithRetryPolicy(usecases.RetryPolicy{MaxAttempts: 3, Backoff: 10 * time.Minute}),
+	)
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	bob, err := useCases.User.CreateUser("Bob", "bob@example.com")
+	require.NoError(t, err)
+
+	transfer, err := useCases.Scheduled.Create(&models.ScheduledTransfer{
+		UserID:   alice.ID,
+		ToUserID: bob.ID,
+		Amount:   2000,
+	}, start)
+	require.NoError(t, err)
+
+	// The first attempt fails for lack of funds and is retried after the backoff
+	ran, err := useCases.Scheduled.RunDue()
+	require.NoError(t, err)
+	assert.Equal(t, 1, ran)
+
+	transfer, err = useCases.Scheduled.Get(alice.ID, transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, transfer.FailureCount)
+	assert.Equal(t, usecases.ErrInsufficientFunds.Error(), transfer.LastError)
+	assert.Equal(t, start.Add(10*time.Minute), transfer.NextRunAt.UTC())
+
+	// Not due again until the backoff has passed
+	fake.Advance(5 * time.Minute)
+	ran, err = useCases.Scheduled.RunDue()
+	require.NoError(t, err)
+	assert.Equal(t, 0, ran)
+
+	_, err = useCases.Wallet.FundWallet(alice.ID, 5000, "USD", "sched_retry_fund")
+	require.NoError(t, err)
+
+	fake.Advance(5 * time.Minute)
+	ran, err = useCases.Scheduled.RunDue()
+	require.NoError(t, err)
+	assert.Equal(t, 1, ran)
+
+	// The retry keeps the occurrence's reference and completes the one-off
+	transfer, err = useCases.Scheduled.Get(alice.ID, transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ScheduledTransferStatusCompleted, transfer.Status)
+	assert.Equal(t, 1, transfer.RunCount)
+	assert.Empty(t, transfer.LastError)
+
+	runs, err := useCases.Scheduled.ListRuns(alice.ID, transfer.ID)
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	statuses := map[models.ScheduledTransferRunStatus]int{}
+	for _, run := range runs {
+		statuses[run.Status] = run.Attempt
+	}
+	assert.Equal(t, 1, statuses[models.ScheduledTransferRunFailed])
+	assert.Equal(t, 2, statuses[models.ScheduledTransferRunSucceeded])
+
+	wallet, err := repos.Wallet.GetByUserIDAndCurrency(bob.ID, "USD")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2000), wallet.Balance)
+}
+
+func TestScheduledTransfer_GivesUpAfterMaxAttempts(t *testing.T) {
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(start)
+	useCases, _ := setupUseCases(
+		usecases.WithClock(fake),
+		usecases.WithRetryPolicy(usecases.RetryPolicy{MaxAttempts: 2, Backoff: time.Minute}),
+	)
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	bob, err := useCases.User.CreateUser("Bob", "bob@example.com")
+	require.NoError(t, err)
+
+	oneOff, err := useCases.Scheduled.Create(&models.ScheduledTransfer{
+		UserID: alice.ID, ToUserID: bob.ID, Amount: 1000,
+	}, start)
+	require.NoError(t, err)
+	daily, err := useCases.Scheduled.Create(&models.ScheduledTransfer{
+		UserID: alice.ID, ToUserID: bob.ID, Amount: 1000, Schedule: "0 9 * * *",
+	}, start)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		ran, err := useCases.Scheduled.RunDue()
+		require.NoError(t, err)
+		assert.Equal(t, 2, ran)
+		fake.Advance(time.Minute)
+	}
+
+	// A one-off that runs out of attempts fails for good
+	oneOff, err = useCases.Scheduled.Get(alice.ID, oneOff.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ScheduledTransferStatusFailed, oneOff.Status)
+
+	// A recurring transfer gives up on the occurrence and moves to the next
+	daily, err = useCases.Scheduled.Get(alice.ID, daily.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ScheduledTransferStatusActive, daily.Status)
+	assert.Equal(t, 0, daily.FailureCount)
+	assert.Equal(t, 0, daily.RunCount)
+	assert.Equal(t, start.AddDate(0, 0, 1), daily.NextRunAt.UTC())
+	assert.NotEmpty(t, daily.LastError)
+}
+
+func TestScheduledTransfer_PauseResumeAndCancel(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	useCases, _ := setupUseCases(usecases.WithClock(fake))
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	bob, err := useCases.User.CreateUser("Bob", "bob@example.com")
+	require.NoError(t, err)
+
+	// Validation
+	_, err = useCases.Scheduled.Create(&models.ScheduledTransfer{
+		UserID: alice.ID, ToUserID: bob.ID, Amount: 100, Schedule: "every day",
+	}, time.Time{})
+	assert.Equal(t, usecases.ErrInvalidSchedule, err)
+	_, err = useCases.Scheduled.Create(&models.ScheduledTransfer{
+		UserID: alice.ID, ToUserID: alice.ID, Amount: 100, Schedule: "@daily",
+	}, time.Time{})
+	assert.Equal(t, usecases.ErrSameUser, err)
+
+	transfer, err := useCases.Scheduled.Create(&models.ScheduledTransfer{
+		UserID: alice.ID, ToUserID: bob.ID, Amount: 100, Schedule: "@daily",
+	}, time.Time{})
+	require.NoError(t, err)
+
+	// Other users cannot see it
+	_, err = useCases.Scheduled.Get(bob.ID, transfer.ID)
+	assert.Equal(t, usecases.ErrScheduledTransferNotFound, err)
+
+	paused := models.ScheduledTransferStatusPaused
+	transfer, err = useCases.Scheduled.Update(alice.ID, transfer.ID, usecases.ScheduledTransferUpdate{Status: &paused})
+	require.NoError(t, err)
+	assert.Equal(t, models.ScheduledTransferStatusPaused, transfer.Status)
+
+	fake.Set(time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC))
+	ran, err := useCases.Scheduled.RunDue()
+	require.NoError(t, err)
+	assert.Equal(t, 0, ran)
+
+	// Resuming skips the occurrences that fell due while paused
+	active := models.ScheduledTransferStatusActive
+	transfer, err = useCases.Scheduled.Update(alice.ID, transfer.ID, usecases.ScheduledTransferUpdate{Status: &active})
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 1, 6, 0, 0, 0, 0, time.UTC), transfer.NextRunAt.UTC())
+
+	transfer, err = useCases.Scheduled.Cancel(alice.ID, transfer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ScheduledTransferStatusCancelled, transfer.Status)
+
+	amount := int64(200)
+	_, err = useCases.Scheduled.Update(alice.ID, transfer.ID, usecases.ScheduledTransferUpdate{Amount: &amount})
+	assert.Equal(t, usecases.ErrScheduledTransferClosed, err)
+}
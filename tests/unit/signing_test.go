@@ -0,0 +1,138 @@
+package unit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/auth"
+	"github.com/Code-Linx/wallet-service/internal/clock"
+	"github.com/Code-Linx/wallet-service/internal/handlers"
+	"github.com/Code-Linx/wallet-service/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigning_RejectsTamperedStaleAndReplayedRequests(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	signer, err := auth.NewRequestSigner(map[string]string{"billing": "billing-secret"}, 5*time.Minute, auth.NewMemoryNonceStore(fake), fake)
+	require.NoError(t, err)
+
+	signed := func(nonce string, at time.Time, body string) auth.SignedRequest {
+		req := auth.SignedRequest{
+			KeyID:     "billing",
+			Method:    "POST",
+			Path:      "/api/v1/users/u1/wallet/fund",
+			Timestamp: strconv.FormatInt(at.Unix(), 10),
+			Nonce:     nonce,
+			Body:      []byte(body),
+		}
+		req.Signature = auth.SignRequest("billing-secret", req)
+		return req
+	}
+
+	req := signed("nonce-000000000001", fake.Now(), `{"amount":100}`)
+	require.NoError(t, signer.Verify(req))
+	assert.Equal(t, auth.ErrNonceReused, signer.Verify(req))
+
+	// Changing anything that was signed breaks the signature
+	tampered := signed("nonce-000000000002", fake.Now(), `{"amount":100}`)
+	tampered.Body = []byte(`{"amount":100000}`)
+	assert.Equal(t, auth.ErrSignatureInvalid, signer.Verify(tampered))
+	tampered = signed("nonce-000000000002", fake.Now(), `{"amount":100}`)
+	tampered.Path = "/api/v1/users/u2/wallet/fund"
+	assert.Equal(t, auth.ErrSignatureInvalid, signer.Verify(tampered))
+	tampered = signed("nonce-000000000002", fake.Now(), `{"amount":100}`)
+	tampered.KeyID = "other"
+	assert.Equal(t, auth.ErrSignatureInvalid, signer.Verify(tampered))
+
+	// A forged request did not use up the nonce
+	assert.NoError(t, signer.Verify(signed("nonce-000000000002", fake.Now(), `{"amount":100}`)))
+
+	assert.Equal(t, auth.ErrSignatureStale, signer.Verify(signed("nonce-000000000003", fake.Now().Add(-6*time.Minute), `{}`)))
+	assert.Equal(t, auth.ErrSignatureStale, signer.Verify(signed("nonce-000000000003", fake.Now().Add(6*time.Minute), `{}`)))
+	assert.Equal(t, auth.ErrSignatureInvalid, signer.Verify(signed("short", fake.Now(), `{}`)))
+	assert.Equal(t, auth.ErrSignatureMissing, signer.Verify(auth.SignedRequest{Method: "POST", Path: "/"}))
+
+	// Once the window has passed the replay is refused as stale
+	fake.Advance(6 * time.Minute)
+	assert.Equal(t, auth.ErrSignatureStale, signer.Verify(req))
+}
+
+func TestSigning_MoneyMovingRoutesNeedASignature(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	useCases, repos := setupUseCases()
+
+	keys, err := auth.NewKeyring("test", "test-secret", nil)
+	require.NoError(t, err)
+	tokens := auth.NewTokens(keys, 15*time.Minute, 24*time.Hour, clock.Real())
+	signer, err := auth.NewRequestSigner(map[string]string{"billing": "billing-secret"}, 5*time.Minute, auth.NewMemoryNonceStore(clock.Real()), clock.Real())
+	require.NoError(t, err)
+	h := handlers.NewHandlers(useCases, tokens, signer)
+	router := h.SetupRouter(h)
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	service, err := tokens.Sign("billing", auth.RoleService, auth.TokenTypeAccess, time.Hour)
+	require.NoError(t, err)
+
+	newRequest := func(path, body string) *http.Request {
+		req := httptest.NewRequest("POST", path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+service)
+		return req
+	}
+	sign := func(req *http.Request, nonce, body string) {
+		signed := auth.SignedRequest{
+			KeyID:     "billing",
+			Method:    req.Method,
+			Path:      req.URL.RequestURI(),
+			Timestamp: strconv.FormatInt(time.Now().Unix(), 10),
+			Nonce:     nonce,
+			Body:      []byte(body),
+		}
+		req.Header.Set(middleware.SignatureKeyIDHeader, signed.KeyID)
+		req.Header.Set(middleware.SignatureTimestampHeader, signed.Timestamp)
+		req.Header.Set(middleware.SignatureNonceHeader, signed.Nonce)
+		req.Header.Set(middleware.SignatureHeader, auth.SignRequest("billing-secret", signed))
+	}
+	serve := func(req *http.Request) int {
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp.Code
+	}
+
+	fundPath := "/api/v1/users/" + alice.ID.String() + "/wallet/fund"
+	body := `{"amount":1000,"reference":"signed_fund"}`
+
+	assert.Equal(t, http.StatusUnauthorized, serve(newRequest(fundPath, body)))
+
+	req := newRequest(fundPath, body)
+	sign(req, "fund-nonce-000001", body)
+	assert.Equal(t, http.StatusOK, serve(req))
+
+	// The same signed request cannot be sent again
+	replay := newRequest(fundPath, body)
+	replay.Header = req.Header.Clone()
+	assert.Equal(t, http.StatusUnauthorized, serve(replay))
+
+	// A body changed after signing is refused
+	tampered := newRequest(fundPath, `{"amount":999999,"reference":"signed_fund_2"}`)
+	sign(tampered, "fund-nonce-000002", `{"amount":1000,"reference":"signed_fund_2"}`)
+	assert.Equal(t, http.StatusUnauthorized, serve(tampered))
+
+	withdrawPath := "/api/v1/users/" + alice.ID.String() + "/wallet/withdraw"
+	body = `{"amount":400,"reference":"signed_withdraw"}`
+	req = newRequest(withdrawPath, body)
+	sign(req, "withdraw-nonce-0001", body)
+	assert.Equal(t, http.StatusOK, serve(req))
+
+	wallet, err := repos.Wallet.GetByID(alice.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(600), wallet.Balance)
+}
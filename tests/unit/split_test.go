@@ -0,0 +1,185 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitPayment_ExactAmounts(t *testing.T) {
+	useCases, repos := setupUseCases()
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	bob, err := useCases.User.CreateUser("Bob", "bob@example.com")
+	require.NoError(t, err)
+	carol, err := useCases.User.CreateUser("Carol", "carol@example.com")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(alice.ID, 10000, "USD", "split_fund")
+	require.NoError(t, err)
+
+	shares := []usecases.SplitShare{
+		{ToUserID: bob.ID, Amount: 3000},
+		{ToUserID: carol.ID, Amount: 2000},
+	}
+
+	// A total that does not match the shares is rejected
+	_, err = useCases.Wallet.SplitPayment(alice.ID, 6000, "USD", shares, "split_dinner")
+	assert.Equal(t, usecases.ErrInvalidSplit, err)
+
+	split, err := useCases.Wallet.SplitPayment(alice.ID, 0, "USD", shares, "split_dinner")
+	require.NoError(t, err)
+	assert.Equal(t, models.TransactionTypeSplit, split.Type)
+	assert.Equal(t, int64(5000), split.Amount)
+	require.Len(t, split.Legs, 2)
+
+	// Retrying returns the same split
+	again, err := useCases.Wallet.SplitPayment(alice.ID, 0, "USD", shares, "split_dinner")
+	require.NoError(t, err)
+	assert.Equal(t, split.ID, again.ID)
+
+	for userID, balance := range map[*models.User]int64{alice: 5000, bob: 3000, carol: 2000} {
+		wallet, err := repos.Wallet.GetByID(userID.Wallet.ID)
+		require.NoError(t, err)
+		assert.Equal(t, balance, wallet.Balance, userID.Name)
+	}
+
+	// Each recipient sees their leg and the whole split
+	transactions, total, err := useCases.Wallet.GetTransactionHistory(bob.ID, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	var parent *models.Transaction
+	for i := range transactions {
+		if transactions[i].ID == split.ID {
+			parent = &transactions[i]
+		}
+	}
+	require.NotNil(t, parent)
+	assert.Len(t, parent.Legs, 2)
+
+	_, total, err = useCases.Wallet.GetTransactionHistory(alice.ID, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), total)
+
+	results, err := useCases.Reconciliation.RunReconciliation()
+	require.NoError(t, err)
+	for _, result := range results {
+		assert.False(t, result.HasMismatch, result.WalletID)
+	}
+}
+
+func TestSplitPayment_PercentagesRoundDeterministically(t *testing.T) {
+	useCases, _ := setupUseCases()
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	bob, err := useCases.User.CreateUser("Bob", "bob@example.com")
+	require.NoError(t, err)
+	carol, err := useCases.User.CreateUser("Carol", "carol@example.com")
+	require.NoError(t, err)
+	dave, err := useCases.User.CreateUser("Dave", "dave@example.com")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(alice.ID, 10000, "USD", "split_fund")
+	require.NoError(t, err)
+
+	legAmounts := func(split *models.Transaction) map[string]int64 {
+		amounts := make(map[string]int64)
+		for _, leg := range split.Legs {
+			amounts[leg.ToUserID.String()] = leg.Amount
+		}
+		return amounts
+	}
+
+	// The unit lost to rounding goes to the largest remainder
+	split, err := useCases.Wallet.SplitPayment(alice.ID, 10, "USD", []usecases.SplitShare{
+		{ToUserID: bob.ID, BasisPoints: 3333},
+		{ToUserID: carol.ID, BasisPoints: 3333},
+		{ToUserID: dave.ID, BasisPoints: 3334},
+	}, "split_thirds")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{bob.ID.String(): 3, carol.ID.String(): 3, dave.ID.String(): 4}, legAmounts(split))
+
+	// On a tie it goes to the earlier share
+	split, err = useCases.Wallet.SplitPayment(alice.ID, 101, "USD", []usecases.SplitShare{
+		{ToUserID: carol.ID, BasisPoints: 5000},
+		{ToUserID: bob.ID, BasisPoints: 5000},
+	}, "split_halves")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{carol.ID.String(): 51, bob.ID.String(): 50}, legAmounts(split))
+
+	// Percentages must add up to 100% and may not be mixed with amounts
+	_, err = useCases.Wallet.SplitPayment(alice.ID, 100, "USD", []usecases.SplitShare{
+		{ToUserID: bob.ID, BasisPoints: 5000},
+		{ToUserID: carol.ID, BasisPoints: 4000},
+	}, "split_short")
+	assert.Equal(t, usecases.ErrInvalidSplit, err)
+	_, err = useCases.Wallet.SplitPayment(alice.ID, 100, "USD", []usecases.SplitShare{
+		{ToUserID: bob.ID, BasisPoints: 5000},
+		{ToUserID: carol.ID, Amount: 50},
+	}, "split_mixed")
+	assert.Equal(t, usecases.ErrInvalidSplit, err)
+}
+
+func TestSplitPayment_FailsAsAWhole(t *testing.T) {
+	useCases, repos := setupUseCases()
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	bob, err := useCases.User.CreateUser("Bob", "bob@example.com")
+	require.NoError(t, err)
+	carol, err := useCases.User.CreateUser("Carol", "carol@example.com")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(alice.ID, 5000, "USD", "split_fund")
+	require.NoError(t, err)
+
+	// The first leg fits but the second does not, so neither is paid
+	_, err = useCases.Wallet.SplitPayment(alice.ID, 0, "USD", []usecases.SplitShare{
+		{ToUserID: bob.ID, Amount: 3000},
+		{ToUserID: carol.ID, Amount: 3000},
+	}, "split_too_much")
+	assert.Equal(t, usecases.ErrInsufficientFunds, err)
+
+	wallet, err := repos.Wallet.GetByID(bob.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), wallet.Balance)
+	wallet, err = repos.Wallet.GetByID(alice.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5000), wallet.Balance)
+
+	// The attempt is kept as a failed split
+	transactions, _, err := useCases.Wallet.GetTransactionHistory(alice.ID, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, transactions, 2)
+	var failed *models.Transaction
+	for i := range transactions {
+		if transactions[i].Type == models.TransactionTypeSplit {
+			failed = &transactions[i]
+		}
+	}
+	require.NotNil(t, failed)
+	assert.Equal(t, models.TransactionStatusFailed, failed.Status)
+	assert.Empty(t, failed.Legs)
+
+	// A recipient whose wallet cannot take money blocks the whole split
+	_, err = useCases.Wallet.ChangeWalletStatus(carol.Wallet.ID, models.WalletStatusSuspended, "Fraud report", "ops@example.com", nil)
+	require.NoError(t, err)
+	_, err = useCases.Wallet.SplitPayment(alice.ID, 0, "USD", []usecases.SplitShare{
+		{ToUserID: bob.ID, Amount: 1000},
+		{ToUserID: carol.ID, Amount: 1000},
+	}, "split_suspended")
+	assert.Equal(t, usecases.ErrWalletSuspended, err)
+
+	wallet, err = repos.Wallet.GetByID(bob.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), wallet.Balance)
+
+	_, err = useCases.Wallet.SplitPayment(alice.ID, 0, "USD", []usecases.SplitShare{
+		{ToUserID: bob.ID, Amount: 1000},
+		{ToUserID: bob.ID, Amount: 1000},
+	}, "split_twice")
+	assert.Equal(t, usecases.ErrInvalidSplit, err)
+}
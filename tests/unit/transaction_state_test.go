@@ -0,0 +1,112 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/clock"
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionState_FailedAttemptsArePersisted(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	useCases, _ := setupUseCases(usecases.WithClock(fake))
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	bob, err := useCases.User.CreateUser("Bob", "bob@example.com")
+	require.NoError(t, err)
+
+	_, err = useCases.Wallet.TransferFunds(alice.ID, bob.ID, 5000, "USD", "state_transfer")
+	assert.Equal(t, usecases.ErrInsufficientFunds, err)
+	_, err = useCases.Wallet.TransferFunds(alice.ID, uuid.New(), 100, "USD", "state_unknown")
+	assert.Equal(t, usecases.ErrRecipientNotFound, err)
+
+	transactions, total, err := useCases.Wallet.GetTransactionHistory(alice.ID, 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), total)
+	for _, transaction := range transactions {
+		assert.Equal(t, models.TransactionStatusFailed, transaction.Status)
+		require.NotNil(t, transaction.FailedAt)
+		assert.True(t, fake.Now().Equal(*transaction.FailedAt))
+		assert.Nil(t, transaction.CompletedAt)
+	}
+	reasons := []string{transactions[0].FailureReason, transactions[1].FailureReason}
+	assert.ElementsMatch(t, []string{usecases.ErrInsufficientFunds.Error(), usecases.ErrRecipientNotFound.Error()}, reasons)
+
+	// The failure leaves the reference free, so the same transfer can be retried
+	_, err = useCases.Wallet.FundWallet(alice.ID, 10000, "USD", "state_fund")
+	require.NoError(t, err)
+	transfer, err := useCases.Wallet.TransferFunds(alice.ID, bob.ID, 5000, "USD", "state_transfer")
+	require.NoError(t, err)
+	assert.Equal(t, models.TransactionStatusCompleted, transfer.Status)
+	assert.Equal(t, "state_transfer", transfer.Reference)
+	require.NotNil(t, transfer.CompletedAt)
+
+	// Failed attempts never move money
+	results, err := useCases.Reconciliation.RunReconciliation()
+	require.NoError(t, err)
+	for _, result := range results {
+		assert.False(t, result.HasMismatch, result.WalletID)
+	}
+}
+
+func TestTransactionState_RejectsInvalidTransitions(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	useCases, repos := setupUseCases(usecases.WithClock(fake))
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	bob, err := useCases.User.CreateUser("Bob", "bob@example.com")
+	require.NoError(t, err)
+	funding, err := useCases.Wallet.FundWallet(alice.ID, 10000, "USD", "state_fund")
+	require.NoError(t, err)
+
+	// Completed is final
+	err = repos.Transaction.Transition(funding, models.TransactionStatusFailed, "late failure", fake.Now())
+	assert.ErrorIs(t, err, models.ErrInvalidTransition)
+	stored, err := repos.Transaction.GetByID(funding.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.TransactionStatusCompleted, stored.Status)
+
+	// Transactions cannot be created past their initial status
+	err = repos.Transaction.Create(&models.Transaction{
+		UserID:    alice.ID,
+		Type:      models.TransactionTypeCredit,
+		Amount:    100,
+		Currency:  "USD",
+		Status:    models.TransactionStatusCancelled,
+		Reference: "state_cancelled",
+	})
+	assert.ErrorIs(t, err, models.ErrInvalidTransition)
+
+	escrow, err := useCases.Wallet.CreateEscrow(alice.ID, bob.ID, 3000, "USD", time.Hour, "state_escrow")
+	require.NoError(t, err)
+
+	fake.Advance(time.Minute)
+	escrow, err = useCases.Wallet.DisputeEscrow(bob.ID, escrow.ID, "Wrong item")
+	require.NoError(t, err)
+	require.NotNil(t, escrow.DisputedAt)
+	assert.True(t, fake.Now().Equal(*escrow.DisputedAt))
+
+	// A stale copy of the escrow cannot be moved on from pending
+	stale := *escrow
+	stale.Status = models.TransactionStatusPending
+	err = repos.Transaction.Transition(&stale, models.TransactionStatusFailed, "stale", fake.Now())
+	assert.ErrorIs(t, err, models.ErrInvalidTransition)
+
+	fake.Advance(time.Minute)
+	escrow, err = useCases.Wallet.ResolveEscrow(escrow.ID, models.EscrowResolutionRefund, "Seller agreed", "disputes@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, models.TransactionStatusCancelled, escrow.Status)
+	require.NotNil(t, escrow.CancelledAt)
+	assert.True(t, fake.Now().Equal(*escrow.CancelledAt))
+
+	assert.False(t, models.TransactionStatusCancelled.CanTransitionTo(models.TransactionStatusCompleted))
+	assert.True(t, models.TransactionStatusDisputed.CanTransitionTo(models.TransactionStatusCompleted))
+}
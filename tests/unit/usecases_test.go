@@ -4,9 +4,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Code-Linx/wallet-service/internal/clock"
 	"github.com/Code-Linx/wallet-service/internal/models"
 	"github.com/Code-Linx/wallet-service/internal/repositories"
 	"github.com/Code-Linx/wallet-service/internal/usecases"
+	"github.com/Code-Linx/wallet-service/pkg/database"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -55,24 +57,95 @@ func (m *MockUserRepository) GetAll() ([]models.User, error) {
 	return args.Get(0).([]models.User), args.Error(1)
 }
 
+func (m *MockUserRepository) Search(query string, limit, offset int) ([]models.User, int64, error) {
+	args := m.Called(query, limit, offset)
+	return args.Get(0).([]models.User), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockUserRepository) UpdateMerchant(id uuid.UUID, isMerchant bool) error {
+	args := m.Called(id, isMerchant)
+	return args.Error(0)
+}
+
 func (m *MockWalletRepository) Create(wallet *models.Wallet) error {
 	args := m.Called(wallet)
 	return args.Error(0)
 }
 
-func (m *MockWalletRepository) GetByUserID(userID uuid.UUID) (*models.Wallet, error) {
-	args := m.Called(userID)
+func (m *MockWalletRepository) GetByUserIDAndCurrency(userID uuid.UUID, currencyCode string) (*models.Wallet, error) {
+	args := m.Called(userID, currencyCode)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.Wallet), args.Error(1)
 }
 
-func (m *MockWalletRepository) UpdateBalance(userID uuid.UUID, newBalance int64) error {
-	args := m.Called(userID, newBalance)
+func (m *MockWalletRepository) GetByUserIDAndCurrencyForUpdate(userID uuid.UUID, currencyCode string) (*models.Wallet, error) {
+	args := m.Called(userID, currencyCode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Wallet), args.Error(1)
+}
+
+func (m *MockWalletRepository) GetPocket(userID uuid.UUID, currencyCode, name string) (*models.Wallet, error) {
+	args := m.Called(userID, currencyCode, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Wallet), args.Error(1)
+}
+
+func (m *MockWalletRepository) ListByUserID(userID uuid.UUID) ([]models.Wallet, error) {
+	args := m.Called(userID)
+	return args.Get(0).([]models.Wallet), args.Error(1)
+}
+
+func (m *MockWalletRepository) AdjustBalance(walletID uuid.UUID, delta int64) error {
+	args := m.Called(walletID, delta)
+	return args.Error(0)
+}
+
+func (m *MockWalletRepository) AdjustHeldBalance(walletID uuid.UUID, delta int64) error {
+	args := m.Called(walletID, delta)
+	return args.Error(0)
+}
+
+func (m *MockWalletRepository) AdjustPromoBalance(walletID uuid.UUID, delta int64) error {
+	args := m.Called(walletID, delta)
+	return args.Error(0)
+}
+
+func (m *MockWalletRepository) UpdateCreditLine(walletID uuid.UUID, creditLimit, overdraftRateBps int64) error {
+	args := m.Called(walletID, creditLimit, overdraftRateBps)
+	return args.Error(0)
+}
+
+func (m *MockWalletRepository) ListOverdrawn() ([]models.Wallet, error) {
+	args := m.Called()
+	return args.Get(0).([]models.Wallet), args.Error(1)
+}
+
+func (m *MockWalletRepository) UpdateInterestCarry(walletID uuid.UUID, carry int64) error {
+	args := m.Called(walletID, carry)
+	return args.Error(0)
+}
+
+func (m *MockWalletRepository) UpdateStatus(walletID uuid.UUID, status models.WalletStatus, reason string) error {
+	args := m.Called(walletID, status, reason)
 	return args.Error(0)
 }
 
+func (m *MockWalletRepository) CreateStatusChange(change *models.WalletStatusChange) error {
+	args := m.Called(change)
+	return args.Error(0)
+}
+
+func (m *MockWalletRepository) ListStatusChanges(walletID uuid.UUID) ([]models.WalletStatusChange, error) {
+	args := m.Called(walletID)
+	return args.Get(0).([]models.WalletStatusChange), args.Error(1)
+}
+
 func (m *MockWalletRepository) GetByID(id uuid.UUID) (*models.Wallet, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
@@ -81,6 +154,14 @@ func (m *MockWalletRepository) GetByID(id uuid.UUID) (*models.Wallet, error) {
 	return args.Get(0).(*models.Wallet), args.Error(1)
 }
 
+func (m *MockWalletRepository) GetByIDForUpdate(id uuid.UUID) (*models.Wallet, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Wallet), args.Error(1)
+}
+
 func (m *MockWalletRepository) GetAllWallets() ([]models.Wallet, error) {
 	args := m.Called()
 	return args.Get(0).([]models.Wallet), args.Error(1)
@@ -91,6 +172,22 @@ func (m *MockTransactionRepository) Create(transaction *models.Transaction) erro
 	return args.Error(0)
 }
 
+func (m *MockTransactionRepository) GetByID(id uuid.UUID) (*models.Transaction, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Transaction), args.Error(1)
+}
+
+func (m *MockTransactionRepository) GetByIDForUpdate(id uuid.UUID) (*models.Transaction, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Transaction), args.Error(1)
+}
+
 func (m *MockTransactionRepository) GetByReference(reference string) (*models.Transaction, error) {
 	args := m.Called(reference)
 	if args.Get(0) == nil {
@@ -104,13 +201,23 @@ func (m *MockTransactionRepository) GetByUserID(userID uuid.UUID, limit, offset
 	return args.Get(0).([]models.Transaction), args.Get(1).(int64), args.Error(2)
 }
 
-func (m *MockTransactionRepository) GetUserTransactionSum(userID uuid.UUID) (int64, error) {
-	args := m.Called(userID)
+func (m *MockTransactionRepository) GetByWalletID(walletID uuid.UUID, limit, offset int) ([]models.Transaction, int64, error) {
+	args := m.Called(walletID, limit, offset)
+	return args.Get(0).([]models.Transaction), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockTransactionRepository) Transition(transaction *models.Transaction, to models.TransactionStatus, reason string, at time.Time) error {
+	args := m.Called(transaction, to, reason, at)
+	return args.Error(0)
+}
+
+func (m *MockTransactionRepository) GetUserTransactionSum(userID uuid.UUID, currencyCode string) (int64, error) {
+	args := m.Called(userID, currencyCode)
 	return args.Get(0).(int64), args.Error(1)
 }
 
-func (m *MockTransactionRepository) Update(transaction *models.Transaction) error {
-	args := m.Called(transaction)
+func (m *MockTransactionRepository) UpdateRefundedAmount(id uuid.UUID, refundedAmount int64) error {
+	args := m.Called(id, refundedAmount)
 	return args.Error(0)
 }
 
@@ -123,8 +230,16 @@ func setupMockDB() *gorm.DB {
 		panic("failed to create mock database: " + err.Error())
 	}
 
+	// Every connection to ":memory:" opens its own empty database, so pin
+	// the pool to a single connection
+	sqlDB, err := db.DB()
+	if err != nil {
+		panic("failed to get mock database handle: " + err.Error())
+	}
+	sqlDB.SetMaxOpenConns(1)
+
 	// Auto-migrate the tables that your application uses
-	err = db.AutoMigrate(&models.User{}, &models.Wallet{}, &models.Transaction{})
+	err = database.AutoMigrate(db)
 	if err != nil {
 		panic("failed to migrate test database: " + err.Error())
 	}
@@ -132,6 +247,21 @@ func setupMockDB() *gorm.DB {
 	return db
 }
 
+// setupUseCases wires real repositories over a fresh in-memory database
+func setupUseCases(opts ...usecases.Option) (*usecases.UseCases, *repositories.Repositories) {
+	repos := repositories.NewRepositories(setupMockDB())
+	return usecases.NewUseCases(repos, opts...), repos
+}
+
+// setupUseCasesAt is setupUseCases with the clock and the database's
+// timestamps both following the fake clock, for tests that read history back
+func setupUseCasesAt(fake *clock.Fake, opts ...usecases.Option) (*usecases.UseCases, *repositories.Repositories) {
+	db := setupMockDB()
+	db.Config.NowFunc = fake.Now
+	repos := repositories.NewRepositories(db)
+	return usecases.NewUseCases(repos, append(opts, usecases.WithClock(fake))...), repos
+}
+
 // Test cases
 
 func TestCreateUser_Success(t *testing.T) {
@@ -207,7 +337,7 @@ func TestFundWallet_InvalidAmount(t *testing.T) {
 	reference := "test-ref-123"
 
 	// Call the function under test and assert the error
-	_, err := walletUseCase.FundWallet(userID, amount, reference)
+	_, err := walletUseCase.FundWallet(userID, amount, "USD", reference)
 
 	// The core of the test: check if the returned error is the expected one
 	assert.Equal(t, usecases.ErrInvalidAmount, err, "Expected invalid amount error")
@@ -0,0 +1,142 @@
+package unit
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/clock"
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVoucher_SingleUseRedeemsOnce(t *testing.T) {
+	fake := clock.NewFake(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	useCases, repos := setupUseCases(usecases.WithClock(fake))
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	bob, err := useCases.User.CreateUser("Bob", "bob@example.com")
+	require.NoError(t, err)
+
+	batch, err := useCases.Voucher.GenerateBatch(&models.VoucherBatch{
+		Amount:    2000,
+		ExpiresAt: fake.Now().Add(24 * time.Hour),
+	}, 3)
+	require.NoError(t, err)
+	require.Len(t, batch.Vouchers, 3)
+	assert.Equal(t, "USD", batch.Currency)
+	assert.Equal(t, 1, batch.MaxRedemptions)
+
+	codes := map[string]bool{}
+	for _, voucher := range batch.Vouchers {
+		assert.Len(t, voucher.Code, 19)
+		codes[voucher.Code] = true
+	}
+	assert.Len(t, codes, 3)
+
+	// Only the hash is kept
+	stored, err := useCases.Voucher.GetBatch(batch.ID)
+	require.NoError(t, err)
+	for _, voucher := range stored.Vouchers {
+		assert.Empty(t, voucher.Code)
+		assert.Len(t, voucher.CodeHash, 64)
+	}
+
+	// Codes are matched however they are typed
+	code := batch.Vouchers[0].Code
+	typed := strings.ToLower(strings.ReplaceAll(code, "-", " "))
+	credit, err := useCases.Voucher.Redeem(alice.ID, typed)
+	require.NoError(t, err)
+	assert.Equal(t, models.TransactionTypeCredit, credit.Type)
+	assert.Equal(t, int64(2000), credit.Amount)
+
+	// Redeeming again returns the first credit
+	again, err := useCases.Voucher.Redeem(alice.ID, code)
+	require.NoError(t, err)
+	assert.Equal(t, credit.ID, again.ID)
+
+	_, err = useCases.Voucher.Redeem(bob.ID, code)
+	assert.Equal(t, usecases.ErrVoucherRedeemed, err)
+	_, err = useCases.Voucher.Redeem(bob.ID, "NOT-A-REAL-CODE")
+	assert.Equal(t, usecases.ErrVoucherNotFound, err)
+
+	fake.Advance(24 * time.Hour)
+	_, err = useCases.Voucher.Redeem(bob.ID, batch.Vouchers[1].Code)
+	assert.Equal(t, usecases.ErrVoucherExpired, err)
+
+	wallet, err := repos.Wallet.GetByID(alice.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2000), wallet.Balance)
+	wallet, err = repos.Wallet.GetByID(bob.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), wallet.Balance)
+
+	stored, err = useCases.Voucher.GetBatch(batch.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.VoucherStatusExhausted, stored.Vouchers[indexOfVoucher(stored, batch.Vouchers[0].ID.String())].Status)
+}
+
+func TestVoucher_MultiUseLimitHoldsUnderConcurrency(t *testing.T) {
+	useCases, _ := setupUseCases()
+
+	batch, err := useCases.Voucher.GenerateBatch(&models.VoucherBatch{
+		Amount:         500,
+		MaxRedemptions: 3,
+		ExpiresAt:      time.Now().Add(time.Hour),
+	}, 1)
+	require.NoError(t, err)
+	code := batch.Vouchers[0].Code
+
+	var users []*models.User
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		user, err := useCases.User.CreateUser(name, name+"@example.com")
+		require.NoError(t, err)
+		users = append(users, user)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(users))
+	for i, user := range users {
+		wg.Add(1)
+		go func(i int, user *models.User) {
+			defer wg.Done()
+			_, errs[i] = useCases.Voucher.Redeem(user.ID, code)
+		}(i, user)
+	}
+	wg.Wait()
+
+	redeemed := 0
+	for _, err := range errs {
+		if err == nil {
+			redeemed++
+			continue
+		}
+		assert.Equal(t, usecases.ErrVoucherRedeemed, err)
+	}
+	assert.Equal(t, 3, redeemed)
+
+	stored, err := useCases.Voucher.GetBatch(batch.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, stored.Vouchers[0].Redemptions)
+	assert.Equal(t, models.VoucherStatusExhausted, stored.Vouchers[0].Status)
+
+	results, err := useCases.Reconciliation.RunReconciliation()
+	require.NoError(t, err)
+	for _, result := range results {
+		assert.False(t, result.HasMismatch, result.WalletID)
+	}
+}
+
+func indexOfVoucher(batch *models.VoucherBatch, id string) int {
+	for i, voucher := range batch.Vouchers {
+		if voucher.ID.String() == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,161 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Code-Linx/wallet-service/internal/models"
+	"github.com/Code-Linx/wallet-service/internal/usecases"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalletStatus_FrozenBlocksDebitsOnly(t *testing.T) {
+	useCases, repos := setupUseCases()
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	bob, err := useCases.User.CreateUser("Bob", "bob@example.com")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(alice.ID, 10000, "USD", "status_fund")
+	require.NoError(t, err)
+	hold, err := useCases.Wallet.PlaceHold(alice.ID, 1000, "USD", time.Hour, "status_hold")
+	require.NoError(t, err)
+
+	// A reason and actor are mandatory
+	_, err = useCases.Wallet.ChangeWalletStatus(alice.Wallet.ID, models.WalletStatusFrozen, "", "compliance@example.com", nil)
+	assert.Equal(t, usecases.ErrStatusReasonRequired, err)
+
+	wallet, err := useCases.Wallet.ChangeWalletStatus(alice.Wallet.ID, models.WalletStatusFrozen, "Under investigation", "compliance@example.com", nil)
+	require.NoError(t, err)
+	assert.Equal(t, models.WalletStatusFrozen, wallet.Status)
+	assert.Equal(t, "Under investigation", wallet.StatusReason)
+
+	_, err = useCases.Wallet.WithdrawFunds(alice.ID, 100, "USD", "status_withdraw")
+	assert.Equal(t, usecases.ErrWalletFrozen, err)
+	_, err = useCases.Wallet.TransferFunds(alice.ID, bob.ID, 100, "USD", "status_transfer_out")
+	assert.Equal(t, usecases.ErrWalletFrozen, err)
+	_, err = useCases.Wallet.PlaceHold(alice.ID, 100, "USD", time.Hour, "status_hold_2")
+	assert.Equal(t, usecases.ErrWalletFrozen, err)
+	_, err = useCases.Wallet.CaptureHold(alice.ID, hold.ID, 0)
+	assert.Equal(t, usecases.ErrWalletFrozen, err)
+
+	// Money can still come in, and holds can still be released
+	_, err = useCases.Wallet.FundWallet(alice.ID, 500, "USD", "status_fund_2")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(bob.ID, 1000, "USD", "status_fund_bob")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.TransferFunds(bob.ID, alice.ID, 1000, "USD", "status_transfer_in")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.VoidHold(alice.ID, hold.ID)
+	require.NoError(t, err)
+
+	wallet, err = useCases.Wallet.ChangeWalletStatus(alice.Wallet.ID, models.WalletStatusActive, "Cleared", "compliance@example.com", nil)
+	require.NoError(t, err)
+	assert.Equal(t, models.WalletStatusActive, wallet.Status)
+
+	_, err = useCases.Wallet.WithdrawFunds(alice.ID, 100, "USD", "status_withdraw")
+	require.NoError(t, err)
+
+	changes, err := useCases.Wallet.ListWalletStatusChanges(alice.Wallet.ID)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	assert.Equal(t, models.WalletStatusActive, changes[0].FromStatus)
+	assert.Equal(t, models.WalletStatusFrozen, changes[0].ToStatus)
+	assert.Equal(t, "compliance@example.com", changes[0].Actor)
+	assert.Equal(t, models.WalletStatusActive, changes[1].ToStatus)
+
+	stored, err := repos.Wallet.GetByID(alice.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(11400), stored.Balance)
+}
+
+func TestWalletStatus_SuspendedBlocksEverything(t *testing.T) {
+	useCases, _ := setupUseCases()
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	bob, err := useCases.User.CreateUser("Bob", "bob@example.com")
+	require.NoError(t, err)
+	transaction, err := useCases.Wallet.FundWallet(bob.ID, 5000, "USD", "suspend_fund")
+	require.NoError(t, err)
+	transfer, err := useCases.Wallet.TransferFunds(bob.ID, alice.ID, 1000, "USD", "suspend_transfer")
+	require.NoError(t, err)
+
+	_, err = useCases.Wallet.ChangeWalletStatus(alice.Wallet.ID, models.WalletStatusSuspended, "Fraud report", "ops@example.com", nil)
+	require.NoError(t, err)
+
+	_, err = useCases.Wallet.FundWallet(alice.ID, 100, "USD", "suspend_fund_2")
+	assert.Equal(t, usecases.ErrWalletSuspended, err)
+	_, err = useCases.Wallet.TransferFunds(bob.ID, alice.ID, 100, "USD", "suspend_transfer_in")
+	assert.Equal(t, usecases.ErrWalletSuspended, err)
+	_, err = useCases.Wallet.TransferFunds(alice.ID, bob.ID, 100, "USD", "suspend_transfer_out")
+	assert.Equal(t, usecases.ErrWalletSuspended, err)
+
+	// Refunding the transfer would take money out of the suspended wallet
+	_, err = useCases.Wallet.ReverseTransaction(transfer.ID, "suspend_reverse")
+	assert.Equal(t, usecases.ErrWalletSuspended, err)
+
+	// Bob's own wallet is unaffected
+	_, err = useCases.Wallet.RefundTransaction(transaction.ID, 100, "suspend_refund")
+	require.NoError(t, err)
+}
+
+func TestWalletStatus_CloseRequiresEmptyWalletOrSweep(t *testing.T) {
+	useCases, repos := setupUseCases()
+
+	alice, err := useCases.User.CreateUser("Alice", "alice@example.com")
+	require.NoError(t, err)
+	bob, err := useCases.User.CreateUser("Bob", "bob@example.com")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.FundWallet(alice.ID, 2500, "USD", "close_fund")
+	require.NoError(t, err)
+	eurWallet, err := useCases.Wallet.OpenWallet(bob.ID, "EUR")
+	require.NoError(t, err)
+
+	_, err = useCases.Wallet.ChangeWalletStatus(alice.Wallet.ID, models.WalletStatusClosed, "Customer request", "support@example.com", nil)
+	assert.Equal(t, usecases.ErrWalletNotEmpty, err)
+
+	// The sweep wallet must be in the same currency
+	_, err = useCases.Wallet.ChangeWalletStatus(alice.Wallet.ID, models.WalletStatusClosed, "Customer request", "support@example.com", &eurWallet.ID)
+	assert.Equal(t, usecases.ErrCurrencyMismatch, err)
+
+	// Money left in a pocket would be stranded behind a closed primary wallet
+	pocket, err := useCases.Wallet.CreatePocket(alice.ID, "Savings", "USD")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.MoveFunds(alice.ID, alice.Wallet.ID, pocket.ID, 500, "close_to_pocket")
+	require.NoError(t, err)
+	_, err = useCases.Wallet.ChangeWalletStatus(alice.Wallet.ID, models.WalletStatusClosed, "Customer request", "support@example.com", &bob.Wallet.ID)
+	assert.Equal(t, usecases.ErrPocketsNotEmpty, err)
+	_, err = useCases.Wallet.MoveFunds(alice.ID, pocket.ID, alice.Wallet.ID, 500, "close_from_pocket")
+	require.NoError(t, err)
+
+	wallet, err := useCases.Wallet.ChangeWalletStatus(alice.Wallet.ID, models.WalletStatusClosed, "Customer request", "support@example.com", &bob.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.WalletStatusClosed, wallet.Status)
+	assert.Equal(t, int64(0), wallet.Balance)
+
+	bobWallet, err := repos.Wallet.GetByID(bob.Wallet.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2500), bobWallet.Balance)
+
+	changes, err := useCases.Wallet.ListWalletStatusChanges(alice.Wallet.ID)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.NotNil(t, changes[0].SweepTransactionID)
+
+	// Closed is final
+	_, err = useCases.Wallet.ChangeWalletStatus(alice.Wallet.ID, models.WalletStatusActive, "Reopen", "support@example.com", nil)
+	assert.Equal(t, usecases.ErrWalletClosed, err)
+	_, err = useCases.Wallet.FundWallet(alice.ID, 100, "USD", "close_fund_2")
+	assert.Equal(t, usecases.ErrWalletClosed, err)
+	_, err = useCases.Wallet.OpenWallet(alice.ID, "USD")
+	assert.Equal(t, usecases.ErrWalletClosed, err)
+
+	results, err := useCases.Reconciliation.RunReconciliation()
+	require.NoError(t, err)
+	for _, result := range results {
+		assert.False(t, result.HasMismatch, result.UserID)
+	}
+}